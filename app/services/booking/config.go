@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -17,6 +19,24 @@ type Config struct {
 	PostgresPassword       string `split_words:"true" default:"class_booking" desc:"postgres password"`
 	PostgresPort           int    `split_words:"true" default:"5432" desc:"postgres port number"`
 	PostgresSSLMode        string `split_words:"true" default:"none" desc:"postgres connection ssl mode"`
+
+	CheckInOpensBefore  time.Duration `split_words:"true" default:"30m" desc:"how long before a session starts members can check in"`
+	CheckInClosesAfter  time.Duration `split_words:"true" default:"15m" desc:"how long after a session ends members can still check in"`
+	NoShowSweepInterval time.Duration `split_words:"true" default:"5m" desc:"how often bookings without check-in are marked as no-show"`
+	HoldTTL             time.Duration `split_words:"true" default:"10m" desc:"how long a booking hold keeps its spots before it has to be confirmed"`
+	HoldSweepInterval   time.Duration `split_words:"true" default:"1m" desc:"how often expired booking holds are released"`
+
+	EventPublishInterval time.Duration `split_words:"true" default:"10s" desc:"how often stored member events are published"`
+
+	AdminToken string `split_words:"true" desc:"token admins send in the X-Admin-Token header to override booking checks (overrides are disabled without it)"`
+
+	FreeCancelCutoff  time.Duration `split_words:"true" default:"12h" desc:"how long before a session starts cancelling stops being free, unless the class has its own policy"`
+	LateCancelPenalty string        `split_words:"true" default:"strike" desc:"penalty for late cancellations (none, strike or credit), unless the class has its own policy"`
+
+	MaxActiveBookings          int `split_words:"true" default:"0" desc:"how many upcoming bookings a member can hold, unless their plan says otherwise (0 for no limit)"`
+	MaxBookingsPerDay          int `split_words:"true" default:"0" desc:"how many sessions a member can book on the same day, unless their plan says otherwise (0 for no limit)"`
+	MaxBookingsPerClassPerWeek int `split_words:"true" default:"0" desc:"how many sessions of a class a member can book in a week, unless their plan says otherwise (0 for no limit)"`
+	MaxGuestsPerBooking        int `split_words:"true" default:"2" desc:"how many guests a member can bring to a session, unless their plan says otherwise (-1 for no limit)"`
 }
 
 func loadConfig() (Config, error) {
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// adminTokenHeader is the header admins send the configured admin token in.
+const adminTokenHeader = "X-Admin-Token"
+
+var errAdminOnly = errors.New("only admins can override booking checks")
+
+// isAdmin tells whether the request carries the admin token. Nobody is an admin while the token
+// isn't configured.
+func (h *Handler) isAdmin(c *gin.Context) bool {
+	token := c.GetHeader(adminTokenHeader)
+	return h.cfg.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.AdminToken)) == 1
+}
+
+// extractAllowOverlap returns whether the allowOverlap query param asks to skip the check of
+// overlapping bookings, which only admins can do. It returns errAdminOnly for anyone else asking.
+func (h *Handler) extractAllowOverlap(c *gin.Context) (bool, error) {
+	param := c.Query("allowOverlap")
+	if param == "" {
+		return false, nil
+	}
+
+	allowOverlap, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, fmt.Errorf("invalid query param allowOverlap %s: %w", param, err)
+	}
+
+	if allowOverlap && !h.isAdmin(c) {
+		return false, errAdminOnly
+	}
+
+	return allowOverlap, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CheckIn(c *gin.Context) {
+	bookingID := c.Param("id")
+	if bookingID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	booking, err := h.cfg.BookingUsecase.CheckIn(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, bookings.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("booking with ID %s not found", bookingID)})
+			return
+		}
+		if errors.Is(err, bookings.ErrCheckInClosed) || errors.Is(err, bookings.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to check in booking", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check in booking"})
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
+
+func (h *Handler) MarkAttendance(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	classDate, err := time.Parse(classDateLayout, c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("path param date must be formatted as %s", classDateLayout)})
+		return
+	}
+
+	var attendance bookings.Attendance
+	err = c.BindJSON(&attendance)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind attendance", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	attended, err := h.cfg.BookingUsecase.MarkAttendance(ctx, classID, classDate, attendance)
+	if err != nil {
+		if errors.Is(err, bookings.ErrClassNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
+			return
+		}
+		if errors.Is(err, bookings.ErrMemberNotBooked) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, bookings.ErrCheckInClosed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to mark attendance", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark attendance"})
+		return
+	}
+
+	c.JSON(http.StatusOK, attended)
+}
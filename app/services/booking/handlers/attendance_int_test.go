@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_CheckIn(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+
+	resp, err := httpClient.Post(fmt.Sprintf("%s/bookings/%s/check-in", serverURL, booking.ID), "application/json", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var checkedIn bookings.Booking
+	err = json.Unmarshal(respBody, &checkedIn)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusAttended, checkedIn.Status)
+}
+
+func TestHandler_CheckIn_WindowClosed(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate.Add(time.Hour * 24 * 5),
+	})
+
+	resp, err := httpClient.Post(fmt.Sprintf("%s/bookings/%s/check-in", serverURL, booking.ID), "application/json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestHandler_MarkAttendance(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+	notBooked := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+
+	url := fmt.Sprintf("%s/classes/%s/sessions/%s/attendance", serverURL, class.ID, class.StartDate.Format("2006-01-02"))
+
+	requestBytes, err := json.Marshal(bookings.Attendance{MemberIDs: []string{member.ID, notBooked.ID}})
+	require.NoError(t, err)
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	requestBytes, err = json.Marshal(bookings.Attendance{MemberIDs: []string{member.ID}})
+	require.NoError(t, err)
+	resp, err = httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var attended []bookings.Booking
+	err = json.Unmarshal(respBody, &attended)
+	require.NoError(t, err)
+	require.Len(t, attended, 1)
+	assert.Equal(t, member.ID, attended[0].MemberID)
+	assert.Equal(t, bookings.StatusAttended, attended[0].Status)
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ClassAvailability(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	from, to, err := extractDateRange(c, defaultSessionsWindow)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	availability, err := h.cfg.BookingUsecase.Availability(ctx, classID, from, to)
+	if err != nil {
+		if errors.Is(err, bookings.ErrClassNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to get class availability", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get class availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
@@ -0,0 +1,55 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ClassAvailability(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	tomorrow := class.StartDate.AddDate(0, 0, 1)
+	BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: tomorrow,
+	})
+
+	url := fmt.Sprintf("%s/classes/%s/availability?from=%s&to=%s", serverURL, class.ID,
+		tomorrow.Format("2006-01-02"), tomorrow.AddDate(0, 0, 1).Format("2006-01-02"))
+	resp, err := httpClient.Get(url)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var availability []bookings.Availability
+	err = json.Unmarshal(respBody, &availability)
+	require.NoError(t, err)
+	require.Len(t, availability, 2)
+	assert.Equal(t, class.Capacity, availability[0].Capacity)
+	assert.Equal(t, 1, availability[0].Booked)
+	assert.Equal(t, class.Capacity-1, availability[0].SpotsLeft)
+	assert.True(t, availability[0].BookingOpen)
+	assert.Equal(t, 0, availability[1].Booked)
+}
+
+func TestHandler_ClassAvailability_NotFound(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s/classes/%s/availability", serverURL, uuid.NewString()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
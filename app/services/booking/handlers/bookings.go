@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
 	"github.com/gin-gonic/gin"
 )
 
@@ -23,6 +26,16 @@ func (h *Handler) BookClass(c *gin.Context) {
 		return
 	}
 
+	bookClass.AllowOverlap, err = h.extractAllowOverlap(c)
+	if err != nil {
+		if errors.Is(err, errAdminOnly) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	booking, err := h.cfg.BookingUsecase.BookClass(ctx, bookClass)
@@ -31,6 +44,10 @@ func (h *Handler) BookClass(c *gin.Context) {
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 			return
 		}
+		if conflict, ok := bookingConflict(err); ok {
+			c.JSON(http.StatusConflict, conflict)
+			return
+		}
 		h.cfg.Logger.Errorw("failed to add new booking", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add new booking"})
 		return
@@ -39,8 +56,31 @@ func (h *Handler) BookClass(c *gin.Context) {
 	c.JSON(http.StatusCreated, booking)
 }
 
+// bookingConflict returns the body of the conflict response to errors of booking a class date,
+// telling whether err is one of them.
+func bookingConflict(err error) (gin.H, bool) {
+	var alreadyBooked *bookings.AlreadyBookedError
+	if errors.As(err, &alreadyBooked) {
+		return gin.H{"error": bookings.ErrAlreadyBooked.Error(), "bookingID": alreadyBooked.BookingID}, true
+	}
+	var scheduleConflict *bookings.ScheduleConflictError
+	if errors.As(err, &scheduleConflict) {
+		return gin.H{"error": bookings.ErrScheduleConflict.Error(), "conflictingBooking": scheduleConflict.Booking}, true
+	}
+	var limitReached *bookings.LimitReachedError
+	if errors.As(err, &limitReached) {
+		return gin.H{"error": err.Error(), "limit": limitReached.Limit, "max": limitReached.Max}, true
+	}
+	if errors.Is(err, bookings.ErrClassFull) || errors.Is(err, bookings.ErrSessionCancelled) || errors.Is(err, bookings.ErrAlreadyHeld) ||
+		errors.Is(err, bookings.ErrClassArchived) {
+		return gin.H{"error": err.Error()}, true
+	}
+	return nil, false
+}
+
 func isInvalidBookingDataErr(err error) bool {
-	return errors.Is(err, bookings.ErrMemberNotFound) || errors.Is(err, bookings.ErrClassNotFound) || errors.Is(err, bookings.ErrInvalidClassDate)
+	return errors.Is(err, bookings.ErrMemberNotFound) || errors.Is(err, bookings.ErrClassNotFound) || errors.Is(err, bookings.ErrInvalidClassDate) ||
+		errors.Is(err, bookings.ErrInvalidGuests)
 }
 
 func (h *Handler) GetBookingByID(c *gin.Context) {
@@ -50,18 +90,25 @@ func (h *Handler) GetBookingByID(c *gin.Context) {
 		return
 	}
 
-	ctx := c.Request.Context()
-	booking, err := h.cfg.BookingUsecase.GetByID(ctx, bookingID)
+	statuses, err := extractStatuses(c)
 	if err != nil {
-		if errors.Is(err, bookings.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("booking with ID %s not found", bookingID)})
-			return
-		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
+	ctx := c.Request.Context()
+	booking, err := h.cfg.BookingUsecase.GetByID(ctx, bookingID)
+	if err != nil && !errors.Is(err, bookings.ErrNotFound) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get booking. Retry later"})
 		return
 	}
 
+	if err != nil || !hasStatus(booking, statuses) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("booking with ID %s not found", bookingID)})
+		return
+	}
+
+	setETag(c, booking.Version)
 	c.JSON(http.StatusOK, booking)
 }
 
@@ -74,26 +121,46 @@ func (h *Handler) DeleteBooking(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	err := h.cfg.BookingUsecase.DeleteBooking(ctx, bookingID)
+	cancellation, err := h.cfg.BookingUsecase.CancelBooking(ctx, bookingID)
 	if err != nil {
+		if errors.Is(err, bookings.ErrNotFound) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		if errors.Is(err, bookings.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.cfg.Logger.Debugw("failed to delete booking: %w", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete booking"})
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, cancellation)
 }
 
 func (h *Handler) ListBookings(c *gin.Context) {
+	h.listBookings(c, func(filter *bookings.Filter) {})
+}
+
+// listBookings lists the bookings matching the query filters, after scope narrows them down to the route.
+func (h *Handler) listBookings(c *gin.Context, scope func(filter *bookings.Filter)) {
 	pageInfo, err := h.extractBookingsPageInfo(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
 		return
 	}
 
+	filter, err := h.extractBookingsFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scope(&filter)
+
 	ctx := c.Request.Context()
 
-	allBookings, err := h.cfg.BookingUsecase.ListBookings(ctx, pageInfo)
+	allBookings, err := h.cfg.BookingUsecase.ListBookings(ctx, filter, pageInfo)
 	if err != nil {
 		h.cfg.Logger.Debugw("failed to list bookings: %w", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list bookings"})
@@ -132,3 +199,120 @@ func (h *Handler) extractBookingsPageInfo(c *gin.Context) (bookings.PageInfo, er
 		Page:  page,
 	}, nil
 }
+
+// ListMemberBookings lists the bookings of a member, accepting the same filters as ListBookings.
+func (h *Handler) ListMemberBookings(c *gin.Context) {
+	memberID := c.Param("id")
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	_, err := h.cfg.MembersUsecase.GetByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, members.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("member with ID %s not found", memberID)})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to get member", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list bookings"})
+		return
+	}
+
+	h.listBookings(c, func(filter *bookings.Filter) {
+		filter.MemberID = memberID
+	})
+}
+
+// ListClassBookings lists the bookings of a class, accepting the same filters as ListBookings.
+func (h *Handler) ListClassBookings(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	_, err := h.cfg.ClassesUsecase.GetByID(ctx, classID)
+	if err != nil {
+		if errors.Is(err, classes.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to get class", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list bookings"})
+		return
+	}
+
+	h.listBookings(c, func(filter *bookings.Filter) {
+		filter.ClassID = classID
+	})
+}
+
+func (h *Handler) extractBookingsFilter(c *gin.Context) (bookings.Filter, error) {
+	statuses, err := extractStatuses(c)
+	if err != nil {
+		return bookings.Filter{}, err
+	}
+
+	filter := bookings.Filter{
+		MemberID: c.Query("memberID"),
+		ClassID:  c.Query("classID"),
+		Statuses: statuses,
+	}
+
+	if c.Query("from") != "" {
+		from, err := time.Parse(classDateLayout, c.Query("from"))
+		if err != nil {
+			return bookings.Filter{}, fmt.Errorf("query param from must be formatted as %s", classDateLayout)
+		}
+		filter.From = &from
+	}
+
+	if c.Query("to") != "" {
+		to, err := time.Parse(classDateLayout, c.Query("to"))
+		if err != nil {
+			return bookings.Filter{}, fmt.Errorf("query param to must be formatted as %s", classDateLayout)
+		}
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return bookings.Filter{}, errors.New("query param to cannot be before from")
+	}
+
+	return filter, nil
+}
+
+// extractStatuses reads the status query param, which can be repeated or hold a comma separated list.
+func extractStatuses(c *gin.Context) ([]bookings.Status, error) {
+	statuses := make([]bookings.Status, 0)
+	for _, param := range c.QueryArray("status") {
+		for _, value := range strings.Split(param, ",") {
+			status := bookings.Status(strings.TrimSpace(value))
+			if !status.Valid() {
+				return nil, fmt.Errorf("invalid booking status: %s", value)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses, nil
+}
+
+func hasStatus(booking bookings.Booking, statuses []bookings.Status) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+
+	for _, status := range statuses {
+		if booking.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -37,7 +38,36 @@ func TestHandler_BookClass(t *testing.T) {
 	assert.NotEmpty(t, booking.UpdatedAt)
 	assert.Equal(t, class.ID, booking.ClassID)
 	assert.Equal(t, member.ID, booking.MemberID)
-	assert.Equal(t, bookClass.ClassDate.Truncate(time.Hour*24), booking.ClassDate)
+	assert.Equal(t, class.LocalDate(bookClass.ClassDate), booking.ClassDate)
+}
+
+func TestHandler_BookClass_DateInClassTimezone(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	now := time.Now().In(saoPaulo)
+	class := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), classes.NewClass{
+		Name:      uuid.NewString(),
+		StartDate: now,
+		EndDate:   now.AddDate(0, 0, 10),
+		Capacity:  30,
+		Timezone:  "America/Sao_Paulo",
+	})
+	assert.Equal(t, "America/Sao_Paulo", class.Timezone)
+
+	member := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+
+	// 23:30 in Sao Paulo is already the next day in UTC.
+	lateEvening := time.Date(now.Year(), now.Month(), now.Day()+1, 23, 30, 0, 0, saoPaulo)
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: lateEvening.UTC(),
+	})
+
+	assert.Equal(t, time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC), booking.ClassDate)
 }
 
 func TestHandler_BookClass_InvalidData(t *testing.T) {
@@ -69,6 +99,211 @@ func TestHandler_BookClass_InvalidData(t *testing.T) {
 	assert.Empty(t, booking.ID)
 }
 
+func TestHandler_BookClass_ConcurrentBookingsRespectCapacity(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/bookings", serverURL)
+
+	newClass := classes.NewClass{
+		Name:      uuid.NewString(),
+		StartDate: time.Now().UTC(),
+		EndDate:   time.Now().UTC().Add(time.Hour * 24 * 10),
+		Capacity:  3,
+	}
+	class := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), newClass)
+
+	attempts := 10
+	requests := make([][]byte, 0, attempts)
+	for i := 0; i < attempts; i++ {
+		member := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+		requestBytes, err := json.Marshal(bookings.BookClass{
+			MemberID:  member.ID,
+			ClassID:   class.ID,
+			ClassDate: time.Now().UTC(),
+		})
+		require.NoError(t, err)
+		requests = append(requests, requestBytes)
+	}
+
+	var wg sync.WaitGroup
+	statusCodes := make(chan int, attempts)
+	for _, requestBytes := range requests {
+		wg.Add(1)
+		go func(requestBytes []byte) {
+			defer wg.Done()
+			resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+			if err != nil {
+				statusCodes <- 0
+				return
+			}
+			defer resp.Body.Close()
+			statusCodes <- resp.StatusCode
+		}(requestBytes)
+	}
+	wg.Wait()
+	close(statusCodes)
+
+	var created, conflicts int
+	for statusCode := range statusCodes {
+		switch statusCode {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+			conflicts++
+		default:
+			t.Errorf("unexpected status code %d", statusCode)
+		}
+	}
+
+	assert.Equal(t, newClass.Capacity, created)
+	assert.Equal(t, attempts-newClass.Capacity, conflicts)
+}
+
+func TestHandler_BookClass_AlreadyBooked(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/bookings", serverURL)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+
+	bookClass := bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: time.Now().UTC(),
+	}
+	booking := BookClass(t, httpClient, url, bookClass)
+
+	requestBytes, err := json.Marshal(bookClass)
+	require.NoError(t, err)
+
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var conflict struct {
+		BookingID string `json:"bookingID"`
+	}
+	err = json.Unmarshal(respBody, &conflict)
+	require.NoError(t, err)
+	assert.Equal(t, booking.ID, conflict.BookingID)
+}
+
+func TestHandler_BookClass_ScheduleConflict(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/bookings", serverURL)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	otherClass, _ := PrepareToBookClass(t, httpClient, serverURL)
+
+	classDate := class.StartDate.AddDate(0, 0, 1)
+	booking := BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: classDate,
+	})
+
+	overlapping := bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   otherClass.ID,
+		ClassDate: classDate,
+	}
+	requestBytes, err := json.Marshal(overlapping)
+	require.NoError(t, err)
+
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var conflict struct {
+		ConflictingBooking bookings.Booking `json:"conflictingBooking"`
+	}
+	err = json.Unmarshal(respBody, &conflict)
+	require.NoError(t, err)
+	assert.Equal(t, booking.ID, conflict.ConflictingBooking.ID)
+
+	overrideURL := fmt.Sprintf("%s?allowOverlap=true", url)
+	resp, err = httpClient.Post(overrideURL, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodPost, overrideURL, bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Token", adminToken)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestHandler_BookClass_Guests(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/bookings", serverURL)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+
+	booking := BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:   member.ID,
+		ClassID:    class.ID,
+		ClassDate:  class.StartDate,
+		GuestNames: []string{"Ana", "Bia"},
+	})
+	assert.Equal(t, 2, booking.Guests)
+	assert.Equal(t, []string{"Ana", "Bia"}, booking.GuestNames)
+
+	_, otherMember := PrepareToBookClass(t, httpClient, serverURL)
+	requestBytes, err := json.Marshal(bookings.BookClass{
+		MemberID:  otherMember.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+		Guests:    -1,
+	})
+	require.NoError(t, err)
+
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestHandler_BookClass_DateNotAnOccurrence(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	today := time.Now().UTC()
+	newClass := classes.NewClass{
+		Name:      uuid.NewString(),
+		StartDate: today,
+		EndDate:   today.Add(time.Hour * 24 * 30),
+		Capacity:  10,
+		Recurrence: &classes.Recurrence{
+			Frequency:       classes.FrequencyWeekly,
+			StartTime:       "18:00",
+			DurationMinutes: 60,
+		},
+	}
+	class := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), newClass)
+	member := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+
+	requestBytes, err := json.Marshal(bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: today.Add(time.Hour * 24),
+	})
+	require.NoError(t, err)
+
+	resp, err := httpClient.Post(fmt.Sprintf("%s/bookings", serverURL), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: today.Add(time.Hour * 24 * 7),
+	})
+}
+
 func TestHandler_GetBooking(t *testing.T) {
 	serverURL, httpClient := setupIntegration(t)
 	url := fmt.Sprintf("%s/bookings", serverURL)
@@ -126,7 +361,85 @@ func TestHandler_DeleteBooking(t *testing.T) {
 
 	resp, err := httpClient.Do(req)
 	require.NoError(t, err)
-	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var cancellation bookings.Cancellation
+	err = json.Unmarshal(respBody, &cancellation)
+	require.NoError(t, err)
+	assert.Equal(t, classBooked.ID, cancellation.Booking.ID)
+	assert.Equal(t, bookings.PolicySourceStudio, cancellation.PolicySource)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s?status=cancelled", bookingURL))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var booking bookings.Booking
+	err = json.Unmarshal(respBody, &booking)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusCancelled, booking.Status)
+	assert.NotNil(t, booking.CancelledAt)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s?status=booked", bookingURL))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_DeleteBooking_LateCancelPenalty(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	newClass := classes.NewClass{
+		Name:      uuid.NewString(),
+		StartDate: time.Now().UTC().Add(time.Hour),
+		EndDate:   time.Now().UTC().Add(time.Hour * 24 * 10),
+		Capacity:  10,
+		CancellationPolicy: &classes.CancellationPolicy{
+			FreeCancelCutoffMinutes: 12 * 60,
+			LateCancelPenalty:       classes.PenaltyStrike,
+		},
+	}
+	class := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), newClass)
+	member := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+
+	classBooked := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/bookings/%s", serverURL, classBooked.ID), nil)
+	require.NoError(t, err)
+
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var cancellation bookings.Cancellation
+	err = json.Unmarshal(respBody, &cancellation)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.PolicySourceClass, cancellation.PolicySource)
+	assert.True(t, cancellation.Late)
+	assert.Equal(t, classes.PenaltyStrike, cancellation.Penalty)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/members/%s", serverURL, member.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var penalizedMember members.Member
+	err = json.Unmarshal(respBody, &penalizedMember)
+	require.NoError(t, err)
+	assert.Equal(t, 1, penalizedMember.Strikes)
 }
 
 func TestHandler_DeleteNonExistingBooking(t *testing.T) {
@@ -177,6 +490,48 @@ func TestHandler_ListBookings(t *testing.T) {
 	assert.Equal(t, 2, len(allBookings))
 }
 
+func TestHandler_ListBookings_FilterByStatus(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/bookings", serverURL)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	cancelled := BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: time.Now().UTC(),
+	})
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", url, cancelled.ID), nil)
+	require.NoError(t, err)
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	class, member = PrepareToBookClass(t, httpClient, serverURL)
+	booked := BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: time.Now().UTC(),
+	})
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s?status=booked", url))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var allBookings []bookings.Booking
+	err = json.Unmarshal(respBody, &allBookings)
+	require.NoError(t, err)
+	require.Len(t, allBookings, 1)
+	assert.Equal(t, booked.ID, allBookings[0].ID)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s?status=unknown", url))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 func TestHandler_ListBookings_EmptyList(t *testing.T) {
 	serverURL, httpClient := setupIntegration(t)
 	url := fmt.Sprintf("%s/bookings", serverURL)
@@ -196,6 +551,70 @@ func TestHandler_ListBookings_EmptyList(t *testing.T) {
 	assert.Equal(t, 0, len(allBookings))
 }
 
+func TestHandler_ListBookings_FilterByMemberAndDates(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/bookings", serverURL)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+	nextWeek := BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate.AddDate(0, 0, 7),
+	})
+
+	_, otherMember := PrepareToBookClass(t, httpClient, serverURL)
+	BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:  otherMember.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate.AddDate(0, 0, 7),
+	})
+
+	from := class.StartDate.AddDate(0, 0, 7).Format("2006-01-02")
+	to := class.StartDate.AddDate(0, 0, 9).Format("2006-01-02")
+	for _, listURL := range []string{
+		fmt.Sprintf("%s?memberID=%s&from=%s&to=%s", url, member.ID, from, to),
+		fmt.Sprintf("%s/members/%s/bookings?from=%s&to=%s", serverURL, member.ID, from, to),
+	} {
+		resp, err := httpClient.Get(listURL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		var allBookings []bookings.Booking
+		err = json.Unmarshal(respBody, &allBookings)
+		require.NoError(t, err)
+		require.Len(t, allBookings, 1)
+		assert.Equal(t, nextWeek.ID, allBookings[0].ID)
+	}
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s/classes/%s/bookings?status=booked", serverURL, class.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var classBookings []bookings.Booking
+	err = json.Unmarshal(respBody, &classBookings)
+	require.NoError(t, err)
+	assert.Len(t, classBookings, 3)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s?from=%s&to=%s", url, to, from))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/members/%s/bookings", serverURL, uuid.NewString()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func PrepareToBookClass(t *testing.T, httpClient *http.Client, url string) (classes.Class, members.Member) {
 	newClass := classes.NewClass{
 		Name:      uuid.NewString(),
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/gin-gonic/gin"
+)
+
+// Calendar lists the occurrences of every class between the 'from' and 'to' query dates,
+// optionally only the ones at a 'location' or taught by an 'instructor'. The service has no
+// authentication yet, so the member whose bookings are shown is taken from the 'memberID' query.
+func (h *Handler) Calendar(c *gin.Context) {
+	from, to, err := extractDateRange(c, defaultSessionsWindow)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := bookings.CalendarFilter{
+		LocationID:   c.Query("location"),
+		InstructorID: c.Query("instructor"),
+		MemberID:     c.Query("memberID"),
+	}
+
+	ctx := c.Request.Context()
+
+	occurrences, err := h.cfg.BookingUsecase.Calendar(ctx, from, to, filter)
+	if err != nil {
+		if errors.Is(err, bookings.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, bookings.ErrMemberNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("member with ID %s not found", filter.MemberID)})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to list calendar", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list calendar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, occurrences)
+}
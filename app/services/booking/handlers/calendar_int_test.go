@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_Calendar(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	instructor := CreateNewInstructor(t, httpClient, fmt.Sprintf("%s/instructors", serverURL), instructors.NewInstructor{
+		Name: uuid.NewString(),
+	})
+	taughtClass := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), classes.NewClass{
+		Name:         uuid.NewString(),
+		StartDate:    class.StartDate.Add(time.Hour),
+		EndDate:      class.EndDate,
+		Capacity:     5,
+		InstructorID: &instructor.ID,
+	})
+
+	tomorrow := class.LocalDate(class.StartDate.AddDate(0, 0, 1))
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: tomorrow,
+	})
+
+	day := tomorrow.Format("2006-01-02")
+	occurrences := getCalendar(t, httpClient, fmt.Sprintf("%s/calendar?from=%s&to=%s&memberID=%s", serverURL, day, day, member.ID))
+	require.Len(t, occurrences, 2)
+
+	assert.Equal(t, class.ID, occurrences[0].ClassID)
+	assert.Equal(t, class.Capacity, occurrences[0].Capacity)
+	assert.Equal(t, class.Capacity-1, occurrences[0].SpotsLeft)
+	assert.Equal(t, booking.ID, occurrences[0].MemberBookingID)
+	assert.Equal(t, bookings.StatusBooked, occurrences[0].MemberBookingStatus)
+	assert.True(t, occurrences[0].EndsAt.After(occurrences[0].StartsAt))
+
+	assert.Equal(t, taughtClass.ID, occurrences[1].ClassID)
+	assert.Equal(t, 5, occurrences[1].SpotsLeft)
+	assert.Empty(t, occurrences[1].MemberBookingID)
+
+	occurrences = getCalendar(t, httpClient, fmt.Sprintf("%s/calendar?from=%s&to=%s&instructor=%s", serverURL, day, day, instructor.ID))
+	require.Len(t, occurrences, 1)
+	assert.Equal(t, taughtClass.ID, occurrences[0].ClassID)
+	require.NotNil(t, occurrences[0].InstructorID)
+	assert.Equal(t, instructor.ID, *occurrences[0].InstructorID)
+}
+
+func TestHandler_Calendar_InvalidRange(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s/calendar?from=2024-01-01&to=2024-06-01", serverURL))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHandler_Calendar_MemberNotFound(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s/calendar?memberID=%s", serverURL, uuid.NewString()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func getCalendar(t *testing.T, httpClient *http.Client, url string) []bookings.Occurrence {
+	resp, err := httpClient.Get(url)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var occurrences []bookings.Occurrence
+	err = json.Unmarshal(respBody, &occurrences)
+	require.NoError(t, err)
+	return occurrences
+}
@@ -31,6 +31,10 @@ func (h *Handler) AddClass(c *gin.Context) {
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, classes.ErrInstructorConflict) || errors.Is(err, classes.ErrRoomConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.cfg.Logger.Errorw("failed to add new class", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add new class"})
 		return
@@ -58,6 +62,7 @@ func (h *Handler) GetClassByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, class.Version)
 	c.JSON(http.StatusOK, class)
 }
 
@@ -82,20 +87,45 @@ func (h *Handler) UpdateClass(c *gin.Context) {
 		return
 	}
 
+	updateClass.IfVersions, err = extractIfMatch(c)
+	if err != nil {
+		c.JSON(ifMatchErrStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	updatedClass, err := h.cfg.ClassesUsecase.UpdateClass(ctx, classID, updateClass)
 	if err != nil {
 		if errors.Is(err, classes.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
 			return
 		}
+		if errors.Is(err, classes.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, classes.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, classes.ErrCapacityBelowBookings) || errors.Is(err, classes.ErrBookingsOutsideSchedule) ||
+			errors.Is(err, classes.ErrInstructorConflict) || errors.Is(err, classes.ErrRoomConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.cfg.Logger.Debugw("failed to update class: %w", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update class"})
 		return
 	}
 
+	setETag(c, updatedClass.Version)
 	c.JSON(http.StatusOK, updatedClass)
 }
 
+// DeleteClass archives the class. With the cascade query param set it deletes the class instead,
+// cancelling its upcoming sessions along with their bookings, holds and waitlist entries. The
+// deletion is soft: the class row stays, marked as deleted, so the bookings of the class remain as
+// the members' history, but the class is gone from every endpoint. Archiving or deleting a deleted
+// class, or deleting one while its bookings change, responds with 409.
 func (h *Handler) DeleteClass(c *gin.Context) {
 	classID := c.Param("id")
 	if classID == "" {
@@ -103,10 +133,33 @@ func (h *Handler) DeleteClass(c *gin.Context) {
 		return
 	}
 
+	cascade := false
+	if cascadeStr := c.Query("cascade"); cascadeStr != "" {
+		var err error
+		cascade, err = strconv.ParseBool(cascadeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "query param 'cascade' must be a boolean"})
+			return
+		}
+	}
+
 	ctx := c.Request.Context()
 
-	err := h.cfg.ClassesUsecase.DeleteClass(ctx, classID)
+	var err error
+	if cascade {
+		err = h.cfg.ClassesUsecase.DeleteClass(ctx, classID)
+	} else {
+		_, err = h.cfg.ClassesUsecase.ArchiveClass(ctx, classID)
+	}
 	if err != nil {
+		if errors.Is(err, classes.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
+			return
+		}
+		if errors.Is(err, classes.ErrClassDeleted) || errors.Is(err, classes.ErrDeleteConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.cfg.Logger.Debugw("failed to delete class: %w", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete class"})
 		return
@@ -124,8 +177,20 @@ func (h *Handler) ListClasses(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	allClasses, err := h.cfg.ClassesUsecase.ListClasses(ctx, pageInfo)
+	filter := classes.Filter{
+		LocationID: c.Query("location"),
+		Category:   c.Query("category"),
+		Tag:        c.Query("tag"),
+		Level:      classes.Level(c.Query("level")),
+	}
+
+	allClasses, err := h.cfg.ClassesUsecase.ListClasses(ctx, filter, pageInfo)
 	if err != nil {
+		if errors.Is(err, classes.ErrInvalidData) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		h.cfg.Logger.Debugw("failed to list classes: %w", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list classes"})
 		return
@@ -134,6 +199,31 @@ func (h *Handler) ListClasses(c *gin.Context) {
 	c.JSON(http.StatusOK, allClasses)
 }
 
+// SearchClasses searches the active classes, returning a page of the best matches along with
+// facet counts over every match.
+func (h *Handler) SearchClasses(c *gin.Context) {
+	pageInfo, err := h.extractClassesPageInfo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	results, err := h.cfg.ClassesUsecase.SearchClasses(ctx, c.Query("q"), pageInfo)
+	if err != nil {
+		if errors.Is(err, classes.ErrInvalidData) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to search classes", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search classes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
 func (h *Handler) extractClassesPageInfo(c *gin.Context) (classes.PageInfo, error) {
 	pageStr := c.Query("page")
 	limitStr := c.Query("limit")
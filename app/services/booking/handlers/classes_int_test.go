@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
 	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -178,6 +179,18 @@ func TestHandler_DeleteClass(t *testing.T) {
 	resp, err := httpClient.Do(req)
 	require.NoError(t, err)
 	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = httpClient.Get(classURL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var class classes.Class
+	err = json.Unmarshal(respBody, &class)
+	require.NoError(t, err)
+	assert.NotNil(t, class.ArchivedAt)
 }
 
 func TestHandler_DeleteNonExistingClass(t *testing.T) {
@@ -187,9 +200,104 @@ func TestHandler_DeleteNonExistingClass(t *testing.T) {
 	req, err := http.NewRequest(http.MethodDelete, classURL, nil)
 	require.NoError(t, err)
 
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_DeleteClass_ArchivedClassCantBeBooked(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/classes/%s", serverURL, class.ID), nil)
+	require.NoError(t, err)
+
 	resp, err := httpClient.Do(req)
 	require.NoError(t, err)
 	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	requestBytes, err := json.Marshal(bookings.BookClass{MemberID: member.ID, ClassID: class.ID, ClassDate: class.StartDate})
+	require.NoError(t, err)
+
+	resp, err = httpClient.Post(fmt.Sprintf("%s/bookings", serverURL), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestHandler_DeleteClass_Cascade(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate.AddDate(0, 0, 1),
+	})
+
+	classURL := fmt.Sprintf("%s/classes/%s", serverURL, class.ID)
+	req, err := http.NewRequest(http.MethodDelete, classURL+"?cascade=true", nil)
+	require.NoError(t, err)
+
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = httpClient.Get(classURL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	for _, deleteURL := range []string{classURL, classURL + "?cascade=true"} {
+		req, err = http.NewRequest(http.MethodDelete, deleteURL, nil)
+		require.NoError(t, err)
+
+		resp, err = httpClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+	}
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/bookings/%s", serverURL, booking.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var bookingFound bookings.Booking
+	err = json.Unmarshal(respBody, &bookingFound)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusStudioCancelled, bookingFound.Status)
+
+	req, err = http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/bookings/%s", serverURL, booking.ID), nil)
+	require.NoError(t, err)
+
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/members/%s/events", serverURL, member.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var events []bookings.Event
+	err = json.Unmarshal(respBody, &events)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, bookings.EventSessionCancelled, events[0].Type)
+}
+
+func TestHandler_DeleteClass_InvalidCascade(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/classes/%s?cascade=maybe", serverURL, uuid.NewString()), nil)
+	require.NoError(t, err)
+
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
 func TestHandler_ListClasses(t *testing.T) {
@@ -219,6 +327,101 @@ func TestHandler_ListClasses(t *testing.T) {
 	assert.Equal(t, 2, len(allClasses))
 }
 
+func TestHandler_ListClasses_FilterByMetadata(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/classes", serverURL)
+	newClass := func(category string, level classes.Level, tags ...string) classes.NewClass {
+		return classes.NewClass{
+			Name:            uuid.NewString(),
+			StartDate:       time.Now().UTC(),
+			EndDate:         time.Now().UTC().Add(time.Hour * 24 * 10),
+			Capacity:        30,
+			Description:     "A class to browse.",
+			Category:        category,
+			Tags:            tags,
+			Level:           level,
+			DurationMinutes: 60,
+			ImageURL:        "https://images.example.com/class.png",
+			Recurrence:      &classes.Recurrence{Frequency: classes.FrequencyDaily, Interval: 1, StartTime: "18:00", DurationMinutes: 60},
+		}
+	}
+	hotYoga := CreateNewClass(t, httpClient, url, newClass("Yoga", classes.LevelBeginner, "Hot", "evening"))
+	assert.Equal(t, "yoga", hotYoga.Category)
+	assert.Equal(t, []string{"hot", "evening"}, hotYoga.Tags)
+	assert.Equal(t, "A class to browse.", hotYoga.Description)
+	assert.Equal(t, 60, hotYoga.DurationMinutes)
+	assert.Equal(t, "https://images.example.com/class.png", hotYoga.ImageURL)
+
+	yoga := CreateNewClass(t, httpClient, url, newClass("yoga", classes.LevelAdvanced, "morning"))
+	spin := CreateNewClass(t, httpClient, url, newClass("spin", classes.LevelBeginner, "evening"))
+
+	listClasses := func(query string) []string {
+		resp, err := httpClient.Get(url + query)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var allClasses []classes.Class
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&allClasses))
+
+		ids := make([]string, 0, len(allClasses))
+		for _, class := range allClasses {
+			ids = append(ids, class.ID)
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(t, []string{hotYoga.ID, yoga.ID}, listClasses("?category=yoga"))
+	assert.ElementsMatch(t, []string{hotYoga.ID, spin.ID}, listClasses("?tag=evening"))
+	assert.ElementsMatch(t, []string{hotYoga.ID, spin.ID}, listClasses("?level=beginner"))
+	assert.ElementsMatch(t, []string{hotYoga.ID}, listClasses("?category=yoga&tag=evening&level=beginner"))
+	assert.Empty(t, listClasses("?category=pilates"))
+
+	resp, err := httpClient.Get(url + "?level=expert")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHandler_SearchClasses(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/classes", serverURL)
+	newClass := func(name string, description string, category string) classes.NewClass {
+		return classes.NewClass{
+			Name:        name,
+			StartDate:   time.Now().UTC(),
+			EndDate:     time.Now().UTC().Add(time.Hour * 24 * 10),
+			Capacity:    30,
+			Description: description,
+			Category:    category,
+		}
+	}
+	vinyasa := CreateNewClass(t, httpClient, url, newClass("Vinyasa Yoga", "Breath-led flow.", "yoga"))
+	CreateNewClass(t, httpClient, url, newClass("Power Yoga", "Strength-focused flow.", "yoga"))
+	CreateNewClass(t, httpClient, url, newClass("Spin", "Cycling intervals.", "spin"))
+
+	resp, err := httpClient.Get(url + "/search?q=yoga&limit=1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var results classes.SearchResults
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+	assert.Equal(t, 2, results.Total)
+	require.Len(t, results.Results, 1)
+	assert.Contains(t, results.Results[0].Snippet, "<mark>Yoga</mark>")
+	assert.Equal(t, []classes.Facet{{Value: "yoga", Count: 2}}, results.Facets.Categories)
+
+	resp, err = httpClient.Get(url + "/search?q=breath")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, vinyasa.ID, results.Results[0].Class.ID)
+
+	resp, err = httpClient.Get(url + "/search")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 func TestHandler_ListClasses_EmptyList(t *testing.T) {
 	serverURL, httpClient := setupIntegration(t)
 	url := fmt.Sprintf("%s/classes", serverURL)
@@ -255,3 +458,135 @@ func CreateNewClass(t *testing.T, httpClient *http.Client, url string, newClass
 	require.NoError(t, err)
 	return class
 }
+
+func TestHandler_UpdateClass_IfMatch(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/classes", serverURL)
+
+	newClass := classes.NewClass{
+		Name:      uuid.NewString(),
+		StartDate: time.Now().UTC(),
+		EndDate:   time.Now().UTC().Add(time.Hour * 24 * 10),
+		Capacity:  30,
+	}
+	classCreated := CreateNewClass(t, httpClient, url, newClass)
+	assert.NotEmpty(t, classCreated.ID)
+
+	classURL := fmt.Sprintf("%s/classes/%s", serverURL, classCreated.ID)
+	resp, err := httpClient.Get(classURL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	patchClass := func(ifMatch string, capacity int) *http.Response {
+		requestBytes, err := json.Marshal(classes.UpdateClass{Capability: &capacity})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPatch, classURL, bytes.NewBuffer(requestBytes))
+		require.NoError(t, err)
+		req.Header.Set("If-Match", ifMatch)
+
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp = patchClass(etag, 40)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	resp = patchClass(etag, 10)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = patchClass(`"2`, 10)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = patchClass(`W/"2"`, 10)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = patchClass(`"1", W/"2"`, 10)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = patchClass(`"1", "2"`, 50)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"3"`, resp.Header.Get("ETag"))
+
+	patchNothing := func(ifMatch string) *http.Response {
+		req, err := http.NewRequest(http.MethodPatch, classURL, bytes.NewBufferString("{}"))
+		require.NoError(t, err)
+		req.Header.Set("If-Match", ifMatch)
+
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp = patchNothing(`"2"`)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = patchNothing(`"3"`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"3"`, resp.Header.Get("ETag"))
+
+	resp, err = httpClient.Get(classURL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var class classes.Class
+	err = json.Unmarshal(respBody, &class)
+	require.NoError(t, err)
+
+	assert.Equal(t, 50, class.Capacity)
+	assert.Equal(t, `"3"`, resp.Header.Get("ETag"))
+}
+
+func TestHandler_UpdateClass_BookingsOutsideSchedule(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate.AddDate(0, 0, 5),
+	})
+
+	classURL := fmt.Sprintf("%s/classes/%s", serverURL, class.ID)
+	patchClass := func(updateClass classes.UpdateClass) *http.Response {
+		requestBytes, err := json.Marshal(updateClass)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPatch, classURL, bytes.NewBuffer(requestBytes))
+		require.NoError(t, err)
+
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	startAfterEnd := class.EndDate.AddDate(0, 0, 1)
+	resp := patchClass(classes.UpdateClass{StartDate: &startAfterEnd})
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	newEndDate := class.StartDate.AddDate(0, 0, 2)
+	resp = patchClass(classes.UpdateClass{EndDate: &newEndDate})
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = patchClass(classes.UpdateClass{EndDate: &newEndDate, Force: classes.OrphanedBookingsCancel})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s/bookings/%s", serverURL, booking.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var cancelledBooking bookings.Booking
+	err = json.Unmarshal(respBody, &cancelledBooking)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusStudioCancelled, cancelledBooking.Status)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errMalformedIfMatch is returned for If-Match headers that aren't an entity tag.
+var errMalformedIfMatch = errors.New("malformed If-Match header")
+
+// setETag sets the ETag header to the version of the returned resource.
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// extractIfMatch returns the versions the If-Match header asks the resource to be at, one per
+// entity tag of the list. It's nil when the header is missing or matches any version. Headers that
+// aren't a list of entity tags return errMalformedIfMatch, while headers none of whose tags can
+// match a version fail as a precondition. If-Match compares entity tags strongly, so weak tags never
+// match.
+func extractIfMatch(c *gin.Context) ([]int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	versions := make([]int, 0)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		weak := strings.HasPrefix(tag, "W/")
+		tag = strings.TrimPrefix(tag, "W/")
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) || strings.Count(tag, `"`) != 2 {
+			return nil, fmt.Errorf("%w %s: not a list of entity tags", errMalformedIfMatch, header)
+		}
+
+		version, err := strconv.Atoi(strings.Trim(tag, `"`))
+		if weak || err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("If-Match header %s doesn't match any version of the resource", header)
+	}
+
+	return versions, nil
+}
+
+// ifMatchErrStatus returns the status of the response to an error extracting the If-Match header.
+func ifMatchErrStatus(err error) int {
+	if errors.Is(err, errMalformedIfMatch) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusPreconditionFailed
+}
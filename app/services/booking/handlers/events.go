@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListMemberEvents(c *gin.Context) {
+	memberID := c.Param("id")
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	pageInfo, err := h.extractBookingsPageInfo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	events, err := h.cfg.BookingUsecase.ListMemberEvents(ctx, memberID, pageInfo)
+	if err != nil {
+		if errors.Is(err, bookings.ErrMemberNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("member with ID %s not found", memberID)})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to list member events", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list member events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) HoldClass(c *gin.Context) {
+	var bookClass bookings.BookClass
+	err := c.BindJSON(&bookClass)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind booking hold", "error", err.Error())
+		return
+	}
+
+	bookClass.AllowOverlap, err = h.extractAllowOverlap(c)
+	if err != nil {
+		if errors.Is(err, errAdminOnly) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	hold, err := h.cfg.BookingUsecase.HoldClass(ctx, bookClass)
+	if err != nil {
+		if isInvalidBookingDataErr(err) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if conflict, ok := bookingConflict(err); ok {
+			c.JSON(http.StatusConflict, conflict)
+			return
+		}
+		h.cfg.Logger.Errorw("failed to add new booking hold", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add new booking hold"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, hold)
+}
+
+func (h *Handler) GetHold(c *gin.Context) {
+	holdID := c.Param("id")
+	if holdID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	hold, err := h.cfg.BookingUsecase.GetHold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, bookings.ErrHoldNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("booking hold with ID %s not found", holdID)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get booking hold. Retry later"})
+		return
+	}
+
+	c.JSON(http.StatusOK, hold)
+}
+
+func (h *Handler) ConfirmHold(c *gin.Context) {
+	holdID := c.Param("id")
+	if holdID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	booking, err := h.cfg.BookingUsecase.ConfirmHold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, bookings.ErrHoldNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("booking hold with ID %s not found", holdID)})
+			return
+		}
+		if errors.Is(err, bookings.ErrHoldExpired) {
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+			return
+		}
+		if conflict, ok := bookingConflict(err); ok {
+			c.JSON(http.StatusConflict, conflict)
+			return
+		}
+		h.cfg.Logger.Errorw("failed to confirm booking hold", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to confirm booking hold"})
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
@@ -0,0 +1,74 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_HoldClass_Confirm(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+
+	requestBytes, err := json.Marshal(bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+		Guests:    1,
+	})
+	require.NoError(t, err)
+
+	resp, err := httpClient.Post(fmt.Sprintf("%s/bookings/holds", serverURL), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var hold bookings.Hold
+	err = json.Unmarshal(respBody, &hold)
+	require.NoError(t, err)
+	assert.NotEmpty(t, hold.ID)
+	assert.Equal(t, bookings.HoldStatusHeld, hold.Status)
+	assert.True(t, hold.ExpiresAt.After(hold.CreatedAt))
+
+	resp, err = httpClient.Post(fmt.Sprintf("%s/bookings/holds/%s/confirm", serverURL, hold.ID), "application/json", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var booking bookings.Booking
+	err = json.Unmarshal(respBody, &booking)
+	require.NoError(t, err)
+	assert.Equal(t, hold.ID, booking.ID)
+	assert.Equal(t, 1, booking.Guests)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/bookings/%s", serverURL, booking.ID))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_ConfirmHold_NotFound(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	resp, err := httpClient.Post(fmt.Sprintf("%s/bookings/holds/%s/confirm", serverURL, uuid.NewString()), "application/json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/bookings/holds/%s", serverURL, uuid.NewString()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
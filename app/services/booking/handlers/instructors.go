@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) AddInstructor(c *gin.Context) {
+	var newInstructor instructors.NewInstructor
+	err := c.BindJSON(&newInstructor)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind instructor", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	instructor, err := h.cfg.InstructorsUsecase.AddInstructor(ctx, newInstructor)
+	if err != nil {
+		if errors.Is(err, instructors.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to add new instructor", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add new instructor"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, instructor)
+}
+
+func (h *Handler) GetInstructorByID(c *gin.Context) {
+	instructorID := c.Param("id")
+	if instructorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	instructor, err := h.cfg.InstructorsUsecase.GetByID(ctx, instructorID)
+	if err != nil {
+		if errors.Is(err, instructors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("instructor with ID %s not found", instructorID)})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get instructor. Retry later"})
+		return
+	}
+
+	c.JSON(http.StatusOK, instructor)
+}
+
+func (h *Handler) UpdateInstructor(c *gin.Context) {
+	instructorID := c.Param("id")
+	if instructorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var updateInstructor instructors.UpdateInstructor
+	err := c.BindJSON(&updateInstructor)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind instructor", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse request body"})
+		return
+	}
+
+	updatedInstructor, err := h.cfg.InstructorsUsecase.UpdateInstructor(ctx, instructorID, updateInstructor)
+	if err != nil {
+		if errors.Is(err, instructors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("instructor with ID %s not found", instructorID)})
+			return
+		}
+		if errors.Is(err, instructors.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to update instructor: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update instructor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedInstructor)
+}
+
+func (h *Handler) DeleteInstructor(c *gin.Context) {
+	instructorID := c.Param("id")
+	if instructorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err := h.cfg.InstructorsUsecase.DeleteInstructor(ctx, instructorID)
+	if err != nil {
+		h.cfg.Logger.Debugw("failed to delete instructor: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete instructor"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ListInstructors(c *gin.Context) {
+	pageInfo, err := h.extractInstructorsPageInfo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	allInstructors, err := h.cfg.InstructorsUsecase.ListInstructors(ctx, pageInfo)
+	if err != nil {
+		h.cfg.Logger.Debugw("failed to list instructors: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list instructors"})
+		return
+	}
+
+	c.JSON(http.StatusOK, allInstructors)
+}
+
+// GetInstructorSchedule lists the sessions the instructor teaches between the 'from' and 'to'
+// query dates.
+func (h *Handler) GetInstructorSchedule(c *gin.Context) {
+	instructorID := c.Param("id")
+	if instructorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	from, to, err := extractDateRange(c, defaultSessionsWindow)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	schedule, err := h.cfg.InstructorsUsecase.Schedule(ctx, instructorID, from, to)
+	if err != nil {
+		if errors.Is(err, instructors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("instructor with ID %s not found", instructorID)})
+			return
+		}
+		if errors.Is(err, instructors.ErrInvalidData) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to list instructor schedule: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list instructor schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) extractInstructorsPageInfo(c *gin.Context) (instructors.PageInfo, error) {
+	pageStr := c.Query("page")
+	limitStr := c.Query("limit")
+
+	var page int
+	var limit int
+	var err error
+
+	if pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil {
+			h.cfg.Logger.Debugw("failed to parse page param: %w", err)
+			return instructors.PageInfo{}, err
+		}
+	}
+
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			h.cfg.Logger.Debugw("failed to parse limit param: %w", err)
+			return instructors.PageInfo{}, err
+		}
+	}
+
+	return instructors.PageInfo{
+		Limit: limit,
+		Page:  page,
+	}, nil
+}
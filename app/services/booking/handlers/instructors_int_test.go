@@ -0,0 +1,235 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_AddInstructor(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/instructors", serverURL)
+
+	newInstructor := instructors.NewInstructor{
+		Name: uuid.NewString(),
+	}
+	instructorCreated := CreateNewInstructor(t, httpClient, url, newInstructor)
+	assert.NotEmpty(t, instructorCreated.ID)
+	assert.Equal(t, newInstructor.Name, instructorCreated.Name)
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s/%s", url, instructorCreated.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/%s", url, uuid.NewString()))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_AddInstructor_InvalidData(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/instructors", serverURL)
+
+	requestBytes, err := json.Marshal(instructors.NewInstructor{})
+	require.NoError(t, err)
+
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestHandler_UpdateInstructor(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/instructors", serverURL)
+
+	instructorCreated := CreateNewInstructor(t, httpClient, url, instructors.NewInstructor{Name: uuid.NewString()})
+
+	newName := uuid.NewString()
+	requestBytes, err := json.Marshal(instructors.UpdateInstructor{Name: &newName})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%s", url, instructorCreated.ID), bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var instructor instructors.Instructor
+	err = json.Unmarshal(respBody, &instructor)
+	require.NoError(t, err)
+	assert.Equal(t, newName, instructor.Name)
+
+	req, err = http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%s", url, uuid.NewString()), bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_DeleteInstructor(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/instructors", serverURL)
+
+	instructorCreated := CreateNewInstructor(t, httpClient, url, instructors.NewInstructor{Name: uuid.NewString()})
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", url, instructorCreated.ID), nil)
+	require.NoError(t, err)
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/%s", url, instructorCreated.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_ListInstructors(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/instructors", serverURL)
+
+	for i := 0; i < 3; i++ {
+		CreateNewInstructor(t, httpClient, url, instructors.NewInstructor{Name: uuid.NewString()})
+	}
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s?limit=2", url))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var allInstructors []instructors.Instructor
+	err = json.Unmarshal(respBody, &allInstructors)
+	require.NoError(t, err)
+	assert.Len(t, allInstructors, 2)
+}
+
+func TestHandler_GetInstructorSchedule(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	instructor := CreateNewInstructor(t, httpClient, fmt.Sprintf("%s/instructors", serverURL), instructors.NewInstructor{Name: uuid.NewString()})
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	class := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), classes.NewClass{
+		Name:         uuid.NewString(),
+		StartDate:    tomorrow,
+		EndDate:      tomorrow.AddDate(0, 0, 6),
+		Capacity:     20,
+		InstructorID: &instructor.ID,
+		Recurrence: &classes.Recurrence{
+			Frequency:       classes.FrequencyDaily,
+			Interval:        1,
+			StartTime:       "08:00",
+			DurationMinutes: 60,
+		},
+	})
+	assert.Equal(t, instructor.ID, *class.InstructorID)
+
+	url := fmt.Sprintf("%s/instructors/%s/schedule?from=%s&to=%s", serverURL, instructor.ID,
+		tomorrow.Format("2006-01-02"), tomorrow.AddDate(0, 0, 2).Format("2006-01-02"))
+	resp, err := httpClient.Get(url)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var schedule []instructors.ScheduledSession
+	err = json.Unmarshal(respBody, &schedule)
+	require.NoError(t, err)
+	require.Len(t, schedule, 3)
+	for _, session := range schedule {
+		assert.Equal(t, class.ID, session.ClassID)
+		assert.Equal(t, class.Name, session.ClassName)
+	}
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/instructors/%s/schedule", serverURL, uuid.NewString()))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_AssignInstructor_OverlappingSession(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	instructor := CreateNewInstructor(t, httpClient, fmt.Sprintf("%s/instructors", serverURL), instructors.NewInstructor{Name: uuid.NewString()})
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	newClass := func(startTime string) classes.NewClass {
+		return classes.NewClass{
+			Name:      uuid.NewString(),
+			StartDate: tomorrow,
+			EndDate:   tomorrow.AddDate(0, 0, 6),
+			Capacity:  20,
+			Recurrence: &classes.Recurrence{
+				Frequency:       classes.FrequencyDaily,
+				Interval:        1,
+				StartTime:       startTime,
+				DurationMinutes: 60,
+			},
+		}
+	}
+
+	morningClass := newClass("08:00")
+	morningClass.InstructorID = &instructor.ID
+	CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), morningClass)
+	overlappingClass := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), newClass("08:30"))
+
+	requestBytes, err := json.Marshal(classes.UpdateClass{InstructorID: &instructor.ID})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/classes/%s", serverURL, overlappingClass.ID), bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	sessionURL := fmt.Sprintf("%s/classes/%s/sessions/%s", serverURL, overlappingClass.ID, tomorrow.Format("2006-01-02"))
+	requestBytes, err = json.Marshal(classes.UpdateSession{InstructorID: &instructor.ID})
+	require.NoError(t, err)
+	req, err = http.NewRequest(http.MethodPatch, sessionURL, bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	unknownInstructorID := uuid.NewString()
+	requestBytes, err = json.Marshal(classes.UpdateSession{InstructorID: &unknownInstructorID})
+	require.NoError(t, err)
+	req, err = http.NewRequest(http.MethodPatch, sessionURL, bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func CreateNewInstructor(t *testing.T, httpClient *http.Client, url string, newInstructor instructors.NewInstructor) instructors.Instructor {
+	requestBytes, err := json.Marshal(newInstructor)
+	require.NoError(t, err)
+	body := bytes.NewBuffer(requestBytes)
+
+	resp, err := httpClient.Post(url, "application/json", body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var instructor instructors.Instructor
+	err = json.Unmarshal(respBody, &instructor)
+	require.NoError(t, err)
+	return instructor
+}
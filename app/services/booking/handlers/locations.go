@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) AddLocation(c *gin.Context) {
+	var newLocation locations.NewLocation
+	err := c.BindJSON(&newLocation)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind location", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	location, err := h.cfg.LocationsUsecase.AddLocation(ctx, newLocation)
+	if err != nil {
+		if errors.Is(err, locations.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to add new location", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add new location"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, location)
+}
+
+func (h *Handler) GetLocationByID(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	location, err := h.cfg.LocationsUsecase.GetByID(ctx, locationID)
+	if err != nil {
+		if errors.Is(err, locations.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("location with ID %s not found", locationID)})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get location. Retry later"})
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+func (h *Handler) UpdateLocation(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var updateLocation locations.UpdateLocation
+	err := c.BindJSON(&updateLocation)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind location", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse request body"})
+		return
+	}
+
+	updatedLocation, err := h.cfg.LocationsUsecase.UpdateLocation(ctx, locationID, updateLocation)
+	if err != nil {
+		if errors.Is(err, locations.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("location with ID %s not found", locationID)})
+			return
+		}
+		if errors.Is(err, locations.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to update location: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update location"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedLocation)
+}
+
+func (h *Handler) ListLocations(c *gin.Context) {
+	pageInfo, err := h.extractLocationsPageInfo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	allLocations, err := h.cfg.LocationsUsecase.ListLocations(ctx, pageInfo)
+	if err != nil {
+		h.cfg.Logger.Debugw("failed to list locations: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list locations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, allLocations)
+}
+
+func (h *Handler) AddRoom(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	var newRoom locations.NewRoom
+	err := c.BindJSON(&newRoom)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind room", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	room, err := h.cfg.LocationsUsecase.AddRoom(ctx, locationID, newRoom)
+	if err != nil {
+		if errors.Is(err, locations.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("location with ID %s not found", locationID)})
+			return
+		}
+		if errors.Is(err, locations.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to add new room", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add new room"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+func (h *Handler) ListRooms(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	pageInfo, err := h.extractLocationsPageInfo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	rooms, err := h.cfg.LocationsUsecase.ListRooms(ctx, locationID, pageInfo)
+	if err != nil {
+		if errors.Is(err, locations.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("location with ID %s not found", locationID)})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to list rooms: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list rooms"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+func (h *Handler) GetRoomByID(c *gin.Context) {
+	roomID := c.Param("id")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	room, err := h.cfg.LocationsUsecase.GetRoomByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, locations.ErrRoomNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("room with ID %s not found", roomID)})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get room. Retry later"})
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+func (h *Handler) UpdateRoom(c *gin.Context) {
+	roomID := c.Param("id")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var updateRoom locations.UpdateRoom
+	err := c.BindJSON(&updateRoom)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind room", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse request body"})
+		return
+	}
+
+	updatedRoom, err := h.cfg.LocationsUsecase.UpdateRoom(ctx, roomID, updateRoom)
+	if err != nil {
+		if errors.Is(err, locations.ErrRoomNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("room with ID %s not found", roomID)})
+			return
+		}
+		if errors.Is(err, locations.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, locations.ErrRoomCapacityBelowClasses) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to update room: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update room"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedRoom)
+}
+
+func (h *Handler) extractLocationsPageInfo(c *gin.Context) (locations.PageInfo, error) {
+	pageStr := c.Query("page")
+	limitStr := c.Query("limit")
+
+	var page int
+	var limit int
+	var err error
+
+	if pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil {
+			h.cfg.Logger.Debugw("failed to parse page param: %w", err)
+			return locations.PageInfo{}, err
+		}
+	}
+
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			h.cfg.Logger.Debugw("failed to parse limit param: %w", err)
+			return locations.PageInfo{}, err
+		}
+	}
+
+	return locations.PageInfo{
+		Limit: limit,
+		Page:  page,
+	}, nil
+}
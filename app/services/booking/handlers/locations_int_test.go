@@ -0,0 +1,196 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_AddLocation(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/locations", serverURL)
+
+	location := CreateNewLocation(t, httpClient, url, locations.NewLocation{
+		Name:     uuid.NewString(),
+		Address:  "Av. Paulista, 1000",
+		Timezone: "America/Sao_Paulo",
+	})
+	assert.NotEmpty(t, location.ID)
+	assert.Equal(t, "America/Sao_Paulo", location.Timezone)
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s/%s", url, location.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/%s", url, uuid.NewString()))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_AddLocation_InvalidTimezone(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/locations", serverURL)
+
+	requestBytes, err := json.Marshal(locations.NewLocation{Name: uuid.NewString(), Address: "Av. Paulista, 1000", Timezone: "Mars/Olympus"})
+	require.NoError(t, err)
+
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestHandler_Rooms(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	location := CreateNewLocation(t, httpClient, fmt.Sprintf("%s/locations", serverURL), locations.NewLocation{
+		Name:     uuid.NewString(),
+		Address:  "Av. Paulista, 1000",
+		Timezone: "America/Sao_Paulo",
+	})
+
+	roomsURL := fmt.Sprintf("%s/locations/%s/rooms", serverURL, location.ID)
+	room := CreateNewRoom(t, httpClient, roomsURL, locations.NewRoom{Name: "Studio A", Capacity: 20})
+	assert.Equal(t, location.ID, room.LocationID)
+
+	resp, err := httpClient.Get(roomsURL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var rooms []locations.Room
+	err = json.Unmarshal(respBody, &rooms)
+	require.NoError(t, err)
+	require.Len(t, rooms, 1)
+	assert.Equal(t, room.ID, rooms[0].ID)
+
+	requestBytes, err := json.Marshal(locations.NewRoom{Name: "Studio B", Capacity: 10})
+	require.NoError(t, err)
+	resp, err = httpClient.Post(fmt.Sprintf("%s/locations/%s/rooms", serverURL, uuid.NewString()), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/rooms/%s", serverURL, room.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_ClassesInRooms(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	location := CreateNewLocation(t, httpClient, fmt.Sprintf("%s/locations", serverURL), locations.NewLocation{
+		Name:     uuid.NewString(),
+		Address:  "Av. Paulista, 1000",
+		Timezone: "America/Sao_Paulo",
+	})
+	room := CreateNewRoom(t, httpClient, fmt.Sprintf("%s/locations/%s/rooms", serverURL, location.ID), locations.NewRoom{Name: "Studio A", Capacity: 20})
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	newClass := func(startTime string, capacity int) classes.NewClass {
+		return classes.NewClass{
+			Name:      uuid.NewString(),
+			StartDate: tomorrow,
+			EndDate:   tomorrow.AddDate(0, 0, 6),
+			Capacity:  capacity,
+			RoomID:    &room.ID,
+			Recurrence: &classes.Recurrence{
+				Frequency:       classes.FrequencyDaily,
+				Interval:        1,
+				StartTime:       startTime,
+				DurationMinutes: 60,
+			},
+		}
+	}
+
+	classesURL := fmt.Sprintf("%s/classes", serverURL)
+	postClass := func(newClass classes.NewClass) *http.Response {
+		requestBytes, err := json.Marshal(newClass)
+		require.NoError(t, err)
+		resp, err := httpClient.Post(classesURL, "application/json", bytes.NewBuffer(requestBytes))
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := postClass(newClass("08:00", 25))
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	class := CreateNewClass(t, httpClient, classesURL, newClass("08:00", 20))
+	assert.Equal(t, room.ID, *class.RoomID)
+
+	resp = postClass(newClass("08:30", 10))
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	unassigned := newClass("08:30", 10)
+	unassigned.RoomID = nil
+	CreateNewClass(t, httpClient, classesURL, unassigned)
+
+	capacity := 10
+	requestBytes, err := json.Marshal(locations.UpdateRoom{Capacity: &capacity})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/rooms/%s", serverURL, room.ID), bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s?location=%s", classesURL, location.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var inLocation []classes.Class
+	err = json.Unmarshal(respBody, &inLocation)
+	require.NoError(t, err)
+	require.Len(t, inLocation, 1)
+	assert.Equal(t, class.ID, inLocation[0].ID)
+}
+
+func CreateNewLocation(t *testing.T, httpClient *http.Client, url string, newLocation locations.NewLocation) locations.Location {
+	requestBytes, err := json.Marshal(newLocation)
+	require.NoError(t, err)
+	body := bytes.NewBuffer(requestBytes)
+
+	resp, err := httpClient.Post(url, "application/json", body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var location locations.Location
+	err = json.Unmarshal(respBody, &location)
+	require.NoError(t, err)
+	return location
+}
+
+func CreateNewRoom(t *testing.T, httpClient *http.Client, url string, newRoom locations.NewRoom) locations.Room {
+	requestBytes, err := json.Marshal(newRoom)
+	require.NoError(t, err)
+	body := bytes.NewBuffer(requestBytes)
+
+	resp, err := httpClient.Post(url, "application/json", body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var room locations.Room
+	err = json.Unmarshal(respBody, &room)
+	require.NoError(t, err)
+	return room
+}
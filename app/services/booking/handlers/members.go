@@ -58,6 +58,7 @@ func (h *Handler) GetMemberByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, member.Version)
 	c.JSON(http.StatusOK, member)
 }
 
@@ -82,17 +83,32 @@ func (h *Handler) UpdateMember(c *gin.Context) {
 		return
 	}
 
+	updateMember.IfVersions, err = extractIfMatch(c)
+	if err != nil {
+		c.JSON(ifMatchErrStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	updatedMember, err := h.cfg.MembersUsecase.UpdateMember(ctx, memberID, updateMember)
 	if err != nil {
 		if errors.Is(err, members.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("member with ID %s not found", memberID)})
 			return
 		}
+		if errors.Is(err, members.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, members.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		h.cfg.Logger.Debugw("failed to update member: %w", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update member"})
 		return
 	}
 
+	setETag(c, updatedMember.Version)
 	c.JSON(http.StatusOK, updatedMember)
 }
 
@@ -134,6 +150,30 @@ func (h *Handler) ListMembers(c *gin.Context) {
 	c.JSON(http.StatusOK, allMembers)
 }
 
+// SearchMembers finds members by a partial or misspelled name, best matches first.
+func (h *Handler) SearchMembers(c *gin.Context) {
+	pageInfo, err := h.extractPageInfo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	results, err := h.cfg.MembersUsecase.SearchMembers(ctx, c.Query("q"), pageInfo)
+	if err != nil {
+		if errors.Is(err, members.ErrInvalidData) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to search members", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
 func (h *Handler) extractPageInfo(c *gin.Context) (members.PageInfo, error) {
 	pageStr := c.Query("page")
 	limitStr := c.Query("limit")
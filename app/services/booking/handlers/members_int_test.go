@@ -16,6 +16,10 @@ import (
 	pgbookings "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
 	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
 	pgclasses "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	pginstructors "github.com/daniel-oliveiravas/class-booking-service/business/instructors/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	pglocations "github.com/daniel-oliveiravas/class-booking-service/business/locations/integration/postgres"
 	"github.com/daniel-oliveiravas/class-booking-service/business/members"
 	pgmembers "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
 	"github.com/daniel-oliveiravas/class-booking-service/foundation/postgres"
@@ -27,6 +31,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// adminToken is the admin token of the handlers under test.
+const adminToken = "admin-token"
+
 func setupIntegration(t *testing.T) (string, *http.Client) {
 	if os.Getenv("INTEGRATION") == "" {
 		t.Skip("skipping integration tests: set INTEGRATION environment variable")
@@ -58,14 +65,23 @@ func setupIntegration(t *testing.T) (string, *http.Client) {
 	classesRepo := pgclasses.NewClassesRepository(logger, db)
 	classesUsecase := classes.NewUsecase(classesRepo)
 
+	instructorsRepo := pginstructors.NewInstructorsRepository(logger, db)
+	instructorsUsecase := instructors.NewUsecase(instructorsRepo)
+
+	locationsRepo := pglocations.NewLocationsRepository(logger, db)
+	locationsUsecase := locations.NewUsecase(locationsRepo)
+
 	bookingsRepo := pgbookings.NewBookingsRepository(logger, db)
-	bookingsUsecase := bookings.NewUsecase(bookingsRepo, membersUsecase, classesUsecase)
+	bookingsUsecase := bookings.NewUsecase(bookingsRepo, membersUsecase, classesUsecase, bookings.Config{})
 
 	cfg := handlers.Config{
-		MembersUsecase: membersUsecase,
-		ClassesUsecase: classesUsecase,
-		BookingUsecase: bookingsUsecase,
-		Logger:         logger,
+		MembersUsecase:     membersUsecase,
+		ClassesUsecase:     classesUsecase,
+		BookingUsecase:     bookingsUsecase,
+		InstructorsUsecase: instructorsUsecase,
+		LocationsUsecase:   locationsUsecase,
+		Logger:             logger,
+		AdminToken:         adminToken,
 	}
 	handlersAPI, err := handlers.NewHandler(cfg)
 	require.NoError(t, err)
@@ -277,6 +293,27 @@ func TestHandler_ListMember(t *testing.T) {
 	assert.Equal(t, 2, len(allMembers))
 }
 
+func TestHandler_SearchMembers(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/members", serverURL)
+	marina := CreateNewMember(t, httpClient, url, members.NewMember{Name: "Marina Flores"})
+	CreateNewMember(t, httpClient, url, members.NewMember{Name: "Joao Pereira"})
+
+	resp, err := httpClient.Get(url + "/search?q=marnia")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var results []members.SearchResult
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+	require.Len(t, results, 1)
+	assert.Equal(t, marina.ID, results[0].Member.ID)
+	assert.Greater(t, results[0].Rank, 0.0)
+
+	resp, err = httpClient.Get(url + "/search?q=")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 func TestHandler_ListMember_EmptyList(t *testing.T) {
 	serverURL, httpClient := setupIntegration(t)
 	url := fmt.Sprintf("%s/members", serverURL)
@@ -313,3 +350,60 @@ func CreateNewMember(t *testing.T, httpClient *http.Client, url string, newMembe
 	require.NoError(t, err)
 	return member
 }
+
+func TestHandler_UpdateMember_IfMatch(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+	url := fmt.Sprintf("%s/members", serverURL)
+
+	memberCreated := CreateNewMember(t, httpClient, url, members.NewMember{Name: uuid.NewString()})
+	assert.NotEmpty(t, memberCreated.ID)
+
+	memberURL := fmt.Sprintf("%s/members/%s", serverURL, memberCreated.ID)
+	resp, err := httpClient.Get(memberURL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	patchMember := func(ifMatch string) *http.Response {
+		newName := uuid.NewString()
+		requestBytes, err := json.Marshal(members.UpdateMember{Name: &newName})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPatch, memberURL, bytes.NewBuffer(requestBytes))
+		require.NoError(t, err)
+		req.Header.Set("If-Match", ifMatch)
+
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp = patchMember(etag)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	resp = patchMember(etag)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = patchMember(`"not-a-version"`)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = patchMember("not-a-version")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = patchMember(`W/"2"`)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = patchMember(`"1", "2"`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"3"`, resp.Header.Get("ETag"))
+
+	req, err := http.NewRequest(http.MethodPatch, memberURL, bytes.NewBufferString("{}"))
+	require.NoError(t, err)
+	req.Header.Set("If-Match", etag)
+
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+}
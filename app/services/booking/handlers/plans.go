@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) AddPlan(c *gin.Context) {
+	var newPlan members.NewPlan
+	err := c.BindJSON(&newPlan)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind plan", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	plan, err := h.cfg.MembersUsecase.AddPlan(ctx, newPlan)
+	if err != nil {
+		if errors.Is(err, members.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to add new plan", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add new plan"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, plan)
+}
+
+func (h *Handler) GetPlanByID(c *gin.Context) {
+	planID := c.Param("id")
+	if planID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	plan, err := h.cfg.MembersUsecase.GetPlanByID(ctx, planID)
+	if err != nil {
+		if errors.Is(err, members.ErrPlanNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("plan with ID %s not found", planID)})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get plan. Retry later"})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *Handler) ListPlans(c *gin.Context) {
+	pageInfo, err := h.extractPageInfo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query params are invalid"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	plans, err := h.cfg.MembersUsecase.ListPlans(ctx, pageInfo)
+	if err != nil {
+		h.cfg.Logger.Debugw("failed to list plans: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list plans"})
+		return
+	}
+
+	c.JSON(http.StatusOK, plans)
+}
@@ -0,0 +1,79 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_BookClass_PlanLimitReached(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	maxActive := 1
+	requestBytes, err := json.Marshal(members.NewPlan{Name: uuid.NewString(), Limits: members.BookingLimits{MaxActiveBookings: &maxActive}})
+	require.NoError(t, err)
+	resp, err := httpClient.Post(fmt.Sprintf("%s/plans", serverURL), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var plan members.Plan
+	err = json.Unmarshal(respBody, &plan)
+	require.NoError(t, err)
+
+	class, _ := PrepareToBookClass(t, httpClient, serverURL)
+	member := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString(), PlanID: &plan.ID})
+
+	url := fmt.Sprintf("%s/bookings", serverURL)
+	BookClass(t, httpClient, url, bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate.AddDate(0, 0, 1),
+	})
+
+	requestBytes, err = json.Marshal(bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate.AddDate(0, 0, 2),
+	})
+	require.NoError(t, err)
+	resp, err = httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	respBody, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var limitReached struct {
+		Limit bookings.Limit `json:"limit"`
+		Max   int            `json:"max"`
+	}
+	err = json.Unmarshal(respBody, &limitReached)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.LimitMaxActiveBookings, limitReached.Limit)
+	assert.Equal(t, maxActive, limitReached.Max)
+}
+
+func TestHandler_AddMember_UnknownPlan(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	planID := uuid.NewString()
+	requestBytes, err := json.Marshal(members.NewMember{Name: uuid.NewString(), PlanID: &planID})
+	require.NoError(t, err)
+	resp, err := httpClient.Post(fmt.Sprintf("%s/members", serverURL), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
 	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
 	"github.com/daniel-oliveiravas/class-booking-service/business/members"
 	"github.com/daniel-oliveiravas/class-booking-service/foundation/postgres"
 	"github.com/gin-gonic/gin"
@@ -13,12 +15,17 @@ import (
 )
 
 type Config struct {
-	MembersUsecase *members.Usecase
-	ClassesUsecase *classes.Usecase
-	BookingUsecase *bookings.Usecase
-	GinMode        string
-	Logger         *zap.SugaredLogger
-	PgProbe        *postgres.Probe
+	MembersUsecase     *members.Usecase
+	ClassesUsecase     *classes.Usecase
+	BookingUsecase     *bookings.Usecase
+	InstructorsUsecase *instructors.Usecase
+	LocationsUsecase   *locations.Usecase
+	GinMode            string
+	Logger             *zap.SugaredLogger
+	PgProbe            *postgres.Probe
+	// AdminToken is the token admins send in the X-Admin-Token header to override booking checks.
+	// Overrides are disabled without it.
+	AdminToken string
 }
 
 type Handler struct {
@@ -46,6 +53,14 @@ func NewHandler(cfg Config) (*Handler, error) {
 		return nil, errors.New("failed to build new handler: missing classes usecase")
 	}
 
+	if cfg.InstructorsUsecase == nil {
+		return nil, errors.New("failed to build new handler: missing instructors usecase")
+	}
+
+	if cfg.LocationsUsecase == nil {
+		return nil, errors.New("failed to build new handler: missing locations usecase")
+	}
+
 	return &Handler{
 		cfg: cfg,
 	}, nil
@@ -57,17 +72,61 @@ func (h *Handler) API() http.Handler {
 
 	//Members routes
 	r.POST("/members", h.AddMember)
+	r.GET("/members/search", h.SearchMembers)
 	r.GET("/members/:id", h.GetMemberByID)
 	r.PATCH("/members/:id", h.UpdateMember)
 	r.DELETE("/members/:id", h.DeleteMember)
 	r.GET("/members/", h.ListMembers)
+	r.GET("/members/:id/events", h.ListMemberEvents)
+	r.GET("/members/:id/bookings", h.ListMemberBookings)
+
+	//Plans routes
+	r.POST("/plans", h.AddPlan)
+	r.GET("/plans/:id", h.GetPlanByID)
+	r.GET("/plans", h.ListPlans)
 
 	//Classes routes
 	r.POST("/classes", h.AddClass)
+	r.GET("/classes/search", h.SearchClasses)
 	r.GET("/classes/:id", h.GetClassByID)
 	r.PATCH("/classes/:id", h.UpdateClass)
 	r.DELETE("/classes/:id", h.DeleteClass)
 	r.GET("/classes", h.ListClasses)
+	r.GET("/classes/:id/availability", h.ClassAvailability)
+	r.GET("/classes/:id/bookings", h.ListClassBookings)
+
+	//Instructors routes
+	r.POST("/instructors", h.AddInstructor)
+	r.GET("/instructors/:id", h.GetInstructorByID)
+	r.PATCH("/instructors/:id", h.UpdateInstructor)
+	r.DELETE("/instructors/:id", h.DeleteInstructor)
+	r.GET("/instructors", h.ListInstructors)
+	r.GET("/instructors/:id/schedule", h.GetInstructorSchedule)
+
+	//Locations routes
+	r.POST("/locations", h.AddLocation)
+	r.GET("/locations/:id", h.GetLocationByID)
+	r.PATCH("/locations/:id", h.UpdateLocation)
+	r.GET("/locations", h.ListLocations)
+	r.POST("/locations/:id/rooms", h.AddRoom)
+	r.GET("/locations/:id/rooms", h.ListRooms)
+	r.GET("/rooms/:id", h.GetRoomByID)
+	r.PATCH("/rooms/:id", h.UpdateRoom)
+
+	//Sessions routes
+	r.GET("/classes/:id/sessions", h.ListSessions)
+	r.PATCH("/classes/:id/sessions/:date", h.UpdateSession)
+	r.POST("/classes/:id/sessions/:date/cancel", h.CancelSession)
+
+	//Waitlist routes
+	r.POST("/classes/:id/waitlist", h.JoinWaitlist)
+	r.GET("/classes/:id/waitlist", h.ListWaitlist)
+	r.GET("/classes/:id/waitlist/:entryID", h.GetWaitlistEntry)
+	r.DELETE("/classes/:id/waitlist/:entryID", h.LeaveWaitlist)
+
+	//Attendance routes
+	r.POST("/bookings/:id/check-in", h.CheckIn)
+	r.POST("/classes/:id/sessions/:date/attendance", h.MarkAttendance)
 
 	//Booking routes
 	r.POST("/bookings", h.BookClass)
@@ -75,6 +134,14 @@ func (h *Handler) API() http.Handler {
 	r.DELETE("/bookings/:id", h.DeleteBooking)
 	r.GET("/bookings", h.ListBookings)
 
+	//Booking holds routes
+	r.POST("/bookings/holds", h.HoldClass)
+	r.GET("/bookings/holds/:id", h.GetHold)
+	r.POST("/bookings/holds/:id/confirm", h.ConfirmHold)
+
+	//Calendar routes
+	r.GET("/calendar", h.Calendar)
+
 	//Health endpoints
 	r.GET("/v1/readiness", h.Readiness)
 	r.GET("/v1/liveness", h.Liveness)
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSessionsWindow is how many days of sessions are listed when the query has no 'to' date.
+const defaultSessionsWindow = 30
+
+func (h *Handler) ListSessions(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	from, to, err := extractDateRange(c, defaultSessionsWindow)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	sessions, err := h.cfg.ClassesUsecase.ListSessions(ctx, classID, from, to)
+	if err != nil {
+		if errors.Is(err, classes.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to list sessions: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (h *Handler) UpdateSession(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	date, err := time.Parse(classDateLayout, c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("path param date must be formatted as %s", classDateLayout)})
+		return
+	}
+
+	var updateSession classes.UpdateSession
+	err = c.BindJSON(&updateSession)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind session", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	session, err := h.cfg.ClassesUsecase.UpdateSession(ctx, classID, date, updateSession)
+	if err != nil {
+		if errors.Is(err, classes.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
+			return
+		}
+		if errors.Is(err, classes.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s has no session on %s", classID, c.Param("date"))})
+			return
+		}
+		if errors.Is(err, classes.ErrInvalidData) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, classes.ErrCapacityBelowBookings) || errors.Is(err, classes.ErrInstructorConflict) ||
+			errors.Is(err, classes.ErrRoomConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to update session: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+func (h *Handler) CancelSession(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	date, err := time.Parse(classDateLayout, c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("path param date must be formatted as %s", classDateLayout)})
+		return
+	}
+
+	var cancelSession bookings.CancelSession
+	err = c.BindJSON(&cancelSession)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind session cancellation", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	cancellation, err := h.cfg.BookingUsecase.CancelSession(ctx, classID, date, cancelSession)
+	if err != nil {
+		if errors.Is(err, bookings.ErrClassNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s not found", classID)})
+			return
+		}
+		if errors.Is(err, bookings.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("class with ID %s has no session on %s", classID, c.Param("date"))})
+			return
+		}
+		if errors.Is(err, bookings.ErrMissingCancelReason) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, bookings.ErrSessionCancelled) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to cancel session", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, cancellation)
+}
+
+// extractDateRange reads the 'from' and 'to' dates of the query. 'from' defaults to today and
+// 'to' defaults to defaultDays days after 'from'.
+func extractDateRange(c *gin.Context, defaultDays int) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if c.Query("from") != "" {
+		parsed, err := time.Parse(classDateLayout, c.Query("from"))
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("query param from must be formatted as %s", classDateLayout)
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, defaultDays)
+	if c.Query("to") != "" {
+		parsed, err := time.Parse(classDateLayout, c.Query("to"))
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("query param to must be formatted as %s", classDateLayout)
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("query param to cannot be before from")
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,159 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ListSessions(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, _ := PrepareToBookClass(t, httpClient, serverURL)
+
+	url := fmt.Sprintf("%s/classes/%s/sessions?from=%s&to=%s", serverURL, class.ID,
+		class.StartDate.Format("2006-01-02"), class.StartDate.AddDate(0, 0, 2).Format("2006-01-02"))
+	resp, err := httpClient.Get(url)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var sessions []classes.Session
+	err = json.Unmarshal(respBody, &sessions)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	for _, session := range sessions {
+		assert.NotEmpty(t, session.ID)
+		assert.Equal(t, class.ID, session.ClassID)
+		assert.Equal(t, classes.SessionStatusScheduled, session.Status)
+	}
+}
+
+func TestHandler_UpdateSession(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+
+	url := fmt.Sprintf("%s/classes/%s/sessions/%s", serverURL, class.ID, class.StartDate.Format("2006-01-02"))
+
+	capacity := 0
+	requestBytes, err := json.Marshal(classes.UpdateSession{Capacity: &capacity})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	capacity = 1
+	notes := "room 2 today"
+	requestBytes, err = json.Marshal(classes.UpdateSession{Capacity: &capacity, Notes: &notes})
+	require.NoError(t, err)
+	req, err = http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var session classes.Session
+	err = json.Unmarshal(respBody, &session)
+	require.NoError(t, err)
+	assert.Equal(t, &capacity, session.Capacity)
+	assert.Equal(t, &notes, session.Notes)
+
+	otherMember := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+	requestBytes, err = json.Marshal(bookings.BookClass{
+		MemberID:  otherMember.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+	require.NoError(t, err)
+	resp, err = httpClient.Post(fmt.Sprintf("%s/bookings", serverURL), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestHandler_CancelSession(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+
+	url := fmt.Sprintf("%s/classes/%s/sessions/%s/cancel", serverURL, class.ID, class.StartDate.Format("2006-01-02"))
+
+	requestBytes, err := json.Marshal(bookings.CancelSession{})
+	require.NoError(t, err)
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	requestBytes, err = json.Marshal(bookings.CancelSession{Reason: "instructor is sick"})
+	require.NoError(t, err)
+	resp, err = httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var cancellation bookings.SessionCancellation
+	err = json.Unmarshal(respBody, &cancellation)
+	require.NoError(t, err)
+	assert.Equal(t, classes.SessionStatusCancelled, cancellation.Session.Status)
+	require.Len(t, cancellation.Bookings, 1)
+	assert.Equal(t, booking.ID, cancellation.Bookings[0].ID)
+	assert.Equal(t, bookings.StatusStudioCancelled, cancellation.Bookings[0].Status)
+
+	resp, err = httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	requestBytes, err = json.Marshal(bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   class.ID,
+		ClassDate: class.StartDate,
+	})
+	require.NoError(t, err)
+	resp, err = httpClient.Post(fmt.Sprintf("%s/bookings", serverURL), "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/members/%s/events", serverURL, member.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var events []bookings.Event
+	err = json.Unmarshal(respBody, &events)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, bookings.EventSessionCancelled, events[0].Type)
+}
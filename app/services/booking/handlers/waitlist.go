@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/gin-gonic/gin"
+)
+
+const classDateLayout = "2006-01-02"
+
+func (h *Handler) JoinWaitlist(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	var joinWaitlist bookings.JoinWaitlist
+	err := c.BindJSON(&joinWaitlist)
+	if err != nil {
+		if strings.Contains(err.Error(), "EOF") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("missing body").Error()})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to bind waitlist entry", "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	entry, err := h.cfg.BookingUsecase.JoinWaitlist(ctx, classID, joinWaitlist)
+	if err != nil {
+		if isInvalidBookingDataErr(err) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		var alreadyBooked *bookings.AlreadyBookedError
+		if errors.As(err, &alreadyBooked) {
+			c.JSON(http.StatusConflict, gin.H{"error": bookings.ErrAlreadyBooked.Error(), "bookingID": alreadyBooked.BookingID})
+			return
+		}
+		if errors.Is(err, bookings.ErrClassNotFull) || errors.Is(err, bookings.ErrAlreadyOnWaitlist) ||
+			errors.Is(err, bookings.ErrSessionCancelled) || errors.Is(err, bookings.ErrClassArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.cfg.Logger.Errorw("failed to join waitlist", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to join waitlist"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *Handler) GetWaitlistEntry(c *gin.Context) {
+	classID := c.Param("id")
+	entryID := c.Param("entryID")
+	if classID == "" || entryID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path params: id, entryID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	entry, err := h.cfg.BookingUsecase.GetWaitlistEntry(ctx, entryID)
+	if err != nil && !errors.Is(err, bookings.ErrWaitlistEntryNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get waitlist entry. Retry later"})
+		return
+	}
+
+	if err != nil || entry.ClassID != classID {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("waitlist entry with ID %s not found", entryID)})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *Handler) LeaveWaitlist(c *gin.Context) {
+	classID := c.Param("id")
+	entryID := c.Param("entryID")
+	if classID == "" || entryID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path params: id, entryID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err := h.cfg.BookingUsecase.LeaveWaitlist(ctx, classID, entryID)
+	if err != nil {
+		if errors.Is(err, bookings.ErrWaitlistEntryNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("waitlist entry with ID %s not found", entryID)})
+			return
+		}
+		h.cfg.Logger.Debugw("failed to leave waitlist: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to leave waitlist"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ListWaitlist(c *gin.Context) {
+	classID := c.Param("id")
+	if classID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing path param: id"})
+		return
+	}
+
+	classDate, err := time.Parse(classDateLayout, c.Query("classDate"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("query param classDate must be formatted as %s", classDateLayout)})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	entries, err := h.cfg.BookingUsecase.ListWaitlist(ctx, classID, classDate)
+	if err != nil {
+		h.cfg.Logger.Debugw("failed to list waitlist: %w", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list waitlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
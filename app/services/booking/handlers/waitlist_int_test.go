@@ -0,0 +1,154 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_JoinWaitlist_ClassNotFull(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	class, member := PrepareToBookClass(t, httpClient, serverURL)
+
+	requestBytes, err := json.Marshal(bookings.JoinWaitlist{
+		MemberID:  member.ID,
+		ClassDate: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("%s/classes/%s/waitlist", serverURL, class.ID)
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(requestBytes))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestHandler_Waitlist_PromotedWhenBookingDeleted(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	newClass := classes.NewClass{
+		Name:      uuid.NewString(),
+		StartDate: time.Now().UTC(),
+		EndDate:   time.Now().UTC().Add(time.Hour * 24 * 10),
+		Capacity:  1,
+	}
+	class := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), newClass)
+	booker := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+	waiting := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+
+	classDate := time.Now().UTC()
+	booking := BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  booker.ID,
+		ClassID:   class.ID,
+		ClassDate: classDate,
+	})
+
+	waitlistURL := fmt.Sprintf("%s/classes/%s/waitlist", serverURL, class.ID)
+	entry := JoinWaitlist(t, httpClient, waitlistURL, bookings.JoinWaitlist{
+		MemberID:  waiting.ID,
+		ClassDate: classDate,
+	})
+	assert.Equal(t, 1, entry.Position)
+
+	resp, err := httpClient.Get(fmt.Sprintf("%s?classDate=%s", waitlistURL, classDate.Format("2006-01-02")))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var waitlist []bookings.WaitlistEntry
+	err = json.Unmarshal(respBody, &waitlist)
+	require.NoError(t, err)
+	require.Len(t, waitlist, 1)
+	assert.Equal(t, entry.ID, waitlist[0].ID)
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/bookings/%s", serverURL, booking.ID), nil)
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/bookings/%s", serverURL, entry.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/%s", waitlistURL, entry.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_LeaveWaitlist_OtherClass(t *testing.T) {
+	serverURL, httpClient := setupIntegration(t)
+
+	newClass := classes.NewClass{
+		Name:      uuid.NewString(),
+		StartDate: time.Now().UTC(),
+		EndDate:   time.Now().UTC().Add(time.Hour * 24 * 10),
+		Capacity:  1,
+	}
+	class := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), newClass)
+	otherClass := CreateNewClass(t, httpClient, fmt.Sprintf("%s/classes", serverURL), newClass)
+	booker := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+	waiting := CreateNewMember(t, httpClient, fmt.Sprintf("%s/members", serverURL), members.NewMember{Name: uuid.NewString()})
+
+	classDate := time.Now().UTC()
+	BookClass(t, httpClient, fmt.Sprintf("%s/bookings", serverURL), bookings.BookClass{
+		MemberID:  booker.ID,
+		ClassID:   class.ID,
+		ClassDate: classDate,
+	})
+
+	waitlistURL := fmt.Sprintf("%s/classes/%s/waitlist", serverURL, class.ID)
+	entry := JoinWaitlist(t, httpClient, waitlistURL, bookings.JoinWaitlist{
+		MemberID:  waiting.ID,
+		ClassDate: classDate,
+	})
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/classes/%s/waitlist/%s", serverURL, otherClass.ID, entry.ID), nil)
+	require.NoError(t, err)
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", waitlistURL, entry.ID), nil)
+	require.NoError(t, err)
+	resp, err = httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = httpClient.Get(fmt.Sprintf("%s/%s", waitlistURL, entry.ID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func JoinWaitlist(t *testing.T, httpClient *http.Client, url string, joinWaitlist bookings.JoinWaitlist) bookings.WaitlistEntry {
+	requestBytes, err := json.Marshal(joinWaitlist)
+	require.NoError(t, err)
+	body := bytes.NewBuffer(requestBytes)
+
+	resp, err := httpClient.Post(url, "application/json", body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var entry bookings.WaitlistEntry
+	err = json.Unmarshal(respBody, &entry)
+	require.NoError(t, err)
+	return entry
+}
@@ -7,12 +7,19 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	// Location timezones are IANA names, which must load wherever the service runs.
+	_ "time/tzdata"
 
 	"github.com/daniel-oliveiravas/class-booking-service/app/services/booking/handlers"
 	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
 	pgbookings "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
 	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
 	pgclasses "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	pginstructors "github.com/daniel-oliveiravas/class-booking-service/business/instructors/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	pglocations "github.com/daniel-oliveiravas/class-booking-service/business/locations/integration/postgres"
 	"github.com/daniel-oliveiravas/class-booking-service/business/members"
 	pgmembers "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
 	"github.com/daniel-oliveiravas/class-booking-service/foundation/logging"
@@ -89,23 +96,72 @@ func run(logger *zap.SugaredLogger) error {
 	classesRepo := pgclasses.NewClassesRepository(logger, dbPool)
 	classesUsecase := classes.NewUsecase(classesRepo)
 
+	instructorsRepo := pginstructors.NewInstructorsRepository(logger, dbPool)
+	instructorsUsecase := instructors.NewUsecase(instructorsRepo)
+
+	locationsRepo := pglocations.NewLocationsRepository(logger, dbPool)
+	locationsUsecase := locations.NewUsecase(locationsRepo)
+
+	studioCancellationPolicy := classes.CancellationPolicy{
+		FreeCancelCutoffMinutes: int(cfg.FreeCancelCutoff.Minutes()),
+		LateCancelPenalty:       classes.Penalty(cfg.LateCancelPenalty),
+	}
+	if !studioCancellationPolicy.LateCancelPenalty.Valid() {
+		return fmt.Errorf("invalid late cancel penalty %q", cfg.LateCancelPenalty)
+	}
+
+	// Zero guests is a limit of its own, so only a negative guest limit means no limit.
+	maxGuestsPerBooking := &cfg.MaxGuestsPerBooking
+	if cfg.MaxGuestsPerBooking < 0 {
+		maxGuestsPerBooking = nil
+	}
+
 	bookingsRepo := pgbookings.NewBookingsRepository(logger, dbPool)
-	bookingsUsecase := bookings.NewUsecase(bookingsRepo, membersUsecase, classesUsecase)
+	bookingsCfg := bookings.Config{
+		CheckInOpensBefore: cfg.CheckInOpensBefore,
+		CheckInClosesAfter: cfg.CheckInClosesAfter,
+		CancellationPolicy: studioCancellationPolicy,
+		HoldTTL:            cfg.HoldTTL,
+		Limits: members.BookingLimits{
+			MaxActiveBookings:          bookingLimit(cfg.MaxActiveBookings),
+			MaxBookingsPerDay:          bookingLimit(cfg.MaxBookingsPerDay),
+			MaxBookingsPerClassPerWeek: bookingLimit(cfg.MaxBookingsPerClassPerWeek),
+			MaxGuestsPerBooking:        maxGuestsPerBooking,
+		},
+	}
+	bookingsUsecase := bookings.NewUsecase(bookingsRepo, membersUsecase, classesUsecase, bookingsCfg)
 
 	pgProbe := postgres.NewProbe(dbPool)
 	handlerCfg := handlers.Config{
-		MembersUsecase: membersUsecase,
-		ClassesUsecase: classesUsecase,
-		BookingUsecase: bookingsUsecase,
-		GinMode:        cfg.GinMode,
-		Logger:         logger,
-		PgProbe:        pgProbe,
+		MembersUsecase:     membersUsecase,
+		ClassesUsecase:     classesUsecase,
+		BookingUsecase:     bookingsUsecase,
+		InstructorsUsecase: instructorsUsecase,
+		LocationsUsecase:   locationsUsecase,
+		GinMode:            cfg.GinMode,
+		Logger:             logger,
+		PgProbe:            pgProbe,
+		AdminToken:         cfg.AdminToken,
 	}
 	handler, err := handlers.NewHandler(handlerCfg)
 	if err != nil {
 		return err
 	}
 
+	// -------------------------------------------------------------------------
+	// Start background sweepers
+
+	sweepersCtx, stopSweepers := context.WithCancel(ctx)
+	defer stopSweepers()
+
+	go runSweeper(sweepersCtx, logger, "no-show", cfg.NoShowSweepInterval, func(ctx context.Context) (int, error) {
+		return bookingsUsecase.MarkNoShows(ctx, time.Now())
+	})
+	go runSweeper(sweepersCtx, logger, "expired-holds", cfg.HoldSweepInterval, bookingsUsecase.ReleaseExpiredHolds)
+	go runSweeper(sweepersCtx, logger, "events", cfg.EventPublishInterval, func(ctx context.Context) (int, error) {
+		return bookingsUsecase.PublishEvents(ctx, logPublisher{logger: logger})
+	})
+
 	// -------------------------------------------------------------------------
 	// Start http.Server
 	server := http.Server{
@@ -138,3 +194,12 @@ func run(logger *zap.SugaredLogger) error {
 
 	return nil
 }
+
+// bookingLimit turns a booking limit from the config into a members.BookingLimits one, where no
+// limit is nil instead of zero.
+func bookingLimit(limit int) *int {
+	if limit <= 0 {
+		return nil
+	}
+	return &limit
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"context"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"go.uber.org/zap"
+)
+
+// logPublisher publishes member events to the service log. It is the only publisher we have until
+// the events are delivered to a notification channel, and keeps the outbox from growing unpublished.
+type logPublisher struct {
+	logger *zap.SugaredLogger
+}
+
+func (p logPublisher) Publish(_ context.Context, event bookings.Event) error {
+	p.logger.Infow("member event published", "event", event.ID, "type", event.Type, "member", event.MemberID, "payload", string(event.Payload))
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// runSweeper calls sweep every interval until ctx is done. Sweeps returning how many records were
+// changed are logged, so we can follow what the background jobs are doing.
+func runSweeper(ctx context.Context, logger *zap.SugaredLogger, name string, interval time.Duration, sweep func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			swept, err := sweep(ctx)
+			if err != nil {
+				logger.Errorw("sweeper failed", "sweeper", name, "error", err.Error())
+				continue
+			}
+
+			if swept > 0 {
+				logger.Infow("sweeper completed", "sweeper", name, "swept", swept)
+			}
+		}
+	}
+}
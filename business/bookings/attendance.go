@@ -0,0 +1,107 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+)
+
+// CheckIn marks the booking as attended. Members can only check in while the session check-in window is open.
+func (u *Usecase) CheckIn(ctx context.Context, bookingID string) (Booking, error) {
+	booking, err := u.GetByID(ctx, bookingID)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	// Bookings outlive the classes deleted after they were booked.
+	class, err := u.classesUsecase.GetByIDIncludingDeleted(ctx, booking.ClassID)
+	if err != nil {
+		return Booking{}, fmt.Errorf("failed to get booked class: %w", err)
+	}
+
+	if !u.checkInOpen(class, booking.ClassDate, time.Now()) {
+		return Booking{}, ErrCheckInClosed
+	}
+
+	checkedIn, err := u.repository.CheckIn(ctx, bookingID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Booking{}, ErrNotFound
+		}
+		if errors.Is(err, ErrInvalidStatusTransition) {
+			return Booking{}, err
+		}
+		return Booking{}, fmt.Errorf("failed to check in booking: %w", err)
+	}
+
+	return checkedIn, nil
+}
+
+// MarkAttendance marks the bookings of the given members for a class session as attended, all or
+// none of them. It follows the same window as member check-ins.
+func (u *Usecase) MarkAttendance(ctx context.Context, classID string, classDate time.Time, attendance Attendance) ([]Booking, error) {
+	class, err := u.classesUsecase.GetByID(ctx, classID)
+	if err != nil {
+		if errors.Is(err, classes.ErrNotFound) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+
+	if !u.checkInOpen(class, classDate, time.Now()) {
+		return nil, ErrCheckInClosed
+	}
+
+	attended, err := u.repository.MarkAttended(ctx, classID, classDate, attendance.MemberIDs)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotBooked) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to mark attendance: %w", err)
+	}
+
+	return attended, nil
+}
+
+// MarkNoShows marks as no-show every booking still without a check-in once its check-in window has closed.
+func (u *Usecase) MarkNoShows(ctx context.Context, now time.Time) (int, error) {
+	booked, err := u.repository.ListBookedUntil(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list bookings without check-in: %w", err)
+	}
+
+	bookedClasses := make(map[string]classes.Class)
+	noShows := make([]string, 0)
+	for _, booking := range booked {
+		class, found := bookedClasses[booking.ClassID]
+		if !found {
+			class, err = u.classesUsecase.GetByIDIncludingDeleted(ctx, booking.ClassID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get booked class: %w", err)
+			}
+			bookedClasses[booking.ClassID] = class
+		}
+
+		if now.After(u.checkInClosesAt(class, booking.ClassDate)) {
+			noShows = append(noShows, booking.ID)
+		}
+	}
+
+	if len(noShows) == 0 {
+		return 0, nil
+	}
+
+	return u.repository.MarkNoShows(ctx, noShows)
+}
+
+func (u *Usecase) checkInOpen(class classes.Class, classDate time.Time, now time.Time) bool {
+	opensAt := class.SessionStart(classDate).Add(-u.cfg.CheckInOpensBefore)
+	return !now.Before(opensAt) && !now.After(u.checkInClosesAt(class, classDate))
+}
+
+func (u *Usecase) checkInClosesAt(class classes.Class, classDate time.Time) time.Time {
+	return class.SessionEnd(classDate).Add(u.cfg.CheckInClosesAfter)
+}
@@ -0,0 +1,125 @@
+package bookings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	classesmocks "github.com/daniel-oliveiravas/class-booking-service/business/classes/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	membersmocks "github.com/daniel-oliveiravas/class-booking-service/business/members/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_CheckIn(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	startedAt := time.Now().UTC().Add(-time.Minute)
+	booking := NewBooking()
+	booking.ClassDate = startedAt
+	class := classes.Class{ID: booking.ClassID, StartDate: startedAt, EndDate: startedAt.Add(time.Hour * 24 * 10)}
+
+	checkedIn := booking
+	checkedIn.Status = bookings.StatusAttended
+	repo.On("GetByID", mock.Anything, booking.ID).Return(booking, nil).Once()
+	classesRepo.On("GetByIDIncludingDeleted", mock.Anything, booking.ClassID).Return(class, nil).Once()
+	repo.On("CheckIn", mock.Anything, booking.ID).Return(checkedIn, nil).Once()
+
+	bookingCheckedIn, err := usecase.CheckIn(ctx, booking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusAttended, bookingCheckedIn.Status)
+}
+
+func TestUsecase_CheckIn_WindowClosed(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	tomorrow := time.Now().UTC().Add(time.Hour * 24)
+	booking := NewBooking()
+	booking.ClassDate = tomorrow
+	class := classes.Class{ID: booking.ClassID, StartDate: tomorrow, EndDate: tomorrow.Add(time.Hour * 24 * 10)}
+
+	repo.On("GetByID", mock.Anything, booking.ID).Return(booking, nil).Once()
+	classesRepo.On("GetByIDIncludingDeleted", mock.Anything, booking.ClassID).Return(class, nil).Once()
+
+	_, err := usecase.CheckIn(ctx, booking.ID)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrCheckInClosed))
+}
+
+func TestUsecase_MarkAttendance_MemberNotBooked(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	startedAt := time.Now().UTC().Add(-time.Minute)
+	class := classes.Class{ID: uuid.NewString(), StartDate: startedAt, EndDate: startedAt.Add(time.Hour * 24 * 10)}
+	attendance := bookings.Attendance{MemberIDs: []string{uuid.NewString()}}
+
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+	repo.On("MarkAttended", mock.Anything, class.ID, startedAt, attendance.MemberIDs).Return(nil, bookings.ErrMemberNotBooked).Once()
+
+	_, err := usecase.MarkAttendance(ctx, class.ID, startedAt, attendance)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrMemberNotBooked))
+}
+
+func TestUsecase_MarkNoShows(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	now := time.Date(2023, 7, 10, 12, 0, 0, 0, time.UTC)
+	morningClass := classes.Class{
+		ID:        uuid.NewString(),
+		StartDate: time.Date(2023, 6, 1, 8, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 8, 31, 9, 0, 0, 0, time.UTC),
+	}
+	eveningClass := classes.Class{
+		ID:        uuid.NewString(),
+		StartDate: time.Date(2023, 6, 1, 18, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 8, 31, 19, 0, 0, 0, time.UTC),
+	}
+
+	morningBooking := NewBooking()
+	morningBooking.ClassID = morningClass.ID
+	morningBooking.ClassDate = time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+	eveningBooking := NewBooking()
+	eveningBooking.ClassID = eveningClass.ID
+	eveningBooking.ClassDate = time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	repo.On("ListBookedUntil", mock.Anything, now).Return([]bookings.Booking{morningBooking, eveningBooking}, nil).Once()
+	classesRepo.On("GetByIDIncludingDeleted", mock.Anything, morningClass.ID).Return(morningClass, nil).Once()
+	classesRepo.On("GetByIDIncludingDeleted", mock.Anything, eveningClass.ID).Return(eveningClass, nil).Once()
+	repo.On("MarkNoShows", mock.Anything, []string{morningBooking.ID}).Return(1, nil).Once()
+
+	marked, err := usecase.MarkNoShows(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, marked)
+}
@@ -0,0 +1,77 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+)
+
+// Availability returns the availability of every session of a class from one date to another,
+// both inclusive. Sessions that dropped out of the class schedule are left out.
+func (u *Usecase) Availability(ctx context.Context, classID string, from time.Time, to time.Time) ([]Availability, error) {
+	class, err := u.classesUsecase.GetByID(ctx, classID)
+	if err != nil {
+		if errors.Is(err, classes.ErrNotFound) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+
+	sessions, err := u.classesUsecase.ListSessions(ctx, classID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list class sessions: %w", err)
+	}
+
+	counts, err := u.repository.CountByDate(ctx, classID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bookings by date: %w", err)
+	}
+
+	countsByDate := make(map[string]DateCounts)
+	for _, dateCounts := range counts {
+		countsByDate[dateCounts.ClassDate.Format("2006-01-02")] = dateCounts
+	}
+
+	now := time.Now()
+	availability := make([]Availability, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Status == classes.SessionStatusUnscheduled {
+			continue
+		}
+
+		dateCounts := countsByDate[session.Date.Format("2006-01-02")]
+		capacity, spotsLeft := sessionSpots(class, session, dateCounts)
+
+		availability = append(availability, Availability{
+			Date:           session.Date,
+			SessionID:      session.ID,
+			StartsAt:       session.StartsAt,
+			Capacity:       capacity,
+			Booked:         dateCounts.Booked,
+			SpotsLeft:      spotsLeft,
+			WaitlistLength: dateCounts.Waitlisted,
+			BookingOpen:    class.ArchivedAt == nil && session.Status == classes.SessionStatusScheduled && spotsLeft > 0 && now.Before(session.StartsAt),
+		})
+	}
+
+	return availability, nil
+}
+
+// sessionSpots returns the capacity of the session, overridden or the class one, and how many of
+// its spots weren't booked yet.
+func sessionSpots(class classes.Class, session classes.Session, dateCounts DateCounts) (int, int) {
+	capacity := class.Capacity
+	if session.Capacity != nil {
+		capacity = *session.Capacity
+	}
+
+	spotsLeft := capacity - dateCounts.Booked
+	if spotsLeft < 0 {
+		spotsLeft = 0
+	}
+
+	return capacity, spotsLeft
+}
@@ -0,0 +1,122 @@
+package bookings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	classesmocks "github.com/daniel-oliveiravas/class-booking-service/business/classes/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	membersmocks "github.com/daniel-oliveiravas/class-booking-service/business/members/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_Availability(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	today := time.Now().UTC()
+	from := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	class := classes.Class{
+		ID:        uuid.NewString(),
+		StartDate: from.Add(time.Hour * 18),
+		EndDate:   to.Add(time.Hour * 19),
+		Capacity:  2,
+	}
+
+	capacity := 3
+	overridden := class.Session(from.AddDate(0, 0, 1))
+	overridden.ID = uuid.NewString()
+	overridden.Capacity = &capacity
+	cancelled := class.Session(to)
+	cancelled.ID = uuid.NewString()
+	cancelled.Status = classes.SessionStatusCancelled
+
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Twice()
+	classesRepo.On("ListSessions", mock.Anything, class.ID, from, to).Return([]classes.Session{overridden, cancelled}, nil).Once()
+	repo.On("CountByDate", mock.Anything, class.ID, from, to).Return([]bookings.DateCounts{
+		{ClassDate: from, Booked: 2, Waitlisted: 1},
+		{ClassDate: from.AddDate(0, 0, 1), Booked: 1},
+	}, nil).Once()
+
+	availability, err := usecase.Availability(ctx, class.ID, from, to)
+	require.NoError(t, err)
+	require.Len(t, availability, 3)
+
+	assert.Equal(t, 2, availability[0].Capacity)
+	assert.Equal(t, 0, availability[0].SpotsLeft)
+	assert.Equal(t, 1, availability[0].WaitlistLength)
+	assert.False(t, availability[0].BookingOpen)
+
+	assert.Equal(t, overridden.ID, availability[1].SessionID)
+	assert.Equal(t, 3, availability[1].Capacity)
+	assert.Equal(t, 2, availability[1].SpotsLeft)
+	assert.True(t, availability[1].BookingOpen)
+
+	assert.Equal(t, 2, availability[2].SpotsLeft)
+	assert.False(t, availability[2].BookingOpen)
+}
+
+func TestUsecase_Availability_ArchivedClass(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	today := time.Now().UTC()
+	from := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	archivedAt := today
+	class := classes.Class{
+		ID:         uuid.NewString(),
+		StartDate:  from.Add(time.Hour * 18),
+		EndDate:    from.Add(time.Hour * 19),
+		Capacity:   2,
+		ArchivedAt: &archivedAt,
+	}
+
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Twice()
+	classesRepo.On("ListSessions", mock.Anything, class.ID, from, from).Return([]classes.Session{}, nil).Once()
+	repo.On("CountByDate", mock.Anything, class.ID, from, from).Return([]bookings.DateCounts{}, nil).Once()
+
+	availability, err := usecase.Availability(ctx, class.ID, from, from)
+	require.NoError(t, err)
+	require.Len(t, availability, 1)
+	assert.Equal(t, 2, availability[0].SpotsLeft)
+	assert.False(t, availability[0].BookingOpen)
+}
+
+func TestUsecase_Availability_ClassNotFound(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	classID := uuid.NewString()
+	expectedErr := pgx.ErrNoRows
+	classesRepo.On("GetByID", mock.Anything, classID).Return(classes.Class{}, expectedErr).Once()
+	classesRepo.On("IsNotFoundErr", expectedErr).Return(true).Once()
+
+	_, err := usecase.Availability(ctx, classID, time.Now(), time.Now())
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrClassNotFound))
+}
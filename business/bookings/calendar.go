@@ -0,0 +1,216 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+)
+
+// maxCalendarDays is the widest window, in days, the calendar expands schedules over.
+const maxCalendarDays = 62
+
+// Calendar expands the schedule of every active class into its occurrences from one date to
+// another, both inclusive, in the order they start. Sessions that dropped out of the class
+// schedule are left out, and so are the ones held outside the filter location, session rooms
+// overriding class rooms. When the filter names a member, the occurrences they booked carry their
+// booking.
+func (u *Usecase) Calendar(ctx context.Context, from time.Time, to time.Time, filter CalendarFilter) ([]Occurrence, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("calendar 'to' date cannot be before 'from' date: %w", ErrInvalidDateRange)
+	}
+
+	if to.Sub(from) > time.Hour*24*maxCalendarDays {
+		return nil, fmt.Errorf("calendar window cannot be longer than %d days: %w", maxCalendarDays, ErrInvalidDateRange)
+	}
+
+	memberBookings := make(map[string]Booking)
+	if filter.MemberID != "" {
+		var err error
+		memberBookings, err = u.memberCalendarBookings(ctx, filter.MemberID, from, to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var locationRoomIDs map[string]bool
+	if filter.LocationID != "" {
+		roomIDs, err := u.classesUsecase.LocationRoomIDs(ctx, filter.LocationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list location rooms: %w", err)
+		}
+
+		locationRoomIDs = make(map[string]bool, len(roomIDs))
+		for _, roomID := range roomIDs {
+			locationRoomIDs[roomID] = true
+		}
+	}
+
+	occurrences := make([]Occurrence, 0)
+	for page := 0; ; page++ {
+		classesPage, err := u.classesUsecase.ListClasses(ctx, classes.Filter{LocationID: filter.LocationID}, classes.PageInfo{Limit: 100, Page: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list classes: %w", err)
+		}
+
+		if len(classesPage) > 0 {
+			pageOccurrences, err := u.classesOccurrences(ctx, classesPage, from, to, filter.InstructorID, locationRoomIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, occurrence := range pageOccurrences {
+				if booking, ok := memberBookings[bookingKey(occurrence.ClassID, occurrence.Date)]; ok {
+					occurrence.MemberBookingID = booking.ID
+					occurrence.MemberBookingStatus = booking.Status
+				}
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+
+		if len(classesPage) < 100 {
+			break
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if !occurrences[i].StartsAt.Equal(occurrences[j].StartsAt) {
+			return occurrences[i].StartsAt.Before(occurrences[j].StartsAt)
+		}
+		return occurrences[i].ClassName < occurrences[j].ClassName
+	})
+
+	return occurrences, nil
+}
+
+// classesOccurrences lists the occurrences of the classes from one date to another, reading the
+// sessions and booking counts of every class at once.
+func (u *Usecase) classesOccurrences(ctx context.Context, classesPage []classes.Class, from time.Time, to time.Time, instructorID string,
+	locationRoomIDs map[string]bool) ([]Occurrence, error) {
+	sessionsByClass, err := u.classesUsecase.ListClassesSessions(ctx, classesPage, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list class sessions: %w", err)
+	}
+
+	classIDs := make([]string, 0, len(classesPage))
+	for _, class := range classesPage {
+		classIDs = append(classIDs, class.ID)
+	}
+
+	counts, err := u.repository.CountByClassDate(ctx, classIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bookings by date: %w", err)
+	}
+
+	countsByKey := make(map[string]DateCounts)
+	for _, dateCounts := range counts {
+		countsByKey[bookingKey(dateCounts.ClassID, dateCounts.ClassDate)] = dateCounts
+	}
+
+	occurrences := make([]Occurrence, 0)
+	for _, class := range classesPage {
+		occurrences = append(occurrences, classOccurrences(class, sessionsByClass[class.ID], countsByKey, instructorID, locationRoomIDs)...)
+	}
+
+	return occurrences, nil
+}
+
+// classOccurrences turns the sessions of a class into occurrences, keeping only the ones the
+// instructor teaches when an instructor ID is given and the ones held in the location rooms when
+// they're given. Cancelled sessions have no spots left.
+func classOccurrences(class classes.Class, sessions []classes.Session, countsByKey map[string]DateCounts, instructorID string,
+	locationRoomIDs map[string]bool) []Occurrence {
+	occurrences := make([]Occurrence, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Status == classes.SessionStatusUnscheduled {
+			continue
+		}
+
+		sessionInstructorID := class.InstructorID
+		if session.InstructorID != nil {
+			sessionInstructorID = session.InstructorID
+		}
+
+		if instructorID != "" && (sessionInstructorID == nil || *sessionInstructorID != instructorID) {
+			continue
+		}
+
+		roomID := class.RoomID
+		if session.RoomID != nil {
+			roomID = session.RoomID
+		}
+
+		if locationRoomIDs != nil && (roomID == nil || !locationRoomIDs[*roomID]) {
+			continue
+		}
+
+		capacity, spotsLeft := sessionSpots(class, session, countsByKey[bookingKey(class.ID, session.Date)])
+		if session.Status == classes.SessionStatusCancelled {
+			spotsLeft = 0
+		}
+
+		occurrences = append(occurrences, Occurrence{
+			ClassID:      class.ID,
+			ClassName:    class.Name,
+			SessionID:    session.ID,
+			Date:         session.Date,
+			StartsAt:     session.StartsAt,
+			EndsAt:       session.EndsAt,
+			Status:       session.Status,
+			InstructorID: sessionInstructorID,
+			RoomID:       roomID,
+			Capacity:     capacity,
+			SpotsLeft:    spotsLeft,
+		})
+	}
+
+	return occurrences
+}
+
+// memberCalendarBookings returns the bookings of the member from one date to another by class
+// and date. When the member booked a class date more than once, the booking that still holds a
+// spot wins over the cancelled ones.
+func (u *Usecase) memberCalendarBookings(ctx context.Context, memberID string, from time.Time, to time.Time) (map[string]Booking, error) {
+	_, err := u.membersUsecase.GetByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, members.ErrNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	bookingsByKey := make(map[string]Booking)
+	filter := Filter{MemberID: memberID, From: &from, To: &to}
+	for page := 0; ; page++ {
+		bookings, err := u.ListBookings(ctx, filter, PageInfo{Limit: 100, Page: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list member bookings: %w", err)
+		}
+
+		for _, booking := range bookings {
+			key := bookingKey(booking.ClassID, booking.ClassDate)
+			if current, ok := bookingsByKey[key]; ok && !cancelledStatus(current.Status) {
+				continue
+			}
+			bookingsByKey[key] = booking
+		}
+
+		if len(bookings) < 100 {
+			break
+		}
+	}
+
+	return bookingsByKey, nil
+}
+
+func bookingKey(classID string, classDate time.Time) string {
+	return classID + "/" + classDate.Format("2006-01-02")
+}
+
+func cancelledStatus(status Status) bool {
+	return status == StatusCancelled || status == StatusStudioCancelled
+}
@@ -0,0 +1,271 @@
+package bookings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	classesmocks "github.com/daniel-oliveiravas/class-booking-service/business/classes/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	membersmocks "github.com/daniel-oliveiravas/class-booking-service/business/members/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_Calendar(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	today := time.Now().UTC()
+	from := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	instructorID := uuid.NewString()
+	evening := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      "Evening",
+		StartDate: from.Add(time.Hour * 18),
+		EndDate:   to.Add(time.Hour * 19),
+		Capacity:  2,
+	}
+	morning := classes.Class{
+		ID:           uuid.NewString(),
+		Name:         "Morning",
+		StartDate:    from.Add(time.Hour * 7),
+		EndDate:      to.Add(time.Hour * 8),
+		Capacity:     10,
+		InstructorID: &instructorID,
+	}
+
+	substituteID := uuid.NewString()
+	substituted := morning.Session(to)
+	substituted.ID = uuid.NewString()
+	substituted.InstructorID = &substituteID
+
+	sessionCancelled := evening.Session(from)
+	sessionCancelled.ID = uuid.NewString()
+	sessionCancelled.Status = classes.SessionStatusCancelled
+
+	memberID := uuid.NewString()
+	cancelled := bookings.Booking{ID: uuid.NewString(), MemberID: memberID, ClassID: evening.ID, ClassDate: to, Status: bookings.StatusCancelled}
+	rebooked := bookings.Booking{ID: uuid.NewString(), MemberID: memberID, ClassID: evening.ID, ClassDate: to, Status: bookings.StatusBooked}
+
+	membersRepo.On("GetByID", mock.Anything, memberID).Return(members.Member{ID: memberID}, nil).Once()
+	repo.On("ListBookings", mock.Anything, bookings.Filter{MemberID: memberID, From: &from, To: &to}, 100, 0).
+		Return([]bookings.Booking{rebooked, cancelled}, nil).Once()
+	classesRepo.On("List", mock.Anything, classes.Filter{}, 100, 0).Return([]classes.Class{evening, morning}, nil).Once()
+	classesRepo.On("ListClassesSessions", mock.Anything, []string{evening.ID, morning.ID}, from, to).
+		Return([]classes.Session{sessionCancelled, substituted}, nil).Once()
+	repo.On("CountByClassDate", mock.Anything, []string{evening.ID, morning.ID}, from, to).Return([]bookings.DateCounts{
+		{ClassID: evening.ID, ClassDate: to, Booked: 1},
+	}, nil).Once()
+
+	occurrences, err := usecase.Calendar(ctx, from, to, bookings.CalendarFilter{MemberID: memberID})
+	require.NoError(t, err)
+	require.Len(t, occurrences, 4)
+
+	assert.Equal(t, morning.ID, occurrences[0].ClassID)
+	assert.Equal(t, from.Add(time.Hour*7), occurrences[0].StartsAt)
+	assert.Equal(t, &instructorID, occurrences[0].InstructorID)
+	assert.Equal(t, 10, occurrences[0].SpotsLeft)
+
+	assert.Equal(t, evening.ID, occurrences[1].ClassID)
+	assert.Equal(t, from.Add(time.Hour*18), occurrences[1].StartsAt)
+	assert.Equal(t, classes.SessionStatusCancelled, occurrences[1].Status)
+	assert.Equal(t, 0, occurrences[1].SpotsLeft)
+	assert.Empty(t, occurrences[1].MemberBookingID)
+
+	assert.Equal(t, substituted.ID, occurrences[2].SessionID)
+	assert.Equal(t, &substituteID, occurrences[2].InstructorID)
+
+	assert.Equal(t, evening.ID, occurrences[3].ClassID)
+	assert.Equal(t, 2, occurrences[3].Capacity)
+	assert.Equal(t, 1, occurrences[3].SpotsLeft)
+	assert.Equal(t, rebooked.ID, occurrences[3].MemberBookingID)
+	assert.Equal(t, bookings.StatusBooked, occurrences[3].MemberBookingStatus)
+}
+
+func TestUsecase_Calendar_FilterByInstructor(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	today := time.Now().UTC()
+	from := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	locationID := uuid.NewString()
+	roomID := uuid.NewString()
+	instructorID := uuid.NewString()
+	class := classes.Class{
+		ID:           uuid.NewString(),
+		Name:         "Morning",
+		StartDate:    from.Add(time.Hour * 7),
+		EndDate:      to.Add(time.Hour * 8),
+		Capacity:     10,
+		InstructorID: &instructorID,
+		RoomID:       &roomID,
+	}
+
+	substituteID := uuid.NewString()
+	substituted := class.Session(to)
+	substituted.ID = uuid.NewString()
+	substituted.InstructorID = &substituteID
+
+	classesRepo.On("ListRoomIDs", mock.Anything, locationID).Return([]string{roomID}, nil).Once()
+	classesRepo.On("List", mock.Anything, classes.Filter{LocationID: locationID}, 100, 0).Return([]classes.Class{class}, nil).Once()
+	classesRepo.On("ListClassesSessions", mock.Anything, []string{class.ID}, from, to).Return([]classes.Session{substituted}, nil).Once()
+	repo.On("CountByClassDate", mock.Anything, []string{class.ID}, from, to).Return([]bookings.DateCounts{}, nil).Once()
+
+	occurrences, err := usecase.Calendar(ctx, from, to, bookings.CalendarFilter{LocationID: locationID, InstructorID: substituteID})
+	require.NoError(t, err)
+	require.Len(t, occurrences, 1)
+	assert.Equal(t, substituted.ID, occurrences[0].SessionID)
+}
+
+func TestUsecase_Calendar_FilterByLocation(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	today := time.Now().UTC()
+	from := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	locationID := uuid.NewString()
+	roomID := uuid.NewString()
+	otherRoomID := uuid.NewString()
+	local := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      "Local",
+		StartDate: from.Add(time.Hour * 7),
+		EndDate:   to.Add(time.Hour * 8),
+		Capacity:  10,
+		RoomID:    &roomID,
+	}
+	visiting := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      "Visiting",
+		StartDate: from.Add(time.Hour * 18),
+		EndDate:   to.Add(time.Hour * 19),
+		Capacity:  10,
+		RoomID:    &otherRoomID,
+	}
+
+	movedOut := local.Session(to)
+	movedOut.ID = uuid.NewString()
+	movedOut.RoomID = &otherRoomID
+
+	movedIn := visiting.Session(from)
+	movedIn.ID = uuid.NewString()
+	movedIn.RoomID = &roomID
+
+	classesRepo.On("ListRoomIDs", mock.Anything, locationID).Return([]string{roomID}, nil).Once()
+	classesRepo.On("List", mock.Anything, classes.Filter{LocationID: locationID}, 100, 0).Return([]classes.Class{local, visiting}, nil).Once()
+	classesRepo.On("ListClassesSessions", mock.Anything, []string{local.ID, visiting.ID}, from, to).
+		Return([]classes.Session{movedOut, movedIn}, nil).Once()
+	repo.On("CountByClassDate", mock.Anything, []string{local.ID, visiting.ID}, from, to).Return([]bookings.DateCounts{}, nil).Once()
+
+	occurrences, err := usecase.Calendar(ctx, from, to, bookings.CalendarFilter{LocationID: locationID})
+	require.NoError(t, err)
+	require.Len(t, occurrences, 2)
+
+	assert.Equal(t, local.ID, occurrences[0].ClassID)
+	assert.Equal(t, from, occurrences[0].Date)
+	assert.Equal(t, &roomID, occurrences[0].RoomID)
+
+	assert.Equal(t, movedIn.ID, occurrences[1].SessionID)
+	assert.Equal(t, &roomID, occurrences[1].RoomID)
+}
+
+func TestUsecase_Calendar_Pages(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	today := time.Now().UTC()
+	from := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	allClasses := make([]classes.Class, 0)
+	classIDs := make([]string, 0)
+	for i := 0; i < 101; i++ {
+		class := classes.Class{ID: uuid.NewString(), Name: "Yoga", StartDate: from.Add(time.Hour * 7), EndDate: from.Add(time.Hour * 8), Capacity: 10}
+		allClasses = append(allClasses, class)
+		classIDs = append(classIDs, class.ID)
+	}
+
+	classesRepo.On("List", mock.Anything, classes.Filter{}, 100, 0).Return(allClasses[:100], nil).Once()
+	classesRepo.On("List", mock.Anything, classes.Filter{}, 100, 100).Return(allClasses[100:], nil).Once()
+	classesRepo.On("ListClassesSessions", mock.Anything, classIDs[:100], from, from).Return([]classes.Session{}, nil).Once()
+	classesRepo.On("ListClassesSessions", mock.Anything, classIDs[100:], from, from).Return([]classes.Session{}, nil).Once()
+	repo.On("CountByClassDate", mock.Anything, classIDs[:100], from, from).Return([]bookings.DateCounts{}, nil).Once()
+	repo.On("CountByClassDate", mock.Anything, classIDs[100:], from, from).Return([]bookings.DateCounts{}, nil).Once()
+
+	occurrences, err := usecase.Calendar(ctx, from, from, bookings.CalendarFilter{})
+	require.NoError(t, err)
+	require.Len(t, occurrences, 101)
+
+	occurrenceClassIDs := make(map[string]bool)
+	for _, occurrence := range occurrences {
+		occurrenceClassIDs[occurrence.ClassID] = true
+	}
+	assert.Len(t, occurrenceClassIDs, 101)
+}
+
+func TestUsecase_Calendar_InvalidDateRange(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := usecase.Calendar(ctx, from, from.AddDate(0, 0, -1), bookings.CalendarFilter{})
+	require.True(t, errors.Is(err, bookings.ErrInvalidDateRange))
+
+	_, err = usecase.Calendar(ctx, from, from.AddDate(0, 3, 0), bookings.CalendarFilter{})
+	require.True(t, errors.Is(err, bookings.ErrInvalidDateRange))
+}
+
+func TestUsecase_Calendar_MemberNotFound(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	memberID := uuid.NewString()
+	expectedErr := pgx.ErrNoRows
+	membersRepo.On("GetByID", mock.Anything, memberID).Return(members.Member{}, expectedErr).Once()
+	membersRepo.On("IsNotFoundErr", expectedErr).Return(true).Once()
+
+	_, err := usecase.Calendar(ctx, time.Now(), time.Now(), bookings.CalendarFilter{MemberID: memberID})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrMemberNotFound))
+}
@@ -0,0 +1,109 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+)
+
+// HoldClass reserves spots of the class date for the member and their guests, going through the
+// same checks as booking it. The spots are taken until the hold is confirmed or expires after the
+// configured hold TTL.
+func (u *Usecase) HoldClass(ctx context.Context, bookClass BookClass) (Hold, error) {
+	booking, limits, err := u.prepareBooking(ctx, bookClass)
+	if err != nil {
+		return Hold{}, err
+	}
+
+	hold := Hold{
+		ID:         booking.ID,
+		MemberID:   booking.MemberID,
+		ClassID:    booking.ClassID,
+		ClassDate:  booking.ClassDate,
+		Guests:     booking.Guests,
+		GuestNames: booking.GuestNames,
+
+		AllowOverlap: booking.AllowOverlap,
+	}
+
+	holdAdded, err := u.repository.HoldClass(ctx, hold, u.cfg.HoldTTL, limits)
+	if err != nil {
+		if errors.Is(err, ErrClassFull) || errors.Is(err, ErrAlreadyBooked) || errors.Is(err, ErrAlreadyHeld) ||
+			errors.Is(err, ErrSessionCancelled) || errors.Is(err, ErrBookingLimitReached) || errors.Is(err, ErrScheduleConflict) {
+			return Hold{}, err
+		}
+		return Hold{}, fmt.Errorf("failed to add hold to repository: %w", err)
+	}
+
+	return holdAdded, nil
+}
+
+func (u *Usecase) GetHold(ctx context.Context, holdID string) (Hold, error) {
+	hold, err := u.repository.GetHold(ctx, holdID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Hold{}, ErrHoldNotFound
+		}
+
+		return Hold{}, err
+	}
+
+	return hold, nil
+}
+
+// ConfirmHold turns the hold into a booking with the same ID, as long as it didn't expire. The
+// booking limits of the member are checked again, since holds don't count toward them. Confirming
+// a hold twice returns the booking it became.
+func (u *Usecase) ConfirmHold(ctx context.Context, holdID string) (Booking, error) {
+	hold, err := u.GetHold(ctx, holdID)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	class, err := u.classesUsecase.GetByID(ctx, hold.ClassID)
+	if err != nil {
+		// Deleted classes were archived before they were deleted.
+		if errors.Is(err, classes.ErrNotFound) {
+			return Booking{}, ErrClassArchived
+		}
+		return Booking{}, fmt.Errorf("failed to get hold class: %w", err)
+	}
+
+	if class.ArchivedAt != nil {
+		return Booking{}, ErrClassArchived
+	}
+
+	member, err := u.membersUsecase.GetByID(ctx, hold.MemberID)
+	if err != nil {
+		return Booking{}, fmt.Errorf("failed to get hold member: %w", err)
+	}
+
+	limits, err := u.membersUsecase.BookingLimits(ctx, member, u.cfg.Limits)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	booking, err := u.repository.ConfirmHold(ctx, holdID, limits)
+	if err != nil {
+		if errors.Is(err, ErrHoldExpired) || errors.Is(err, ErrAlreadyBooked) || errors.Is(err, ErrSessionCancelled) ||
+			errors.Is(err, ErrBookingLimitReached) {
+			return Booking{}, err
+		}
+		return Booking{}, fmt.Errorf("failed to confirm hold in repository: %w", err)
+	}
+
+	return booking, nil
+}
+
+// ReleaseExpiredHolds releases the holds that expired without being confirmed, returning how many
+// it released. Members waiting for the released spots are promoted.
+func (u *Usecase) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	released, err := u.repository.ReleaseExpiredHolds(ctx, u.cfg.Limits)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release expired holds in repository: %w", err)
+	}
+
+	return released, nil
+}
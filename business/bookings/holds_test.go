@@ -0,0 +1,146 @@
+package bookings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	classesmocks "github.com/daniel-oliveiravas/class-booking-service/business/classes/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	membersmocks "github.com/daniel-oliveiravas/class-booking-service/business/members/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_HoldClass(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	ttl := time.Minute * 5
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{HoldTTL: ttl})
+
+	now := time.Now()
+	bookClass := bookings.BookClass{
+		MemberID:   uuid.NewString(),
+		ClassID:    uuid.NewString(),
+		ClassDate:  now,
+		GuestNames: []string{"Ana"},
+	}
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1)}, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+	repo.On("HoldClass", mock.Anything, mock.MatchedBy(func(hold bookings.Hold) bool {
+		return hold.MemberID == bookClass.MemberID && hold.Guests == 1
+	}), ttl, mock.Anything).Return(bookings.Hold{ID: uuid.NewString(), Status: bookings.HoldStatusHeld}, nil).Once()
+
+	hold, err := usecase.HoldClass(ctx, bookClass)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.HoldStatusHeld, hold.Status)
+}
+
+func TestUsecase_HoldClass_ClassFull(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	now := time.Now()
+	bookClass := bookings.BookClass{MemberID: uuid.NewString(), ClassID: uuid.NewString(), ClassDate: now}
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1)}, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+	repo.On("HoldClass", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(bookings.Hold{}, bookings.ErrClassFull).Once()
+
+	_, err := usecase.HoldClass(ctx, bookClass)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrClassFull))
+}
+
+func TestUsecase_ConfirmHold(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	maxActive := 3
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{
+		Limits: members.BookingLimits{MaxActiveBookings: &maxActive},
+	})
+
+	hold := bookings.Hold{ID: uuid.NewString(), MemberID: uuid.NewString(), ClassID: uuid.NewString(), Status: bookings.HoldStatusHeld}
+	repo.On("GetHold", mock.Anything, hold.ID).Return(hold, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, hold.ClassID).Return(classes.Class{ID: hold.ClassID}, nil).Once()
+	membersRepo.On("GetByID", mock.Anything, hold.MemberID).Return(members.Member{ID: hold.MemberID}, nil).Once()
+	repo.On("ConfirmHold", mock.Anything, hold.ID, members.BookingLimits{MaxActiveBookings: &maxActive}).
+		Return(bookings.Booking{ID: hold.ID, Status: bookings.StatusBooked}, nil).Once()
+
+	booking, err := usecase.ConfirmHold(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, hold.ID, booking.ID)
+}
+
+func TestUsecase_ConfirmHold_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		getErr      error
+		archived    bool
+		confirmErr  error
+		expectedErr error
+	}{
+		{name: "hold not found", getErr: pgx.ErrNoRows, expectedErr: bookings.ErrHoldNotFound},
+		{name: "class archived", archived: true, expectedErr: bookings.ErrClassArchived},
+		{name: "hold expired", confirmErr: bookings.ErrHoldExpired, expectedErr: bookings.ErrHoldExpired},
+		{name: "limit reached", confirmErr: &bookings.LimitReachedError{Limit: bookings.LimitMaxActiveBookings, Max: 1}, expectedErr: bookings.ErrBookingLimitReached},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			membersRepo := membersmocks.NewRepository(t)
+			membersUsecase := members.NewUsecase(membersRepo)
+			classesRepo := classesmocks.NewRepository(t)
+			classesUsecase := classes.NewUsecase(classesRepo)
+			repo := mocks.NewRepository(t)
+			usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+			hold := bookings.Hold{ID: uuid.NewString(), MemberID: uuid.NewString(), ClassID: uuid.NewString()}
+			class := classes.Class{ID: hold.ClassID}
+			if tc.archived {
+				archivedAt := time.Now().UTC()
+				class.ArchivedAt = &archivedAt
+			}
+			repo.On("GetHold", mock.Anything, hold.ID).Return(hold, tc.getErr).Once()
+			if tc.getErr != nil {
+				repo.On("IsNotFoundErr", tc.getErr).Return(true).Once()
+			} else {
+				classesRepo.On("GetByID", mock.Anything, hold.ClassID).Return(class, nil).Once()
+			}
+			if tc.getErr == nil && !tc.archived {
+				membersRepo.On("GetByID", mock.Anything, hold.MemberID).Return(members.Member{ID: hold.MemberID}, nil).Once()
+				repo.On("ConfirmHold", mock.Anything, hold.ID, mock.Anything).Return(bookings.Booking{}, tc.confirmErr).Once()
+			}
+
+			_, err := usecase.ConfirmHold(ctx, hold.ID)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, tc.expectedErr))
+		})
+	}
+}
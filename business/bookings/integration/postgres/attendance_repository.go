@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/jackc/pgx/v5"
+)
+
+// CheckIn moves a booked booking to attended. Checking in twice is a no-op, while cancelled and
+// no-show bookings can't be checked in.
+func (r *BookingsRepository) CheckIn(ctx context.Context, bookingID string) (bookings.Booking, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE id = $1 FOR UPDATE`
+	booking, err := scanBooking(txn.QueryRow(ctx, query, bookingID))
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	switch booking.Status {
+	case bookings.StatusAttended:
+		return booking, nil
+	case bookings.StatusBooked:
+	default:
+		return bookings.Booking{}, fmt.Errorf("cannot check in a booking with status %s: %w", booking.Status, bookings.ErrInvalidStatusTransition)
+	}
+
+	statement := `UPDATE bookings SET status = $1, attended_at = now(), updated_at = now(), version = version + 1 WHERE id = $2
+					RETURNING ` + bookingColumns
+	booking, err = scanBooking(txn.QueryRow(ctx, statement, bookings.StatusAttended, bookingID))
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return booking, nil
+}
+
+// MarkAttended marks the bookings of the given members for a class date as attended. Nothing is
+// changed when any of the members has no active booking for it.
+func (r *BookingsRepository) MarkAttended(ctx context.Context, classID string, classDate time.Time, memberIDs []string) ([]bookings.Booking, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	statement := `UPDATE bookings
+					SET status = $1, attended_at = COALESCE(attended_at, now()), updated_at = now(), version = version + 1
+				  WHERE class_id = $2 AND class_date = $3 AND member_id = ANY($4) AND status IN ($5, $1)
+				  RETURNING ` + bookingColumns
+	rows, err := txn.Query(ctx, statement, bookings.StatusAttended, classID, classDate, memberIDs, bookings.StatusBooked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark bookings as attended: %w", err)
+	}
+
+	attended := make([]bookings.Booking, 0)
+	attendedMembers := make(map[string]bool)
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		attended = append(attended, booking)
+		attendedMembers[booking.MemberID] = true
+	}
+
+	notBooked := make([]string, 0)
+	for _, memberID := range memberIDs {
+		if !attendedMembers[memberID] {
+			notBooked = append(notBooked, memberID)
+		}
+	}
+
+	if len(notBooked) > 0 {
+		return nil, fmt.Errorf("members %s: %w", strings.Join(notBooked, ", "), bookings.ErrMemberNotBooked)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return attended, nil
+}
+
+// ListBookedUntil lists the bookings still waiting for a check-in up to the given class date.
+func (r *BookingsRepository) ListBookedUntil(ctx context.Context, classDate time.Time) ([]bookings.Booking, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE status = $1 AND class_date <= $2`
+	rows, err := txn.Query(ctx, query, bookings.StatusBooked, classDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bookings: %w", err)
+	}
+
+	booked := make([]bookings.Booking, 0)
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		booked = append(booked, booking)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return booked, nil
+}
+
+// MarkNoShows moves the given bookings to no-show, skipping the ones checked in or cancelled in the meantime.
+func (r *BookingsRepository) MarkNoShows(ctx context.Context, bookingIDs []string) (int, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	statement := `UPDATE bookings SET status = $1, no_show_at = now(), updated_at = now(), version = version + 1
+				  WHERE id = ANY($2) AND status = $3`
+	tag, err := txn.Exec(ctx, statement, bookings.StatusNoShow, bookingIDs, bookings.StatusBooked)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark bookings as no-show: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,147 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepoclass "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepomember "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_CheckIn(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 10})
+	require.NoError(t, err)
+
+	booking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	checkedIn, err := repo.CheckIn(ctx, booking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusAttended, checkedIn.Status)
+	require.NotNil(t, checkedIn.AttendedAt)
+
+	checkedInAgain, err := repo.CheckIn(ctx, booking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, checkedIn.AttendedAt, checkedInAgain.AttendedAt)
+
+	cancelled, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: now.Add(time.Hour * 24)}, members.BookingLimits{})
+	require.NoError(t, err)
+	_, err = repo.CancelBooking(ctx, cancelled.ID, false, classes.PenaltyNone, members.BookingLimits{})
+	require.NoError(t, err)
+
+	_, err = repo.CheckIn(ctx, cancelled.ID)
+	require.ErrorIs(t, err, bookings.ErrInvalidStatusTransition)
+}
+
+func TestRepository_MarkAttended(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 10})
+	require.NoError(t, err)
+
+	memberIDs := make([]string, 0)
+	for i := 0; i < 2; i++ {
+		memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+
+		_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+		require.NoError(t, err)
+		memberIDs = append(memberIDs, memberAdded.ID)
+	}
+
+	notBooked, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	_, err = repo.MarkAttended(ctx, classAdded.ID, now, append(memberIDs, notBooked.ID))
+	require.ErrorIs(t, err, bookings.ErrMemberNotBooked)
+
+	stillBooked, err := repo.ListBookings(ctx, bookings.Filter{Statuses: []bookings.Status{bookings.StatusAttended}}, 100, 0)
+	require.NoError(t, err)
+	assert.Len(t, stillBooked, 0)
+
+	attended, err := repo.MarkAttended(ctx, classAdded.ID, now, memberIDs)
+	require.NoError(t, err)
+	require.Len(t, attended, 2)
+	for _, booking := range attended {
+		assert.Equal(t, bookings.StatusAttended, booking.Status)
+	}
+}
+
+func TestRepository_MarkNoShows(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	yesterday := time.Now().UTC().Add(-time.Hour * 24)
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: yesterday, EndDate: yesterday, Capacity: 10})
+	require.NoError(t, err)
+
+	bookingIDs := make([]string, 0)
+	for i := 0; i < 2; i++ {
+		memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+
+		booking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: yesterday}, members.BookingLimits{})
+		require.NoError(t, err)
+		bookingIDs = append(bookingIDs, booking.ID)
+	}
+
+	_, err = repo.CheckIn(ctx, bookingIDs[0])
+	require.NoError(t, err)
+
+	booked, err := repo.ListBookedUntil(ctx, yesterday)
+	require.NoError(t, err)
+	require.Len(t, booked, 1)
+	assert.Equal(t, bookingIDs[1], booked[0].ID)
+
+	marked, err := repo.MarkNoShows(ctx, bookingIDs)
+	require.NoError(t, err)
+	assert.Equal(t, 1, marked)
+
+	noShow, err := repo.GetByID(ctx, bookingIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusNoShow, noShow.Status)
+	require.NotNil(t, noShow.NoShowAt)
+}
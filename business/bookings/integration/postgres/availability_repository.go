@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/jackc/pgx/v5"
+)
+
+// CountByDate counts the spots taken by active bookings and holds, guests included, and the
+// waiting members of a class on every date from one date to another, both inclusive, in a single
+// query. Dates without any are left out.
+func (r *BookingsRepository) CountByDate(ctx context.Context, classID string, from time.Time, to time.Time) ([]bookings.DateCounts, error) {
+	return r.CountByClassDate(ctx, []string{classID}, from, to)
+}
+
+// CountByClassDate counts as CountByDate does for every one of the classes at once.
+func (r *BookingsRepository) CountByClassDate(ctx context.Context, classIDs []string, from time.Time, to time.Time) ([]bookings.DateCounts, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT class_id, class_date, SUM(booked), SUM(waitlisted)
+				FROM (SELECT class_id, class_date, 1 + guests AS booked, 0 AS waitlisted
+						FROM bookings
+					  WHERE class_id = ANY($1) AND class_date BETWEEN $2 AND $3 AND ` + activeBookingCondition + `
+					  UNION ALL
+					  SELECT class_id, class_date, 1 + guests AS booked, 0 AS waitlisted
+						FROM booking_holds
+					  WHERE class_id = ANY($1) AND class_date BETWEEN $2 AND $3 AND ` + activeHoldCondition + `
+					  UNION ALL
+					  SELECT class_id, class_date, 0 AS booked, 1 AS waitlisted
+						FROM waitlist_entries
+					  WHERE class_id = ANY($1) AND class_date BETWEEN $2 AND $3 AND status = 'waiting') counts
+			  GROUP BY class_id, class_date
+			  ORDER BY class_id, class_date;`
+	rows, err := txn.Query(ctx, query, classIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bookings by date: %w", err)
+	}
+
+	allCounts := make([]bookings.DateCounts, 0)
+	for rows.Next() {
+		var counts bookings.DateCounts
+		err := rows.Scan(&counts.ClassID, &counts.ClassDate, &counts.Booked, &counts.Waitlisted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking counts: %w", err)
+		}
+
+		allCounts = append(allCounts, counts)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return allCounts, nil
+}
@@ -0,0 +1,78 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepoclass "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepomember "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_CountByDate(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	today := time.Now().UTC()
+	firstDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	secondDate := firstDate.AddDate(0, 0, 1)
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: firstDate, EndDate: firstDate.AddDate(0, 0, 5), Capacity: 1})
+	require.NoError(t, err)
+
+	memberIDs := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+		memberIDs = append(memberIDs, member.ID)
+	}
+
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberIDs[0], ClassID: classAdded.ID, ClassDate: firstDate}, members.BookingLimits{})
+	require.NoError(t, err)
+	_, err = repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: memberIDs[1], ClassID: classAdded.ID, ClassDate: firstDate})
+	require.NoError(t, err)
+	_, err = repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: memberIDs[2], ClassID: classAdded.ID, ClassDate: firstDate})
+	require.NoError(t, err)
+
+	cancelled, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberIDs[0], ClassID: classAdded.ID, ClassDate: secondDate}, members.BookingLimits{})
+	require.NoError(t, err)
+	_, err = repo.CancelBooking(ctx, cancelled.ID, false, classes.PenaltyNone, members.BookingLimits{})
+	require.NoError(t, err)
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberIDs[1], ClassID: classAdded.ID, ClassDate: secondDate}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	counts, err := repo.CountByDate(ctx, classAdded.ID, firstDate, firstDate.AddDate(0, 0, 5))
+	require.NoError(t, err)
+	assert.Equal(t, []bookings.DateCounts{
+		{ClassID: classAdded.ID, ClassDate: firstDate, Booked: 1, Waitlisted: 2},
+		{ClassID: classAdded.ID, ClassDate: secondDate, Booked: 1, Waitlisted: 0},
+	}, counts)
+
+	otherClass, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: firstDate, EndDate: firstDate.AddDate(0, 0, 5), Capacity: 1})
+	require.NoError(t, err)
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberIDs[2], ClassID: otherClass.ID, ClassDate: secondDate}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	counts, err = repo.CountByClassDate(ctx, []string{classAdded.ID, otherClass.ID}, secondDate, secondDate)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []bookings.DateCounts{
+		{ClassID: classAdded.ID, ClassDate: secondDate, Booked: 1, Waitlisted: 0},
+		{ClassID: otherClass.ID, ClassDate: secondDate, Booked: 1, Waitlisted: 0},
+	}, counts)
+}
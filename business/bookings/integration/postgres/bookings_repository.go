@@ -4,13 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
+const (
+	bookingColumns = `id, booked_at, updated_at, member_id, class_id, class_date, status, cancelled_at, attended_at, no_show_at, late_cancel, cancel_penalty, session_id, refunded_at, guests, guest_names, version, credits_used`
+
+	// activeBookingCondition matches the bookings that hold spots in a class date.
+	activeBookingCondition = `status IN ('booked', 'attended', 'no_show')`
+
+	// activeHoldCondition matches the holds that hold spots in a class date.
+	activeHoldCondition = `status = 'held' AND expires_at > now()`
+
+	// bookedSpots sums the spots taken by bookings or holds, one for the member and one for every guest.
+	bookedSpots = `COALESCE(SUM(1 + guests), 0)`
+
+	// sessionIDQuery selects the session of the class ($3) on the class date ($4) of a booking being inserted.
+	sessionIDQuery = `SELECT id FROM class_sessions WHERE class_id = $3 AND session_date = $4`
+)
+
 type BookingsRepository struct {
 	logger *zap.SugaredLogger
 	db     *pgxpool.Pool
@@ -23,7 +43,11 @@ func NewBookingsRepository(logger *zap.SugaredLogger, db *pgxpool.Pool) *Booking
 	}
 }
 
-func (r *BookingsRepository) BookClass(ctx context.Context, booking bookings.Booking) (bookings.Booking, error) {
+// BookClass books the class date for the member, unless the class date is full or the member
+// would go over one of the booking limits. Classes are locked before members, the same order
+// every transaction touching both follows. Guests are booked along with the member, so the class
+// date needs a spot for each of them.
+func (r *BookingsRepository) BookClass(ctx context.Context, booking bookings.Booking, limits members.BookingLimits) (bookings.Booking, error) {
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadWrite,
 	})
@@ -33,15 +57,36 @@ func (r *BookingsRepository) BookClass(ctx context.Context, booking bookings.Boo
 
 	defer tx.Rollback(ctx)
 
-	insertBooking := `INSERT INTO bookings (id, member_id, class_id, class_date)
-				VALUES ($1, $2, $3, $4)
-				RETURNING id, booked_at, updated_at, member_id, class_id, class_date`
-	row := tx.QueryRow(ctx, insertBooking, booking.ID, booking.MemberID, booking.ClassID, booking.ClassDate)
+	capacity, booked, err := r.lockClassTxn(ctx, tx, booking.ClassID, booking.ClassDate)
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if err := r.checkSessionNotCancelledTxn(ctx, tx, booking.ClassID, booking.ClassDate); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if err := r.checkNotBookedTxn(ctx, tx, booking.MemberID, booking.ClassID, booking.ClassDate); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if err := r.checkBookingLimitsTxn(ctx, tx, booking, limits); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if !booking.AllowOverlap {
+		if err := r.checkScheduleConflictTxn(ctx, tx, booking); err != nil {
+			return bookings.Booking{}, err
+		}
+	}
+
+	if booked+booking.Spots() > capacity {
+		return bookings.Booking{}, bookings.ErrClassFull
+	}
 
-	var storedBooking bookings.Booking
-	err = row.Scan(&storedBooking.ID, &storedBooking.BookedAt, &storedBooking.UpdatedAt, &storedBooking.MemberID, &storedBooking.ClassID, &storedBooking.ClassDate)
+	storedBooking, err := r.insertBookingTxn(ctx, tx, booking)
 	if err != nil {
-		return bookings.Booking{}, fmt.Errorf("failed to scan bookings row to bookings.Booking: %w", err)
+		return bookings.Booking{}, err
 	}
 
 	err = tx.Commit(ctx)
@@ -52,6 +97,28 @@ func (r *BookingsRepository) BookClass(ctx context.Context, booking bookings.Boo
 	return storedBooking, nil
 }
 
+// insertBookingTxn stores the booking in the session of its class date and takes the member off
+// the waitlist of the class date.
+func (r *BookingsRepository) insertBookingTxn(ctx context.Context, txn pgx.Tx, booking bookings.Booking) (bookings.Booking, error) {
+	insertBooking := `INSERT INTO bookings (id, member_id, class_id, class_date, session_id, guests, guest_names)
+				VALUES ($1, $2, $3, $4, (` + sessionIDQuery + `), $5, COALESCE($6::text[], '{}'))
+				RETURNING ` + bookingColumns
+	row := txn.QueryRow(ctx, insertBooking, booking.ID, booking.MemberID, booking.ClassID, booking.ClassDate, booking.Guests, booking.GuestNames)
+
+	storedBooking, err := scanBooking(row)
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	leaveWaitlist := `DELETE FROM waitlist_entries WHERE member_id = $1 AND class_id = $2 AND class_date = $3`
+	_, err = txn.Exec(ctx, leaveWaitlist, booking.MemberID, booking.ClassID, booking.ClassDate)
+	if err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to remove booked member from waitlist: %w", err)
+	}
+
+	return storedBooking, nil
+}
+
 func (r *BookingsRepository) GetByID(ctx context.Context, bookingID string) (bookings.Booking, error) {
 	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadOnly,
@@ -74,14 +141,34 @@ func (r *BookingsRepository) GetByID(ctx context.Context, bookingID string) (boo
 }
 
 func (r *BookingsRepository) getByIdTxn(ctx context.Context, txn pgx.Tx, bookingID string) (bookings.Booking, error) {
-	query := `SELECT id, booked_at, updated_at, member_id, class_id, class_date FROM bookings WHERE id = $1;`
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE id = $1;`
 
 	row := txn.QueryRow(ctx, query, bookingID)
+	return scanBooking(row)
+}
+
+func scanBooking(row pgx.Row) (bookings.Booking, error) {
 	var booking bookings.Booking
-	err := row.Scan(&booking.ID, &booking.BookedAt, &booking.UpdatedAt, &booking.MemberID, &booking.ClassID, &booking.ClassDate)
+	var cancelPenalty *classes.Penalty
+	var sessionID *string
+	err := row.Scan(&booking.ID, &booking.BookedAt, &booking.UpdatedAt, &booking.MemberID, &booking.ClassID, &booking.ClassDate,
+		&booking.Status, &booking.CancelledAt, &booking.AttendedAt, &booking.NoShowAt, &booking.LateCancel, &cancelPenalty, &sessionID,
+		&booking.RefundedAt, &booking.Guests, &booking.GuestNames, &booking.Version, &booking.CreditsUsed)
 	if err != nil {
 		return bookings.Booking{}, fmt.Errorf("failed to scan bookings row to bookings.Booking: %w", err)
 	}
+
+	if sessionID != nil {
+		booking.SessionID = *sessionID
+	}
+
+	if cancelPenalty != nil {
+		booking.CancelPenalty = *cancelPenalty
+	}
+
+	if len(booking.GuestNames) == 0 {
+		booking.GuestNames = nil
+	}
 	return booking, nil
 }
 
@@ -89,30 +176,339 @@ func (r *BookingsRepository) IsNotFoundErr(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows)
 }
 
-func (r *BookingsRepository) DeleteBooking(ctx context.Context, bookingID string) error {
+// CancelBooking moves a booked booking to cancelled, recording whether it was a late cancel and
+// applying the penalty to the member in the same transaction. Cancelling a booking that is
+// already cancelled, by the member or the studio, returns it untouched, while attended and no-show bookings can't be cancelled anymore.
+// The members waiting for the freed spots are promoted, following studioLimits where their plan sets no limits.
+func (r *BookingsRepository) CancelBooking(ctx context.Context, bookingID string, late bool, penalty classes.Penalty,
+	studioLimits members.BookingLimits) (bookings.Booking, error) {
 	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadWrite,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return bookings.Booking{}, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	defer txn.Rollback(ctx)
 
-	statement := `DELETE FROM bookings WHERE id = $1`
-	_, err = txn.Exec(ctx, statement, bookingID)
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE id = $1 FOR UPDATE`
+	booking, err := scanBooking(txn.QueryRow(ctx, query, bookingID))
 	if err != nil {
-		return fmt.Errorf("failed to delete booking: %w", err)
+		return bookings.Booking{}, err
+	}
+
+	switch booking.Status {
+	case bookings.StatusCancelled, bookings.StatusStudioCancelled:
+		return booking, nil
+	case bookings.StatusBooked:
+	default:
+		return bookings.Booking{}, fmt.Errorf("cannot cancel a booking with status %s: %w", booking.Status, bookings.ErrInvalidStatusTransition)
+	}
+
+	statement := `UPDATE bookings SET status = $1, cancelled_at = now(), updated_at = now(), version = version + 1, late_cancel = $2, cancel_penalty = $3
+					WHERE id = $4
+					RETURNING ` + bookingColumns
+	booking, err = scanBooking(txn.QueryRow(ctx, statement, bookings.StatusCancelled, late, penalty, bookingID))
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if err := r.promoteFromWaitlistTxn(ctx, txn, booking.ClassID, booking.ClassDate, studioLimits); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	creditsTaken, err := r.applyPenaltyTxn(ctx, txn, booking.MemberID, penalty)
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if creditsTaken > 0 {
+		statement := `UPDATE bookings SET credits_used = credits_used + $2 WHERE id = $1 RETURNING ` + bookingColumns
+		booking, err = scanBooking(txn.QueryRow(ctx, statement, bookingID, creditsTaken))
+		if err != nil {
+			return bookings.Booking{}, err
+		}
 	}
 
 	if err := txn.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction. :%w", err)
+		return bookings.Booking{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return booking, nil
+}
+
+// applyPenaltyTxn gives the member a strike or takes one of their credits, depending on the
+// penalty, returning how many credits it took. Members without credits left lose none.
+func (r *BookingsRepository) applyPenaltyTxn(ctx context.Context, txn pgx.Tx, memberID string, penalty classes.Penalty) (int, error) {
+	var statement string
+	switch penalty {
+	case classes.PenaltyStrike:
+		statement = `UPDATE members SET strikes = strikes + 1, updated_at = now(), version = version + 1 WHERE id = $1`
+	case classes.PenaltyCredit:
+		statement = `UPDATE members SET credits = credits - 1, updated_at = now(), version = version + 1 WHERE id = $1 AND credits > 0`
+	default:
+		return 0, nil
+	}
+
+	tag, err := txn.Exec(ctx, statement, memberID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply %s penalty to member: %w", penalty, err)
+	}
+
+	if penalty == classes.PenaltyCredit {
+		return int(tag.RowsAffected()), nil
+	}
+
+	return 0, nil
+}
+
+// promoteFromWaitlistTxn books members on the waitlist of a class date in the order they joined,
+// while the class date has free spots. A cancelled booking with guests frees more than one spot.
+// The waitlist entry ID is reused as the booking ID, so members can look their booking up with the
+// ID they got when joining the waitlist. Members the booking would take over their booking limits,
+// the ones of their plan or otherwise studioLimits, or into a session overlapping another of their
+// bookings are skipped, staying on the waitlist, and the next member in line is promoted instead.
+func (r *BookingsRepository) promoteFromWaitlistTxn(ctx context.Context, txn pgx.Tx, classID string, classDate time.Time,
+	studioLimits members.BookingLimits) error {
+	capacity, booked, err := r.lockClassTxn(ctx, txn, classID, classDate)
+	if err != nil {
+		return err
+	}
+
+	skipped := make([]string, 0)
+	for booked < capacity {
+		entry, found, err := r.firstWaitingTxn(ctx, txn, classID, classDate, skipped)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return nil
+		}
+
+		err = r.checkPromotionTxn(ctx, txn, entry, studioLimits)
+		if errors.Is(err, bookings.ErrBookingLimitReached) || errors.Is(err, bookings.ErrScheduleConflict) {
+			skipped = append(skipped, entry.ID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := r.promoteTxn(ctx, txn, entry); err != nil {
+			return err
+		}
+		booked++
+	}
+
+	return nil
+}
+
+// firstWaitingTxn returns the first member waiting for the class date, leaving out the skipped
+// waitlist entries, and tells whether there was one.
+func (r *BookingsRepository) firstWaitingTxn(ctx context.Context, txn pgx.Tx, classID string, classDate time.Time,
+	skipped []string) (bookings.WaitlistEntry, bool, error) {
+	entry := bookings.WaitlistEntry{ClassID: classID, ClassDate: classDate}
+	query := `SELECT w.id, w.member_id FROM waitlist_entries w
+				WHERE w.class_id = $1 AND w.class_date = $2 AND w.status = 'waiting' AND NOT (w.id = ANY($3))
+				AND NOT EXISTS (SELECT 1 FROM bookings b
+					WHERE b.member_id = w.member_id AND b.class_id = w.class_id AND b.class_date = w.class_date
+					AND b.` + activeBookingCondition + `)
+			  ORDER BY w.joined_at, w.id
+			  LIMIT 1`
+	err := txn.QueryRow(ctx, query, classID, classDate, skipped).Scan(&entry.ID, &entry.MemberID)
+	if err != nil {
+		if r.IsNotFoundErr(err) {
+			return bookings.WaitlistEntry{}, false, nil
+		}
+		return bookings.WaitlistEntry{}, false, fmt.Errorf("failed to get first waitlist entry: %w", err)
+	}
+
+	return entry, true, nil
+}
+
+// checkPromotionTxn runs the checks of booking the class date on the member waiting for it: their
+// booking limits, the ones of their plan or otherwise studioLimits, and their overlapping bookings.
+func (r *BookingsRepository) checkPromotionTxn(ctx context.Context, txn pgx.Tx, entry bookings.WaitlistEntry, studioLimits members.BookingLimits) error {
+	var planLimits members.BookingLimits
+	query := `SELECT p.max_active_bookings, p.max_bookings_per_day, p.max_bookings_per_class_per_week
+				FROM members m
+				LEFT JOIN plans p ON p.id = m.plan_id
+			  WHERE m.id = $1`
+	err := txn.QueryRow(ctx, query, entry.MemberID).Scan(&planLimits.MaxActiveBookings, &planLimits.MaxBookingsPerDay,
+		&planLimits.MaxBookingsPerClassPerWeek)
+	if err != nil {
+		return fmt.Errorf("failed to get waiting member limits: %w", err)
+	}
+
+	booking := bookings.Booking{ID: entry.ID, MemberID: entry.MemberID, ClassID: entry.ClassID, ClassDate: entry.ClassDate}
+	if err := r.checkBookingLimitsTxn(ctx, txn, booking, planLimits.Or(studioLimits)); err != nil {
+		return err
+	}
+
+	return r.checkScheduleConflictTxn(ctx, txn, booking)
+}
+
+// promoteTxn books the class date for the member waiting for it, removing them from the waitlist.
+func (r *BookingsRepository) promoteTxn(ctx context.Context, txn pgx.Tx, entry bookings.WaitlistEntry) error {
+	insertBooking := `INSERT INTO bookings (id, member_id, class_id, class_date, session_id)
+				VALUES ($1, $2, $3, $4, (` + sessionIDQuery + `))`
+	_, err := txn.Exec(ctx, insertBooking, entry.ID, entry.MemberID, entry.ClassID, entry.ClassDate)
+	if err != nil {
+		return fmt.Errorf("failed to promote waitlist entry to booking: %w", err)
+	}
+
+	deleteEntry := `DELETE FROM waitlist_entries WHERE id = $1`
+	_, err = txn.Exec(ctx, deleteEntry, entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete promoted waitlist entry: %w", err)
+	}
+
+	return nil
+}
+
+// checkSessionNotCancelledTxn returns bookings.ErrSessionCancelled when the studio cancelled the class session on the date.
+func (r *BookingsRepository) checkSessionNotCancelledTxn(ctx context.Context, txn pgx.Tx, classID string, classDate time.Time) error {
+	var cancelled bool
+	query := `SELECT EXISTS (SELECT 1 FROM class_sessions WHERE class_id = $1 AND session_date = $2 AND status = $3)`
+	err := txn.QueryRow(ctx, query, classID, classDate, classes.SessionStatusCancelled).Scan(&cancelled)
+	if err != nil {
+		return fmt.Errorf("failed to check session status: %w", err)
+	}
+
+	if cancelled {
+		return bookings.ErrSessionCancelled
+	}
+
+	return nil
+}
+
+// checkBookingLimitsTxn returns a *bookings.LimitReachedError when booking would take the member
+// over one of the limits. The member row is locked first, so concurrent bookings of the same
+// member are counted one after the other.
+func (r *BookingsRepository) checkBookingLimitsTxn(ctx context.Context, txn pgx.Tx, booking bookings.Booking, limits members.BookingLimits) error {
+	if limits.MaxActiveBookings == nil && limits.MaxBookingsPerDay == nil && limits.MaxBookingsPerClassPerWeek == nil {
+		return nil
+	}
+
+	if err := r.lockMemberTxn(ctx, txn, booking.MemberID); err != nil {
+		return err
+	}
+
+	var active, sameDay, sameClassWeek int
+	countBookings := `SELECT COUNT(*) FILTER (WHERE status = 'booked' AND class_date >= (SELECT (now() AT TIME ZONE c.timezone)::date FROM classes c WHERE c.id = bookings.class_id)),
+					COUNT(*) FILTER (WHERE class_date = $2),
+					COUNT(*) FILTER (WHERE class_id = $3 AND date_trunc('week', class_date) = date_trunc('week', $2::date))
+				FROM bookings
+			  WHERE member_id = $1 AND ` + activeBookingCondition
+	err := txn.QueryRow(ctx, countBookings, booking.MemberID, booking.ClassDate, booking.ClassID).Scan(&active, &sameDay, &sameClassWeek)
+	if err != nil {
+		return fmt.Errorf("failed to count member bookings: %w", err)
+	}
+
+	checks := []struct {
+		limit  bookings.Limit
+		max    *int
+		booked int
+	}{
+		{limit: bookings.LimitMaxActiveBookings, max: limits.MaxActiveBookings, booked: active},
+		{limit: bookings.LimitMaxBookingsPerDay, max: limits.MaxBookingsPerDay, booked: sameDay},
+		{limit: bookings.LimitMaxBookingsPerClassPerWeek, max: limits.MaxBookingsPerClassPerWeek, booked: sameClassWeek},
+	}
+	for _, check := range checks {
+		if check.max != nil && check.booked >= *check.max {
+			return &bookings.LimitReachedError{Limit: check.limit, Max: *check.max}
+		}
 	}
 
 	return nil
 }
 
-func (r *BookingsRepository) ListBookings(ctx context.Context, limit int, offset int) ([]bookings.Booking, error) {
+// checkScheduleConflictTxn returns a *bookings.ScheduleConflictError when the session of the
+// booking overlaps the session of another active booking of the member. Sessions that only touch
+// don't overlap, and bookings of the same class date are left to checkNotBookedTxn. The member row
+// is locked first, so concurrent bookings of the same member can't both miss each other.
+func (r *BookingsRepository) checkScheduleConflictTxn(ctx context.Context, txn pgx.Tx, booking bookings.Booking) error {
+	if err := r.lockMemberTxn(ctx, txn, booking.MemberID); err != nil {
+		return err
+	}
+
+	// Sessions never run past the day after their date, so the neighbouring dates hold every
+	// booking that can overlap.
+	conflicting := `SELECT ` + bookingColumns + ` FROM bookings WHERE id = (
+					SELECT b.id
+					  FROM (SELECT * FROM bookings WHERE member_id = $1 AND ` + activeBookingCondition + `) b
+					  JOIN class_sessions s ON s.class_id = b.class_id AND s.session_date = b.class_date
+					  JOIN class_sessions booked ON booked.class_id = $2 AND booked.session_date = $3
+					WHERE b.class_date BETWEEN $3::date - 1 AND $3::date + 1
+					  AND NOT (b.class_id = $2 AND b.class_date = $3)
+					  AND s.starts_at < booked.ends_at AND booked.starts_at < s.ends_at
+					ORDER BY s.starts_at
+					LIMIT 1)`
+	other, err := scanBooking(txn.QueryRow(ctx, conflicting, booking.MemberID, booking.ClassID, booking.ClassDate))
+	if err != nil {
+		if r.IsNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check overlapping bookings: %w", err)
+	}
+
+	return &bookings.ScheduleConflictError{Booking: other}
+}
+
+// lockMemberTxn locks the member row, serializing the bookings of the member.
+func (r *BookingsRepository) lockMemberTxn(ctx context.Context, txn pgx.Tx, memberID string) error {
+	lockMember := `SELECT id FROM members WHERE id = $1 FOR UPDATE`
+	_, err := txn.Exec(ctx, lockMember, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to lock member: %w", err)
+	}
+
+	return nil
+}
+
+// checkNotBookedTxn returns a *bookings.AlreadyBookedError when the member already holds a booking for the class date.
+func (r *BookingsRepository) checkNotBookedTxn(ctx context.Context, txn pgx.Tx, memberID string, classID string, classDate time.Time) error {
+	var bookingID string
+	query := `SELECT id FROM bookings WHERE member_id = $1 AND class_id = $2 AND class_date = $3 AND ` + activeBookingCondition
+	err := txn.QueryRow(ctx, query, memberID, classID, classDate).Scan(&bookingID)
+	if err != nil {
+		if r.IsNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check existing bookings: %w", err)
+	}
+
+	return &bookings.AlreadyBookedError{BookingID: bookingID}
+}
+
+// lockClassTxn locks the class row and returns the capacity of the class date, which the session
+// can override, along with how many spots are booked or held for it. Holding the lock serializes
+// concurrent bookings of the same class, so capacity checks cannot be raced by another
+// transaction taking the last spot. Members and their guests take a spot each.
+func (r *BookingsRepository) lockClassTxn(ctx context.Context, txn pgx.Tx, classID string, classDate time.Time) (int, int, error) {
+	var capacity int
+	lockClass := `SELECT COALESCE(s.capacity, c.capacity) FROM classes c
+					LEFT JOIN class_sessions s ON s.class_id = c.id AND s.session_date = $2
+				  WHERE c.id = $1
+				  FOR UPDATE OF c`
+	err := txn.QueryRow(ctx, lockClass, classID, classDate).Scan(&capacity)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to lock class: %w", err)
+	}
+
+	var booked int
+	countSpots := `SELECT (SELECT ` + bookedSpots + ` FROM bookings WHERE class_id = $1 AND class_date = $2 AND ` + activeBookingCondition + `)
+					+ (SELECT ` + bookedSpots + ` FROM booking_holds WHERE class_id = $1 AND class_date = $2 AND ` + activeHoldCondition + `)`
+	err = txn.QueryRow(ctx, countSpots, classID, classDate).Scan(&booked)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count class bookings: %w", err)
+	}
+
+	return capacity, booked, nil
+}
+
+func (r *BookingsRepository) ListBookings(ctx context.Context, filter bookings.Filter, limit int, offset int) ([]bookings.Booking, error) {
 	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadOnly,
 	})
@@ -120,21 +516,60 @@ func (r *BookingsRepository) ListBookings(ctx context.Context, limit int, offset
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	query := `SELECT id, booked_at, updated_at, member_id, class_id, class_date
-				FROM bookings m
-			  LIMIT $1 OFFSET $2;`
+	defer txn.Rollback(ctx)
+
+	values := make([]interface{}, 0)
+	conditions := make([]string, 0)
+
+	if filter.MemberID != "" {
+		values = append(values, filter.MemberID)
+		conditions = append(conditions, fmt.Sprintf("member_id = $%d", len(values)))
+	}
+
+	if filter.ClassID != "" {
+		values = append(values, filter.ClassID)
+		conditions = append(conditions, fmt.Sprintf("class_id = $%d", len(values)))
+	}
+
+	if filter.From != nil {
+		values = append(values, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("class_date >= $%d", len(values)))
+	}
+
+	if filter.To != nil {
+		values = append(values, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("class_date <= $%d", len(values)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		values = append(values, statuses)
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(values)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	values = append(values, limit, offset)
+	query := `SELECT ` + bookingColumns + `
+				FROM bookings ` + where + `
+			  ORDER BY booked_at, id` + fmt.Sprintf(" LIMIT $%d OFFSET $%d;", len(values)-1, len(values))
 
-	rows, err := txn.Query(ctx, query, limit, offset)
+	rows, err := txn.Query(ctx, query, values...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query bookings: %w", err)
 	}
 
 	allbookings := make([]bookings.Booking, 0)
 	for rows.Next() {
-		var booking bookings.Booking
-		err := rows.Scan(&booking.ID, &booking.BookedAt, &booking.UpdatedAt, &booking.MemberID, &booking.ClassID, &booking.ClassDate)
+		booking, err := scanBooking(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan bookings row to bookings.Booking: %w", err)
+			return nil, err
 		}
 
 		allbookings = append(allbookings, booking)
@@ -2,7 +2,9 @@ package postgres_test
 
 import (
 	"context"
+	"errors"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -83,16 +85,176 @@ func TestRepository_AddBooking(t *testing.T) {
 		ClassID:   classAdded.ID,
 		ClassDate: classDate,
 	}
-	booking, err := repo.BookClass(ctx, bookClass)
+	booking, err := repo.BookClass(ctx, bookClass, members.BookingLimits{})
 	require.NoError(t, err)
 
 	assert.Equal(t, bookClass.ID, booking.ID)
 	assert.Equal(t, bookClass.MemberID, booking.MemberID)
 	assert.Equal(t, bookClass.ClassID, booking.ClassID)
+	assert.Equal(t, bookings.StatusBooked, booking.Status)
 	assert.NotEmpty(t, booking.BookedAt)
 	assert.NotEmpty(t, booking.UpdatedAt)
 }
 
+func TestRepository_BookClass_ConcurrentBookingsRespectCapacity(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	now := time.Now().UTC()
+	class := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      uuid.NewString(),
+		StartDate: now,
+		EndDate:   now,
+		Capacity:  5,
+	}
+	classAdded, err := classRepo.Add(ctx, class)
+	require.NoError(t, err)
+
+	attempts := 20
+	bookingsToAdd := make([]bookings.Booking, 0, attempts)
+	for i := 0; i < attempts; i++ {
+		memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+
+		bookingsToAdd = append(bookingsToAdd, bookings.Booking{
+			ID:        uuid.NewString(),
+			MemberID:  memberAdded.ID,
+			ClassID:   classAdded.ID,
+			ClassDate: now,
+		})
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for _, booking := range bookingsToAdd {
+		wg.Add(1)
+		go func(booking bookings.Booking) {
+			defer wg.Done()
+			_, err := repo.BookClass(ctx, booking, members.BookingLimits{})
+			errs <- err
+		}(booking)
+	}
+	wg.Wait()
+	close(errs)
+
+	var booked, full int
+	for err := range errs {
+		switch {
+		case err == nil:
+			booked++
+		case errors.Is(err, bookings.ErrClassFull):
+			full++
+		default:
+			require.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, class.Capacity, booked)
+	assert.Equal(t, attempts-class.Capacity, full)
+
+	allBookings, err := repo.ListBookings(ctx, bookings.Filter{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, class.Capacity, len(allBookings))
+}
+
+func TestRepository_BookClass_AlreadyBooked(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 20})
+	require.NoError(t, err)
+
+	booking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrAlreadyBooked)
+
+	var alreadyBooked *bookings.AlreadyBookedError
+	require.True(t, errors.As(err, &alreadyBooked))
+	assert.Equal(t, booking.ID, alreadyBooked.BookingID)
+
+	allBookings, err := repo.ListBookings(ctx, bookings.Filter{}, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(allBookings))
+}
+
+func TestRepository_BookClass_ScheduleConflict(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	date := time.Date(2030, time.March, 4, 0, 0, 0, 0, time.UTC)
+	dailyAt := func(startTime string) classes.Class {
+		class, err := classRepo.Add(ctx, classes.Class{
+			ID:         uuid.NewString(),
+			Name:       uuid.NewString(),
+			StartDate:  date,
+			EndDate:    date.AddDate(0, 0, 7),
+			Capacity:   10,
+			Recurrence: &classes.Recurrence{Frequency: classes.FrequencyDaily, StartTime: startTime, DurationMinutes: 60},
+		})
+		require.NoError(t, err)
+		_, err = classRepo.EnsureSession(ctx, class.Session(date))
+		require.NoError(t, err)
+		return class
+	}
+
+	memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	booked := dailyAt("09:00")
+	booking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: booked.ID, ClassDate: date}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	overlapping := bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: dailyAt("09:30").ID, ClassDate: date}
+	_, err = repo.BookClass(ctx, overlapping, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrScheduleConflict)
+
+	var scheduleConflict *bookings.ScheduleConflictError
+	require.True(t, errors.As(err, &scheduleConflict))
+	assert.Equal(t, booking.ID, scheduleConflict.Booking.ID)
+
+	_, err = repo.HoldClass(ctx, bookings.Hold{ID: uuid.NewString(), MemberID: overlapping.MemberID, ClassID: overlapping.ClassID, ClassDate: date},
+		time.Minute, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrScheduleConflict)
+
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: dailyAt("10:00").ID, ClassDate: date}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	overlapping.AllowOverlap = true
+	_, err = repo.BookClass(ctx, overlapping, members.BookingLimits{})
+	require.NoError(t, err)
+}
+
 func TestRepository_GetByID(t *testing.T) {
 	if os.Getenv("INTEGRATION") == "" {
 		t.Skip("skipping integration tests: set INTEGRATION environment variable")
@@ -130,7 +292,7 @@ func TestRepository_GetByID(t *testing.T) {
 		ClassID:   classAdded.ID,
 		ClassDate: classDate,
 	}
-	booking, err := repo.BookClass(ctx, bookClass)
+	booking, err := repo.BookClass(ctx, bookClass, members.BookingLimits{})
 	require.NoError(t, err)
 
 	bookingFound, err := repo.GetByID(ctx, booking.ID)
@@ -158,7 +320,7 @@ func TestRepository_GetByID_NotFound(t *testing.T) {
 	assert.True(t, repo.IsNotFoundErr(err))
 }
 
-func TestRepository_DeleteMember(t *testing.T) {
+func TestRepository_CancelBooking(t *testing.T) {
 	if os.Getenv("INTEGRATION") == "" {
 		t.Skip("skipping integration tests: set INTEGRATION environment variable")
 	}
@@ -195,17 +357,72 @@ func TestRepository_DeleteMember(t *testing.T) {
 		ClassID:   classAdded.ID,
 		ClassDate: classDate,
 	}
-	booking, err := repo.BookClass(ctx, bookClass)
+	booking, err := repo.BookClass(ctx, bookClass, members.BookingLimits{})
 	require.NoError(t, err)
 
 	_, err = repo.GetByID(ctx, booking.ID)
 	require.False(t, repo.IsNotFoundErr(err))
 
-	err = repo.DeleteBooking(ctx, booking.ID)
+	_, err = repo.CancelBooking(ctx, booking.ID, false, classes.PenaltyNone, members.BookingLimits{})
 	require.NoError(t, err)
 
-	_, err = repo.GetByID(ctx, booking.ID)
-	require.True(t, repo.IsNotFoundErr(err))
+	cancelledBooking, err := repo.GetByID(ctx, booking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusCancelled, cancelledBooking.Status)
+	assert.NotNil(t, cancelledBooking.CancelledAt)
+	assert.False(t, cancelledBooking.LateCancel)
+
+	cancelledAgain, err := repo.CancelBooking(ctx, booking.ID, true, classes.PenaltyStrike, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.False(t, cancelledAgain.LateCancel)
+	assert.Equal(t, classes.PenaltyNone, cancelledAgain.CancelPenalty)
+
+	rebooking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: classDate}, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusBooked, rebooking.Status)
+
+	activeBookings, err := repo.ListBookings(ctx, bookings.Filter{Statuses: []bookings.Status{bookings.StatusBooked}}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, activeBookings, 1)
+	assert.Equal(t, rebooking.ID, activeBookings[0].ID)
+}
+
+func TestRepository_CancelBooking_LateCancelPenalty(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString(), Credits: 5})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now.Add(time.Hour * 24 * 10), Capacity: 10})
+	require.NoError(t, err)
+
+	strikeBooking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.NoError(t, err)
+	creditBooking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: memberAdded.ID, ClassID: classAdded.ID, ClassDate: now.Add(time.Hour * 24)}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	cancelled, err := repo.CancelBooking(ctx, strikeBooking.ID, true, classes.PenaltyStrike, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.True(t, cancelled.LateCancel)
+	assert.Equal(t, classes.PenaltyStrike, cancelled.CancelPenalty)
+
+	_, err = repo.CancelBooking(ctx, creditBooking.ID, true, classes.PenaltyCredit, members.BookingLimits{})
+	require.NoError(t, err)
+
+	penalizedMember, err := memberRepo.GetByID(ctx, memberAdded.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, penalizedMember.Strikes)
+	assert.Equal(t, 4, penalizedMember.Credits)
 }
 
 func TestRepository_ListBookings(t *testing.T) {
@@ -245,10 +462,59 @@ func TestRepository_ListBookings(t *testing.T) {
 		ClassID:   classAdded.ID,
 		ClassDate: classDate,
 	}
-	_, err = repo.BookClass(ctx, bookClass)
+	_, err = repo.BookClass(ctx, bookClass, members.BookingLimits{})
 	require.NoError(t, err)
 
-	allMembers, err := repo.ListBookings(ctx, 100, 0)
+	allMembers, err := repo.ListBookings(ctx, bookings.Filter{}, 100, 0)
 	require.NoError(t, err)
 	require.NotEmpty(t, allMembers)
 }
+
+func TestRepository_ListBookings_Filters(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	today := time.Now().UTC()
+	firstDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	yoga, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: firstDate, EndDate: firstDate.AddDate(0, 0, 14), Capacity: 20})
+	require.NoError(t, err)
+	pilates, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: firstDate, EndDate: firstDate.AddDate(0, 0, 14), Capacity: 20})
+	require.NoError(t, err)
+
+	alice, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	bob, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	aliceNextWeek, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: alice.ID, ClassID: yoga.ID, ClassDate: firstDate.AddDate(0, 0, 8)}, members.BookingLimits{})
+	require.NoError(t, err)
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: alice.ID, ClassID: pilates.ID, ClassDate: firstDate}, members.BookingLimits{})
+	require.NoError(t, err)
+	bobNextWeek, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: bob.ID, ClassID: yoga.ID, ClassDate: firstDate.AddDate(0, 0, 9)}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	from := firstDate.AddDate(0, 0, 7)
+	to := firstDate.AddDate(0, 0, 13)
+	found, err := repo.ListBookings(ctx, bookings.Filter{MemberID: alice.ID, From: &from, To: &to}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, aliceNextWeek.ID, found[0].ID)
+
+	found, err = repo.ListBookings(ctx, bookings.Filter{ClassID: yoga.ID, Statuses: []bookings.Status{bookings.StatusBooked}}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, aliceNextWeek.ID, found[0].ID)
+	assert.Equal(t, bobNextWeek.ID, found[1].ID)
+
+	found, err = repo.ListBookings(ctx, bookings.Filter{ClassID: pilates.ID, From: &from}, 100, 0)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/jackc/pgx/v5"
+)
+
+// addEventTxn stores an event in the outbox, so it is only published if the transaction commits.
+func (r *BookingsRepository) addEventTxn(ctx context.Context, txn pgx.Tx, eventType bookings.EventType, memberID string, payload []byte) error {
+	insertEvent := `INSERT INTO events (type, member_id, payload) VALUES ($1, $2, $3)`
+	_, err := txn.Exec(ctx, insertEvent, eventType, memberID, payload)
+	if err != nil {
+		return fmt.Errorf("failed to insert %s event: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// PublishEvents hands the oldest unpublished events, up to limit, to publish in the order they
+// were stored and marks them as published. The events are locked while being published, so
+// concurrent relays skip them. Publishing stops at the first event that fails, which is retried
+// by the next call along with the events after it.
+func (r *BookingsRepository) PublishEvents(ctx context.Context, limit int, publish func(ctx context.Context, event bookings.Event) error) (int, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT id, type, member_id, payload, created_at, published_at
+				FROM events
+			  WHERE published_at IS NULL
+			  ORDER BY created_at, id
+			  LIMIT $1
+			  FOR UPDATE SKIP LOCKED`
+	rows, err := txn.Query(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query unpublished events: %w", err)
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	var publishErr error
+	for _, event := range events {
+		if publishErr = publish(ctx, event); publishErr != nil {
+			publishErr = fmt.Errorf("failed to publish event %s: %w", event.ID, publishErr)
+			break
+		}
+
+		markPublished := `UPDATE events SET published_at = now() WHERE id = $1`
+		if _, err := txn.Exec(ctx, markPublished, event.ID); err != nil {
+			return 0, fmt.Errorf("failed to mark event as published: %w", err)
+		}
+		published++
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return published, publishErr
+}
+
+func (r *BookingsRepository) ListMemberEvents(ctx context.Context, memberID string, limit int, offset int) ([]bookings.Event, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT id, type, member_id, payload, created_at, published_at
+				FROM events
+			  WHERE member_id = $1
+			  ORDER BY created_at, id
+			  LIMIT $2 OFFSET $3;`
+	rows, err := txn.Query(ctx, query, memberID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return events, nil
+}
+
+func scanEvents(rows pgx.Rows) ([]bookings.Event, error) {
+	defer rows.Close()
+
+	events := make([]bookings.Event, 0)
+	for rows.Next() {
+		var event bookings.Event
+		err := rows.Scan(&event.ID, &event.Type, &event.MemberID, &event.Payload, &event.CreatedAt, &event.PublishedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan events row to bookings.Event: %w", err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
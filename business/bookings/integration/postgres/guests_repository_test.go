@@ -0,0 +1,90 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepoclass "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepomember "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_BookClass_GuestsTakeSpots(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	classDate := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	class, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: classDate, EndDate: classDate, Capacity: 3})
+	require.NoError(t, err)
+
+	newMember := func() members.Member {
+		member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+		return member
+	}
+	host, other, waiting := newMember(), newMember(), newMember()
+
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: other.ID, ClassID: class.ID, ClassDate: classDate, Guests: 2}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: host.ID, ClassID: class.ID, ClassDate: classDate, Guests: 1}, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrClassFull)
+
+	otherBookings, err := repo.ListBookings(ctx, bookings.Filter{MemberID: other.ID}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, otherBookings, 1)
+
+	_, err = repo.CancelBooking(ctx, otherBookings[0].ID, false, classes.PenaltyNone, members.BookingLimits{})
+	require.NoError(t, err)
+
+	hostBooking, err := repo.BookClass(ctx, bookings.Booking{
+		ID:         uuid.NewString(),
+		MemberID:   host.ID,
+		ClassID:    class.ID,
+		ClassDate:  classDate,
+		Guests:     2,
+		GuestNames: []string{"Ana"},
+	}, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, hostBooking.Guests)
+	assert.Equal(t, []string{"Ana"}, hostBooking.GuestNames)
+	assert.Equal(t, 3, hostBooking.Spots())
+
+	counts, err := repo.CountByDate(ctx, class.ID, classDate, classDate)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	assert.Equal(t, 3, counts[0].Booked)
+
+	_, err = repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: other.ID, ClassID: class.ID, ClassDate: classDate})
+	require.NoError(t, err)
+	_, err = repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: waiting.ID, ClassID: class.ID, ClassDate: classDate})
+	require.NoError(t, err)
+
+	_, err = repo.CancelBooking(ctx, hostBooking.ID, false, classes.PenaltyNone, members.BookingLimits{})
+	require.NoError(t, err)
+
+	promoted, err := repo.ListBookings(ctx, bookings.Filter{ClassID: class.ID, Statuses: []bookings.Status{bookings.StatusBooked}}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, promoted, 2)
+
+	waitlist, err := repo.ListWaitlist(ctx, class.ID, classDate)
+	require.NoError(t, err)
+	assert.Empty(t, waitlist)
+}
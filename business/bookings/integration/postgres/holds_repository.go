@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	"github.com/jackc/pgx/v5"
+)
+
+const holdColumns = `id, member_id, class_id, class_date, guests, guest_names, status, created_at, expires_at, confirmed_at, released_at, booking_id`
+
+func scanHold(row pgx.Row) (bookings.Hold, error) {
+	var hold bookings.Hold
+	err := row.Scan(&hold.ID, &hold.MemberID, &hold.ClassID, &hold.ClassDate, &hold.Guests, &hold.GuestNames, &hold.Status,
+		&hold.CreatedAt, &hold.ExpiresAt, &hold.ConfirmedAt, &hold.ReleasedAt, &hold.BookingID)
+	if err != nil {
+		return bookings.Hold{}, fmt.Errorf("failed to scan booking_holds row to bookings.Hold: %w", err)
+	}
+
+	if len(hold.GuestNames) == 0 {
+		hold.GuestNames = nil
+	}
+	return hold, nil
+}
+
+// HoldClass reserves spots of the class date for the member and their guests until ttl passes,
+// going through the same checks as booking it.
+func (r *BookingsRepository) HoldClass(ctx context.Context, hold bookings.Hold, ttl time.Duration, limits members.BookingLimits) (bookings.Hold, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return bookings.Hold{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	capacity, booked, err := r.lockClassTxn(ctx, txn, hold.ClassID, hold.ClassDate)
+	if err != nil {
+		return bookings.Hold{}, err
+	}
+
+	if err := r.checkSessionNotCancelledTxn(ctx, txn, hold.ClassID, hold.ClassDate); err != nil {
+		return bookings.Hold{}, err
+	}
+
+	if err := r.checkNotBookedTxn(ctx, txn, hold.MemberID, hold.ClassID, hold.ClassDate); err != nil {
+		return bookings.Hold{}, err
+	}
+
+	var held bool
+	checkHeld := `SELECT EXISTS (SELECT 1 FROM booking_holds WHERE member_id = $1 AND class_id = $2 AND class_date = $3 AND ` + activeHoldCondition + `)`
+	err = txn.QueryRow(ctx, checkHeld, hold.MemberID, hold.ClassID, hold.ClassDate).Scan(&held)
+	if err != nil {
+		return bookings.Hold{}, fmt.Errorf("failed to check existing holds: %w", err)
+	}
+
+	if held {
+		return bookings.Hold{}, bookings.ErrAlreadyHeld
+	}
+
+	booking := bookings.Booking{MemberID: hold.MemberID, ClassID: hold.ClassID, ClassDate: hold.ClassDate}
+	if err := r.checkBookingLimitsTxn(ctx, txn, booking, limits); err != nil {
+		return bookings.Hold{}, err
+	}
+
+	if !hold.AllowOverlap {
+		if err := r.checkScheduleConflictTxn(ctx, txn, booking); err != nil {
+			return bookings.Hold{}, err
+		}
+	}
+
+	if booked+hold.Spots() > capacity {
+		return bookings.Hold{}, bookings.ErrClassFull
+	}
+
+	insertHold := `INSERT INTO booking_holds (id, member_id, class_id, class_date, guests, guest_names, expires_at)
+				VALUES ($1, $2, $3, $4, $5, COALESCE($6::text[], '{}'), now() + $7 * interval '1 millisecond')
+				RETURNING ` + holdColumns
+	row := txn.QueryRow(ctx, insertHold, hold.ID, hold.MemberID, hold.ClassID, hold.ClassDate, hold.Guests, hold.GuestNames, ttl.Milliseconds())
+
+	storedHold, err := scanHold(row)
+	if err != nil {
+		return bookings.Hold{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return bookings.Hold{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedHold, nil
+}
+
+func (r *BookingsRepository) GetHold(ctx context.Context, holdID string) (bookings.Hold, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return bookings.Hold{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + holdColumns + ` FROM booking_holds WHERE id = $1`
+	hold, err := scanHold(txn.QueryRow(ctx, query, holdID))
+	if err != nil {
+		return bookings.Hold{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return bookings.Hold{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return hold, nil
+}
+
+// ConfirmHold books the spots the hold reserved, reusing the hold ID as the booking ID. The class
+// is locked before the hold, so the hold can't expire into a spot another booking takes while it
+// is confirmed. Confirming a confirmed hold returns its booking.
+func (r *BookingsRepository) ConfirmHold(ctx context.Context, holdID string, limits members.BookingLimits) (bookings.Booking, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	var classID string
+	var classDate time.Time
+	query := `SELECT class_id, class_date FROM booking_holds WHERE id = $1`
+	err = txn.QueryRow(ctx, query, holdID).Scan(&classID, &classDate)
+	if err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to get hold: %w", err)
+	}
+
+	if _, _, err := r.lockClassTxn(ctx, txn, classID, classDate); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	lockHold := `SELECT ` + holdColumns + ` FROM booking_holds WHERE id = $1 FOR UPDATE`
+	hold, err := scanHold(txn.QueryRow(ctx, lockHold, holdID))
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	var expired bool
+	checkExpired := `SELECT expires_at <= now() FROM booking_holds WHERE id = $1`
+	err = txn.QueryRow(ctx, checkExpired, holdID).Scan(&expired)
+	if err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to check hold expiry: %w", err)
+	}
+
+	switch {
+	case hold.Status == bookings.HoldStatusConfirmed && hold.BookingID != nil:
+		booking, err := r.getByIdTxn(ctx, txn, *hold.BookingID)
+		if err != nil {
+			return bookings.Booking{}, err
+		}
+
+		if err := txn.Commit(ctx); err != nil {
+			return bookings.Booking{}, fmt.Errorf("failed to commit transaction. :%w", err)
+		}
+		return booking, nil
+	case hold.Status == bookings.HoldStatusReleased || expired:
+		return bookings.Booking{}, bookings.ErrHoldExpired
+	}
+
+	if err := r.checkSessionNotCancelledTxn(ctx, txn, hold.ClassID, hold.ClassDate); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	if err := r.checkNotBookedTxn(ctx, txn, hold.MemberID, hold.ClassID, hold.ClassDate); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	booking := bookings.Booking{
+		ID:         hold.ID,
+		MemberID:   hold.MemberID,
+		ClassID:    hold.ClassID,
+		ClassDate:  hold.ClassDate,
+		Guests:     hold.Guests,
+		GuestNames: hold.GuestNames,
+	}
+	if err := r.checkBookingLimitsTxn(ctx, txn, booking, limits); err != nil {
+		return bookings.Booking{}, err
+	}
+
+	// The spots of the hold are handed over to the booking, so capacity isn't checked again.
+	storedBooking, err := r.insertBookingTxn(ctx, txn, booking)
+	if err != nil {
+		return bookings.Booking{}, err
+	}
+
+	confirmHold := `UPDATE booking_holds SET status = $1, confirmed_at = now(), booking_id = $2 WHERE id = $3`
+	_, err = txn.Exec(ctx, confirmHold, bookings.HoldStatusConfirmed, storedBooking.ID, hold.ID)
+	if err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to confirm hold: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return bookings.Booking{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedBooking, nil
+}
+
+// ReleaseExpiredHolds releases the holds that expired without being confirmed and promotes members
+// waiting for the class dates they held spots in, following studioLimits where their plan sets no
+// limits. Every class date is released in a transaction of its own, locking the class before its
+// holds like bookings do.
+func (r *BookingsRepository) ReleaseExpiredHolds(ctx context.Context, studioLimits members.BookingLimits) (int, error) {
+	type classDate struct {
+		classID string
+		date    time.Time
+	}
+
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT DISTINCT class_id, class_date FROM booking_holds WHERE status = $1 AND expires_at <= now()`
+	rows, err := txn.Query(ctx, query, bookings.HoldStatusHeld)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query expired holds: %w", err)
+	}
+
+	classDates := make([]classDate, 0)
+	for rows.Next() {
+		var expired classDate
+		if err := rows.Scan(&expired.classID, &expired.date); err != nil {
+			return 0, fmt.Errorf("failed to scan expired hold class date: %w", err)
+		}
+		classDates = append(classDates, expired)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	released := 0
+	for _, expired := range classDates {
+		count, err := r.releaseExpiredHolds(ctx, expired.classID, expired.date, studioLimits)
+		if err != nil {
+			return released, err
+		}
+		released += count
+	}
+
+	return released, nil
+}
+
+func (r *BookingsRepository) releaseExpiredHolds(ctx context.Context, classID string, classDate time.Time, studioLimits members.BookingLimits) (int, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	if _, _, err := r.lockClassTxn(ctx, txn, classID, classDate); err != nil {
+		return 0, err
+	}
+
+	releaseHolds := `UPDATE booking_holds SET status = $3, released_at = now()
+				WHERE class_id = $1 AND class_date = $2 AND status = $4 AND expires_at <= now()`
+	tag, err := txn.Exec(ctx, releaseHolds, classID, classDate, bookings.HoldStatusReleased, bookings.HoldStatusHeld)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release expired holds: %w", err)
+	}
+
+	if err := r.promoteFromWaitlistTxn(ctx, txn, classID, classDate, studioLimits); err != nil {
+		return 0, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
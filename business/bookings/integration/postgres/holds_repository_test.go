@@ -0,0 +1,128 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepoclass "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepomember "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_HoldClass_ConfirmHold(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	classDate := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	class, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: classDate, EndDate: classDate, Capacity: 2})
+	require.NoError(t, err)
+
+	member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	other, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	hold, err := repo.HoldClass(ctx, bookings.Hold{ID: uuid.NewString(), MemberID: member.ID, ClassID: class.ID, ClassDate: classDate, Guests: 1},
+		time.Minute, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.Equal(t, bookings.HoldStatusHeld, hold.Status)
+	assert.True(t, hold.ExpiresAt.After(hold.CreatedAt))
+
+	_, err = repo.HoldClass(ctx, bookings.Hold{ID: uuid.NewString(), MemberID: member.ID, ClassID: class.ID, ClassDate: classDate},
+		time.Minute, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrAlreadyHeld)
+
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: other.ID, ClassID: class.ID, ClassDate: classDate}, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrClassFull)
+
+	counts, err := repo.CountByDate(ctx, class.ID, classDate, classDate)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	assert.Equal(t, 2, counts[0].Booked)
+
+	booking, err := repo.ConfirmHold(ctx, hold.ID, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.Equal(t, hold.ID, booking.ID)
+	assert.Equal(t, 1, booking.Guests)
+	assert.Equal(t, bookings.StatusBooked, booking.Status)
+
+	confirmedAgain, err := repo.ConfirmHold(ctx, hold.ID, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.Equal(t, booking.ID, confirmedAgain.ID)
+
+	confirmed, err := repo.GetHold(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.HoldStatusConfirmed, confirmed.Status)
+	require.NotNil(t, confirmed.BookingID)
+	assert.Equal(t, booking.ID, *confirmed.BookingID)
+
+	counts, err = repo.CountByDate(ctx, class.ID, classDate, classDate)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	assert.Equal(t, 2, counts[0].Booked)
+}
+
+func TestRepository_ReleaseExpiredHolds(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	classDate := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	class, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: classDate, EndDate: classDate, Capacity: 1})
+	require.NoError(t, err)
+
+	member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	waiting, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	hold, err := repo.HoldClass(ctx, bookings.Hold{ID: uuid.NewString(), MemberID: member.ID, ClassID: class.ID, ClassDate: classDate},
+		time.Millisecond*200, members.BookingLimits{})
+	require.NoError(t, err)
+
+	entry, err := repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: waiting.ID, ClassID: class.ID, ClassDate: classDate})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 300)
+
+	_, err = repo.ConfirmHold(ctx, hold.ID, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrHoldExpired)
+
+	released, err := repo.ReleaseExpiredHolds(ctx, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, released, 1)
+
+	releasedHold, err := repo.GetHold(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.HoldStatusReleased, releasedHold.Status)
+	assert.NotNil(t, releasedHold.ReleasedAt)
+
+	promoted, err := repo.GetByID(ctx, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, waiting.ID, promoted.MemberID)
+	assert.Equal(t, bookings.StatusBooked, promoted.Status)
+}
@@ -0,0 +1,134 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepoclass "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepomember "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_BookClass_Limits(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	// 2030-01-07 is a Monday.
+	monday := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	yoga, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: monday, EndDate: monday.AddDate(0, 0, 13), Capacity: 10})
+	require.NoError(t, err)
+	pilates, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: monday, EndDate: monday.AddDate(0, 0, 13), Capacity: 10})
+	require.NoError(t, err)
+
+	member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	one, two, three := 1, 2, 3
+	limits := members.BookingLimits{MaxActiveBookings: &three, MaxBookingsPerDay: &one, MaxBookingsPerClassPerWeek: &two}
+
+	book := func(classID string, classDate time.Time) error {
+		_, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: member.ID, ClassID: classID, ClassDate: classDate}, limits)
+		return err
+	}
+
+	require.NoError(t, book(yoga.ID, monday))
+
+	var limitReached *bookings.LimitReachedError
+	err = book(pilates.ID, monday)
+	require.ErrorAs(t, err, &limitReached)
+	assert.Equal(t, bookings.LimitMaxBookingsPerDay, limitReached.Limit)
+	assert.Equal(t, 1, limitReached.Max)
+
+	require.NoError(t, book(yoga.ID, monday.AddDate(0, 0, 1)))
+	err = book(yoga.ID, monday.AddDate(0, 0, 2))
+	require.ErrorAs(t, err, &limitReached)
+	assert.Equal(t, bookings.LimitMaxBookingsPerClassPerWeek, limitReached.Limit)
+
+	require.NoError(t, book(yoga.ID, monday.AddDate(0, 0, 7)))
+	err = book(pilates.ID, monday.AddDate(0, 0, 8))
+	require.ErrorAs(t, err, &limitReached)
+	assert.Equal(t, bookings.LimitMaxActiveBookings, limitReached.Limit)
+	assert.True(t, errors.Is(err, bookings.ErrBookingLimitReached))
+}
+
+func TestRepository_BookClass_ConcurrentBookingsRespectLimits(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	// Every attempt books a different class, so only the member lock keeps them from racing.
+	classDate := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	attempts := 10
+	bookingsToAdd := make([]bookings.Booking, 0, attempts)
+	for i := 0; i < attempts; i++ {
+		classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: classDate, EndDate: classDate, Capacity: 5})
+		require.NoError(t, err)
+
+		bookingsToAdd = append(bookingsToAdd, bookings.Booking{
+			ID:        uuid.NewString(),
+			MemberID:  member.ID,
+			ClassID:   classAdded.ID,
+			ClassDate: classDate,
+		})
+	}
+
+	maxPerDay := 2
+	limits := members.BookingLimits{MaxBookingsPerDay: &maxPerDay}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for _, booking := range bookingsToAdd {
+		wg.Add(1)
+		go func(booking bookings.Booking) {
+			defer wg.Done()
+			_, err := repo.BookClass(ctx, booking, limits)
+			errs <- err
+		}(booking)
+	}
+	wg.Wait()
+	close(errs)
+
+	var booked, limited int
+	for err := range errs {
+		switch {
+		case err == nil:
+			booked++
+		case errors.Is(err, bookings.ErrBookingLimitReached):
+			limited++
+		default:
+			require.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, maxPerDay, booked)
+	assert.Equal(t, attempts-maxPerDay, limited)
+}
@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/jackc/pgx/v5"
+)
+
+// CancelSession cancels the class session on the date and, in the same transaction, moves its
+// booked bookings and waitlist entries to studio-cancelled, releases its holds, refunds the credits
+// its cancelled bookings used and stores a session cancelled event for every affected member.
+// Attended and no-show bookings are left as they are.
+func (r *BookingsRepository) CancelSession(ctx context.Context, classID string, classDate time.Time, reason string) (bookings.SessionCancellation, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return bookings.SessionCancellation{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	// Locking the class serializes the cancellation with bookings of the same class.
+	if _, _, err := r.lockClassTxn(ctx, txn, classID, classDate); err != nil {
+		return bookings.SessionCancellation{}, err
+	}
+
+	cancellation, err := r.CancelSessionTxn(ctx, txn, classID, classDate, reason)
+	if err != nil {
+		return bookings.SessionCancellation{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return bookings.SessionCancellation{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return cancellation, nil
+}
+
+// CancelSessionTxn cancels the class session on the date within txn, as CancelSession does. The
+// class has to be locked by txn already. Class changes cancelling sessions use it, so the
+// cancellations commit or roll back along with the change.
+func (r *BookingsRepository) CancelSessionTxn(ctx context.Context, txn pgx.Tx, classID string, classDate time.Time, reason string) (bookings.SessionCancellation, error) {
+	session, err := r.markSessionCancelledTxn(ctx, txn, classID, classDate, reason)
+	if err != nil {
+		return bookings.SessionCancellation{}, err
+	}
+
+	// Attended and no-show bookings are kept as they are, since the session already ran for them.
+	cancelBookings := `UPDATE bookings SET status = $3, cancelled_at = now(), updated_at = now(), version = version + 1
+					WHERE class_id = $1 AND class_date = $2 AND status = $4
+					RETURNING ` + bookingColumns
+	cancelled, err := r.queryBookingsTxn(ctx, txn, cancelBookings, classID, classDate, bookings.StatusStudioCancelled, bookings.StatusBooked)
+	if err != nil {
+		return bookings.SessionCancellation{}, err
+	}
+
+	// Every cancelled booking of the session gives back the credits it used, whether the studio
+	// just cancelled it or the member cancelled it before.
+	refundBookings := `UPDATE bookings SET refunded_at = now(), updated_at = now(), version = version + 1
+					WHERE class_id = $1 AND class_date = $2 AND status IN ($3, $4) AND credits_used > 0 AND refunded_at IS NULL
+					RETURNING ` + bookingColumns
+	refunded, err := r.queryBookingsTxn(ctx, txn, refundBookings, classID, classDate, bookings.StatusCancelled, bookings.StatusStudioCancelled)
+	if err != nil {
+		return bookings.SessionCancellation{}, err
+	}
+
+	for _, booking := range refunded {
+		refundCredits := `UPDATE members SET credits = credits + $2, updated_at = now(), version = version + 1 WHERE id = $1`
+		_, err = txn.Exec(ctx, refundCredits, booking.MemberID, booking.CreditsUsed)
+		if err != nil {
+			return bookings.SessionCancellation{}, fmt.Errorf("failed to refund member credits: %w", err)
+		}
+	}
+
+	releaseHolds := `UPDATE booking_holds SET status = $3, released_at = now()
+					WHERE class_id = $1 AND class_date = $2 AND status = $4`
+	_, err = txn.Exec(ctx, releaseHolds, classID, classDate, bookings.HoldStatusReleased, bookings.HoldStatusHeld)
+	if err != nil {
+		return bookings.SessionCancellation{}, fmt.Errorf("failed to release session holds: %w", err)
+	}
+
+	cancelEntries := `UPDATE waitlist_entries SET status = $3, cancelled_at = now()
+					WHERE class_id = $1 AND class_date = $2 AND status = $4
+					RETURNING id, member_id, class_id, class_date, status, joined_at, cancelled_at, 0`
+	rows, err := txn.Query(ctx, cancelEntries, classID, classDate, bookings.WaitlistStatusStudioCancelled, bookings.WaitlistStatusWaiting)
+	if err != nil {
+		return bookings.SessionCancellation{}, fmt.Errorf("failed to cancel waitlist entries: %w", err)
+	}
+
+	entries := make([]bookings.WaitlistEntry, 0)
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return bookings.SessionCancellation{}, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return bookings.SessionCancellation{}, fmt.Errorf("failed to cancel waitlist entries: %w", err)
+	}
+
+	cancellation := bookings.SessionCancellation{
+		Session:          session,
+		Bookings:         cancelled,
+		WaitlistEntries:  entries,
+		RefundedBookings: refunded,
+	}
+
+	if err := r.addSessionCancelledEventsTxn(ctx, txn, cancellation, reason); err != nil {
+		return bookings.SessionCancellation{}, err
+	}
+
+	return cancellation, nil
+}
+
+// markSessionCancelledTxn marks the class session on the date as cancelled, returning
+// bookings.ErrSessionCancelled when it already was.
+func (r *BookingsRepository) markSessionCancelledTxn(ctx context.Context, txn pgx.Tx, classID string, classDate time.Time, reason string) (classes.Session, error) {
+	if err := r.checkSessionNotCancelledTxn(ctx, txn, classID, classDate); err != nil {
+		return classes.Session{}, err
+	}
+
+	var session classes.Session
+	statement := `UPDATE class_sessions SET status = $3, cancelled_at = now(), cancel_reason = $4, updated_at = now()
+					WHERE class_id = $1 AND session_date = $2
+					RETURNING id, class_id, session_date, starts_at, ends_at, status, created_at, updated_at,
+						capacity, instructor_id, room_id, notes, cancelled_at, cancel_reason`
+	err := txn.QueryRow(ctx, statement, classID, classDate, classes.SessionStatusCancelled, reason).Scan(&session.ID, &session.ClassID,
+		&session.Date, &session.StartsAt, &session.EndsAt, &session.Status, &session.CreatedAt, &session.UpdatedAt,
+		&session.Capacity, &session.InstructorID, &session.RoomID, &session.Notes, &session.CancelledAt, &session.CancelReason)
+	if err != nil {
+		return classes.Session{}, fmt.Errorf("failed to cancel session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *BookingsRepository) queryBookingsTxn(ctx context.Context, txn pgx.Tx, query string, args ...interface{}) ([]bookings.Booking, error) {
+	rows, err := txn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bookings: %w", err)
+	}
+
+	allBookings := make([]bookings.Booking, 0)
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		allBookings = append(allBookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query bookings: %w", err)
+	}
+
+	return allBookings, nil
+}
+
+// addSessionCancelledEventsTxn stores one session cancelled event for every member with a
+// booking, a waitlist entry or a refund in the cancelled session.
+func (r *BookingsRepository) addSessionCancelledEventsTxn(ctx context.Context, txn pgx.Tx, cancellation bookings.SessionCancellation, reason string) error {
+	payloads := make(map[string]*bookings.SessionCancelledPayload)
+	memberIDs := make([]string, 0)
+	payloadOf := func(memberID string) *bookings.SessionCancelledPayload {
+		payload, ok := payloads[memberID]
+		if !ok {
+			payload = &bookings.SessionCancelledPayload{
+				ClassID:   cancellation.Session.ClassID,
+				SessionID: cancellation.Session.ID,
+				ClassDate: cancellation.Session.Date,
+				Reason:    reason,
+			}
+			payloads[memberID] = payload
+			memberIDs = append(memberIDs, memberID)
+		}
+		return payload
+	}
+
+	for _, booking := range cancellation.Bookings {
+		payload := payloadOf(booking.MemberID)
+		payload.BookingIDs = append(payload.BookingIDs, booking.ID)
+	}
+
+	for _, entry := range cancellation.WaitlistEntries {
+		payload := payloadOf(entry.MemberID)
+		payload.WaitlistEntryIDs = append(payload.WaitlistEntryIDs, entry.ID)
+	}
+
+	for _, booking := range cancellation.RefundedBookings {
+		payloadOf(booking.MemberID).RefundedCredits += booking.CreditsUsed
+	}
+
+	for _, memberID := range memberIDs {
+		payload, err := json.Marshal(payloads[memberID])
+		if err != nil {
+			return fmt.Errorf("failed to marshal session cancelled event: %w", err)
+		}
+
+		if err := r.addEventTxn(ctx, txn, bookings.EventSessionCancelled, memberID, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
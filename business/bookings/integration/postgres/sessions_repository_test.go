@@ -0,0 +1,187 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepoclass "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepomember "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_CancelSession(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 1})
+	require.NoError(t, err)
+
+	lateCanceller, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString(), Credits: 3})
+	require.NoError(t, err)
+	lateCancelled, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: lateCanceller.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.NoError(t, err)
+	penalised, err := repo.CancelBooking(ctx, lateCancelled.ID, true, classes.PenaltyCredit, members.BookingLimits{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, penalised.CreditsUsed)
+
+	booker, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString(), Credits: 1})
+	require.NoError(t, err)
+	booking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: booker.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.NoError(t, err)
+	// Simulates a booking paid with credits, which are refunded along with the penalties.
+	_, err = db.Exec(ctx, `UPDATE bookings SET credits_used = 2 WHERE id = $1`, booking.ID)
+	require.NoError(t, err)
+
+	attendee, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	attendedID := uuid.NewString()
+	_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id, status, attended_at, credits_used)
+		SELECT $1, $2, class_id, session_date, id, 'attended', now(), 1 FROM class_sessions WHERE class_id = $3 AND session_date = $4`,
+		attendedID, attendee.ID, classAdded.ID, booking.ClassDate)
+	require.NoError(t, err)
+
+	waiting, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	entry, err := repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: waiting.ID, ClassID: classAdded.ID, ClassDate: now})
+	require.NoError(t, err)
+
+	cancellation, err := repo.CancelSession(ctx, classAdded.ID, now, "instructor is sick")
+	require.NoError(t, err)
+	assert.Equal(t, classes.SessionStatusCancelled, cancellation.Session.Status)
+	require.Len(t, cancellation.Bookings, 1)
+	assert.Equal(t, booking.ID, cancellation.Bookings[0].ID)
+	assert.Equal(t, bookings.StatusStudioCancelled, cancellation.Bookings[0].Status)
+	require.Len(t, cancellation.WaitlistEntries, 1)
+	assert.Equal(t, entry.ID, cancellation.WaitlistEntries[0].ID)
+	assert.Equal(t, bookings.WaitlistStatusStudioCancelled, cancellation.WaitlistEntries[0].Status)
+	require.Len(t, cancellation.RefundedBookings, 2)
+	refundedIDs := []string{cancellation.RefundedBookings[0].ID, cancellation.RefundedBookings[1].ID}
+	assert.ElementsMatch(t, []string{lateCancelled.ID, booking.ID}, refundedIDs)
+	for _, refunded := range cancellation.RefundedBookings {
+		assert.NotNil(t, refunded.RefundedAt)
+	}
+
+	refundedMember, err := memberRepo.GetByID(ctx, lateCanceller.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, refundedMember.Credits)
+
+	refundedBooker, err := memberRepo.GetByID(ctx, booker.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, refundedBooker.Credits)
+
+	attended, err := repo.GetByID(ctx, attendedID)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.StatusAttended, attended.Status)
+	assert.Nil(t, attended.RefundedAt)
+
+	attendeeEvents, err := repo.ListMemberEvents(ctx, attendee.ID, 100, 0)
+	require.NoError(t, err)
+	assert.Empty(t, attendeeEvents)
+
+	for _, memberID := range []string{lateCanceller.ID, booker.ID, waiting.ID} {
+		events, err := repo.ListMemberEvents(ctx, memberID, 100, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, bookings.EventSessionCancelled, events[0].Type)
+
+		var payload bookings.SessionCancelledPayload
+		err = json.Unmarshal(events[0].Payload, &payload)
+		require.NoError(t, err)
+		assert.Equal(t, cancellation.Session.ID, payload.SessionID)
+		assert.Equal(t, "instructor is sick", payload.Reason)
+	}
+
+	waitlist, err := repo.ListWaitlist(ctx, classAdded.ID, now)
+	require.NoError(t, err)
+	assert.Empty(t, waitlist)
+
+	_, err = repo.CancelSession(ctx, classAdded.ID, now, "instructor is sick")
+	require.ErrorIs(t, err, bookings.ErrSessionCancelled)
+
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: waiting.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.ErrorIs(t, err, bookings.ErrSessionCancelled)
+}
+
+func TestRepository_PublishEvents(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 2})
+	require.NoError(t, err)
+
+	var memberIDs []string
+	for i := 0; i < 2; i++ {
+		member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+		_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: member.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+		require.NoError(t, err)
+		memberIDs = append(memberIDs, member.ID)
+	}
+
+	_, err = repo.CancelSession(ctx, classAdded.ID, now, "instructor is sick")
+	require.NoError(t, err)
+
+	publishErr := errors.New("notification channel is down")
+	var published []bookings.Event
+	count, err := repo.PublishEvents(ctx, 100, func(ctx context.Context, event bookings.Event) error {
+		if len(published) == 1 {
+			return publishErr
+		}
+		published = append(published, event)
+		return nil
+	})
+	require.ErrorIs(t, err, publishErr)
+	assert.Equal(t, 1, count)
+
+	count, err = repo.PublishEvents(ctx, 100, func(ctx context.Context, event bookings.Event) error {
+		published = append(published, event)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, published, 2)
+	assert.ElementsMatch(t, memberIDs, []string{published[0].MemberID, published[1].MemberID})
+
+	for _, memberID := range memberIDs {
+		events, err := repo.ListMemberEvents(ctx, memberID, 100, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.NotNil(t, events[0].PublishedAt)
+	}
+
+	count, err = repo.PublishEvents(ctx, 100, func(ctx context.Context, event bookings.Event) error {
+		t.Fatalf("event %s was published twice", event.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
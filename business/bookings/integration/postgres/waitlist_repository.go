@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *BookingsRepository) JoinWaitlist(ctx context.Context, entry bookings.WaitlistEntry) (bookings.WaitlistEntry, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return bookings.WaitlistEntry{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	capacity, booked, err := r.lockClassTxn(ctx, tx, entry.ClassID, entry.ClassDate)
+	if err != nil {
+		return bookings.WaitlistEntry{}, err
+	}
+
+	if err := r.checkSessionNotCancelledTxn(ctx, tx, entry.ClassID, entry.ClassDate); err != nil {
+		return bookings.WaitlistEntry{}, err
+	}
+
+	if err := r.checkNotBookedTxn(ctx, tx, entry.MemberID, entry.ClassID, entry.ClassDate); err != nil {
+		return bookings.WaitlistEntry{}, err
+	}
+
+	if booked < capacity {
+		return bookings.WaitlistEntry{}, bookings.ErrClassNotFull
+	}
+
+	var alreadyOnWaitlist bool
+	query := `SELECT EXISTS (SELECT 1 FROM waitlist_entries WHERE member_id = $1 AND class_id = $2 AND class_date = $3)`
+	err = tx.QueryRow(ctx, query, entry.MemberID, entry.ClassID, entry.ClassDate).Scan(&alreadyOnWaitlist)
+	if err != nil {
+		return bookings.WaitlistEntry{}, fmt.Errorf("failed to check waitlist entries: %w", err)
+	}
+
+	if alreadyOnWaitlist {
+		return bookings.WaitlistEntry{}, bookings.ErrAlreadyOnWaitlist
+	}
+
+	insertEntry := `INSERT INTO waitlist_entries (id, member_id, class_id, class_date) VALUES ($1, $2, $3, $4)`
+	_, err = tx.Exec(ctx, insertEntry, entry.ID, entry.MemberID, entry.ClassID, entry.ClassDate)
+	if err != nil {
+		return bookings.WaitlistEntry{}, fmt.Errorf("failed to insert waitlist entry: %w", err)
+	}
+
+	storedEntry, err := r.getWaitlistEntryTxn(ctx, tx, entry.ID)
+	if err != nil {
+		return bookings.WaitlistEntry{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return bookings.WaitlistEntry{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedEntry, nil
+}
+
+func (r *BookingsRepository) GetWaitlistEntry(ctx context.Context, entryID string) (bookings.WaitlistEntry, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return bookings.WaitlistEntry{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	entry, err := r.getWaitlistEntryTxn(ctx, txn, entryID)
+	if err != nil {
+		return bookings.WaitlistEntry{}, err
+	}
+
+	err = txn.Commit(ctx)
+	if err != nil {
+		return bookings.WaitlistEntry{}, fmt.Errorf("failed to get waitlist entry by ID: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *BookingsRepository) getWaitlistEntryTxn(ctx context.Context, txn pgx.Tx, entryID string) (bookings.WaitlistEntry, error) {
+	query := `SELECT w.id, w.member_id, w.class_id, w.class_date, w.status, w.joined_at, w.cancelled_at,
+				CASE WHEN w.status = 'waiting' THEN
+					(SELECT COUNT(*) FROM waitlist_entries ahead
+						WHERE ahead.class_id = w.class_id AND ahead.class_date = w.class_date AND ahead.status = 'waiting'
+						AND (ahead.joined_at, ahead.id) <= (w.joined_at, w.id))
+				ELSE 0 END AS position
+				FROM waitlist_entries w
+			  WHERE w.id = $1;`
+
+	return scanWaitlistEntry(txn.QueryRow(ctx, query, entryID))
+}
+
+func scanWaitlistEntry(row pgx.Row) (bookings.WaitlistEntry, error) {
+	var entry bookings.WaitlistEntry
+	err := row.Scan(&entry.ID, &entry.MemberID, &entry.ClassID, &entry.ClassDate, &entry.Status, &entry.JoinedAt, &entry.CancelledAt, &entry.Position)
+	if err != nil {
+		return bookings.WaitlistEntry{}, fmt.Errorf("failed to scan waitlist_entries row to bookings.WaitlistEntry: %w", err)
+	}
+	return entry, nil
+}
+
+// LeaveWaitlist deletes the waitlist entry of the class, returning pgx.ErrNoRows when the class
+// has no such entry.
+func (r *BookingsRepository) LeaveWaitlist(ctx context.Context, classID string, entryID string) error {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	statement := `DELETE FROM waitlist_entries WHERE id = $1 AND class_id = $2`
+	tag, err := txn.Exec(ctx, statement, entryID, classID)
+	if err != nil {
+		return fmt.Errorf("failed to delete waitlist entry: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return nil
+}
+
+func (r *BookingsRepository) ListWaitlist(ctx context.Context, classID string, classDate time.Time) ([]bookings.WaitlistEntry, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT id, member_id, class_id, class_date, status, joined_at, cancelled_at,
+				ROW_NUMBER() OVER (ORDER BY joined_at, id) AS position
+				FROM waitlist_entries
+			  WHERE class_id = $1 AND class_date = $2 AND status = 'waiting'
+			  ORDER BY position;`
+
+	rows, err := txn.Query(ctx, query, classID, classDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query waitlist entries: %w", err)
+	}
+
+	entries := make([]bookings.WaitlistEntry, 0)
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return entries, nil
+}
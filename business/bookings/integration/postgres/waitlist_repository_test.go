@@ -0,0 +1,221 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepoclass "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepomember "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_JoinWaitlist_ClassNotFull(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	memberAdded, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 1})
+	require.NoError(t, err)
+
+	_, err = repo.JoinWaitlist(ctx, bookings.WaitlistEntry{
+		ID:        uuid.NewString(),
+		MemberID:  memberAdded.ID,
+		ClassID:   classAdded.ID,
+		ClassDate: now,
+	})
+	require.ErrorIs(t, err, bookings.ErrClassNotFull)
+}
+
+func TestRepository_JoinWaitlist_Positions(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 1})
+	require.NoError(t, err)
+
+	booker, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: booker.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	entries := make([]bookings.WaitlistEntry, 0)
+	for i := 0; i < 3; i++ {
+		waiting, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+
+		entry, err := repo.JoinWaitlist(ctx, bookings.WaitlistEntry{
+			ID:        uuid.NewString(),
+			MemberID:  waiting.ID,
+			ClassID:   classAdded.ID,
+			ClassDate: now,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, i+1, entry.Position)
+		entries = append(entries, entry)
+	}
+
+	_, err = repo.JoinWaitlist(ctx, bookings.WaitlistEntry{
+		ID:        uuid.NewString(),
+		MemberID:  entries[0].MemberID,
+		ClassID:   classAdded.ID,
+		ClassDate: now,
+	})
+	require.ErrorIs(t, err, bookings.ErrAlreadyOnWaitlist)
+
+	err = repo.LeaveWaitlist(ctx, uuid.NewString(), entries[0].ID)
+	require.True(t, repo.IsNotFoundErr(err))
+
+	err = repo.LeaveWaitlist(ctx, classAdded.ID, entries[0].ID)
+	require.NoError(t, err)
+
+	entry, err := repo.GetWaitlistEntry(ctx, entries[2].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, entry.Position)
+
+	waitlist, err := repo.ListWaitlist(ctx, classAdded.ID, now)
+	require.NoError(t, err)
+	require.Len(t, waitlist, 2)
+	assert.Equal(t, entries[1].ID, waitlist[0].ID)
+	assert.Equal(t, 1, waitlist[0].Position)
+}
+
+func TestRepository_CancelBooking_PromotesFromWaitlist(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	now := time.Now().UTC()
+	classAdded, err := classRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 1})
+	require.NoError(t, err)
+
+	booker, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	booking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: booker.ID, ClassID: classAdded.ID, ClassDate: now}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	waiting, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	entry, err := repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: waiting.ID, ClassID: classAdded.ID, ClassDate: now})
+	require.NoError(t, err)
+
+	_, err = repo.CancelBooking(ctx, booking.ID, false, classes.PenaltyNone, members.BookingLimits{})
+	require.NoError(t, err)
+
+	promoted, err := repo.GetByID(ctx, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, waiting.ID, promoted.MemberID)
+	assert.Equal(t, classAdded.ID, promoted.ClassID)
+
+	_, err = repo.GetWaitlistEntry(ctx, entry.ID)
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_CancelBooking_PromotionSkipsLimitsAndConflicts(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	repo := pgrepo.NewBookingsRepository(logger, db)
+	memberRepo := pgrepomember.NewMembersRepository(logger, db)
+	classRepo := pgrepoclass.NewClassesRepository(logger, db)
+
+	date := time.Date(2030, time.March, 4, 0, 0, 0, 0, time.UTC)
+	dailyAt := func(startTime string, capacity int) classes.Class {
+		class, err := classRepo.Add(ctx, classes.Class{
+			ID:         uuid.NewString(),
+			Name:       uuid.NewString(),
+			StartDate:  date,
+			EndDate:    date.AddDate(0, 0, 7),
+			Capacity:   capacity,
+			Recurrence: &classes.Recurrence{Frequency: classes.FrequencyDaily, StartTime: startTime, DurationMinutes: 60},
+		})
+		require.NoError(t, err)
+		return class
+	}
+	addMember := func(planID *string) members.Member {
+		member, err := memberRepo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString(), PlanID: planID})
+		require.NoError(t, err)
+		return member
+	}
+
+	full := dailyAt("09:00", 1)
+	overlapping := dailyAt("09:30", 10)
+	other := dailyAt("12:00", 10)
+
+	booking, err := repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: addMember(nil).ID, ClassID: full.ID, ClassDate: date}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	maxActive := 5
+	plan, err := memberRepo.AddPlan(ctx, members.Plan{ID: uuid.NewString(), Name: uuid.NewString(), Limits: members.BookingLimits{MaxActiveBookings: &maxActive}})
+	require.NoError(t, err)
+
+	// Over the studio limit of one active booking.
+	overLimit := addMember(nil)
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: overLimit.ID, ClassID: other.ID, ClassDate: date.AddDate(0, 0, 1)}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	// Within the limits of their plan, but booked into an overlapping session.
+	conflicting := addMember(&plan.ID)
+	_, err = repo.BookClass(ctx, bookings.Booking{ID: uuid.NewString(), MemberID: conflicting.ID, ClassID: overlapping.ID, ClassDate: date}, members.BookingLimits{})
+	require.NoError(t, err)
+
+	entries := make([]bookings.WaitlistEntry, 0)
+	for _, member := range []members.Member{overLimit, conflicting, addMember(nil)} {
+		entry, err := repo.JoinWaitlist(ctx, bookings.WaitlistEntry{ID: uuid.NewString(), MemberID: member.ID, ClassID: full.ID, ClassDate: date})
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+
+	maxActiveStudio := 1
+	_, err = repo.CancelBooking(ctx, booking.ID, false, classes.PenaltyNone, members.BookingLimits{MaxActiveBookings: &maxActiveStudio})
+	require.NoError(t, err)
+
+	promoted, err := repo.GetByID(ctx, entries[2].ID)
+	require.NoError(t, err)
+	assert.Equal(t, entries[2].MemberID, promoted.MemberID)
+
+	waitlist, err := repo.ListWaitlist(ctx, full.ID, date)
+	require.NoError(t, err)
+	require.Len(t, waitlist, 2)
+	assert.Equal(t, entries[0].ID, waitlist[0].ID)
+	assert.Equal(t, entries[1].ID, waitlist[1].ID)
+}
@@ -3,11 +3,16 @@
 package mocks
 
 import (
+	bookings "github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	classes "github.com/daniel-oliveiravas/class-booking-service/business/classes"
+
 	context "context"
 
-	bookings "github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	members "github.com/daniel-oliveiravas/class-booking-service/business/members"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -15,23 +20,23 @@ type Repository struct {
 	mock.Mock
 }
 
-// BookClass provides a mock function with given fields: ctx, booking
-func (_m *Repository) BookClass(ctx context.Context, booking bookings.Booking) (bookings.Booking, error) {
-	ret := _m.Called(ctx, booking)
+// BookClass provides a mock function with given fields: ctx, booking, limits
+func (_m *Repository) BookClass(ctx context.Context, booking bookings.Booking, limits members.BookingLimits) (bookings.Booking, error) {
+	ret := _m.Called(ctx, booking, limits)
 
 	var r0 bookings.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bookings.Booking) (bookings.Booking, error)); ok {
-		return rf(ctx, booking)
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.Booking, members.BookingLimits) (bookings.Booking, error)); ok {
+		return rf(ctx, booking, limits)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bookings.Booking) bookings.Booking); ok {
-		r0 = rf(ctx, booking)
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.Booking, members.BookingLimits) bookings.Booking); ok {
+		r0 = rf(ctx, booking, limits)
 	} else {
 		r0 = ret.Get(0).(bookings.Booking)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bookings.Booking) error); ok {
-		r1 = rf(ctx, booking)
+	if rf, ok := ret.Get(1).(func(context.Context, bookings.Booking, members.BookingLimits) error); ok {
+		r1 = rf(ctx, booking, limits)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -39,18 +44,152 @@ func (_m *Repository) BookClass(ctx context.Context, booking bookings.Booking) (
 	return r0, r1
 }
 
-// DeleteBooking provides a mock function with given fields: ctx, bookingID
-func (_m *Repository) DeleteBooking(ctx context.Context, bookingID string) error {
+// CancelBooking provides a mock function with given fields: ctx, bookingID, late, penalty, studioLimits
+func (_m *Repository) CancelBooking(ctx context.Context, bookingID string, late bool, penalty classes.Penalty, studioLimits members.BookingLimits) (bookings.Booking, error) {
+	ret := _m.Called(ctx, bookingID, late, penalty, studioLimits)
+
+	var r0 bookings.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, classes.Penalty, members.BookingLimits) (bookings.Booking, error)); ok {
+		return rf(ctx, bookingID, late, penalty, studioLimits)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, classes.Penalty, members.BookingLimits) bookings.Booking); ok {
+		r0 = rf(ctx, bookingID, late, penalty, studioLimits)
+	} else {
+		r0 = ret.Get(0).(bookings.Booking)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, classes.Penalty, members.BookingLimits) error); ok {
+		r1 = rf(ctx, bookingID, late, penalty, studioLimits)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelSession provides a mock function with given fields: ctx, classID, classDate, reason
+func (_m *Repository) CancelSession(ctx context.Context, classID string, classDate time.Time, reason string) (bookings.SessionCancellation, error) {
+	ret := _m.Called(ctx, classID, classDate, reason)
+
+	var r0 bookings.SessionCancellation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string) (bookings.SessionCancellation, error)); ok {
+		return rf(ctx, classID, classDate, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string) bookings.SessionCancellation); ok {
+		r0 = rf(ctx, classID, classDate, reason)
+	} else {
+		r0 = ret.Get(0).(bookings.SessionCancellation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, string) error); ok {
+		r1 = rf(ctx, classID, classDate, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckIn provides a mock function with given fields: ctx, bookingID
+func (_m *Repository) CheckIn(ctx context.Context, bookingID string) (bookings.Booking, error) {
 	ret := _m.Called(ctx, bookingID)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 bookings.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bookings.Booking, error)); ok {
+		return rf(ctx, bookingID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bookings.Booking); ok {
 		r0 = rf(ctx, bookingID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bookings.Booking)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, bookingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmHold provides a mock function with given fields: ctx, holdID, limits
+func (_m *Repository) ConfirmHold(ctx context.Context, holdID string, limits members.BookingLimits) (bookings.Booking, error) {
+	ret := _m.Called(ctx, holdID, limits)
+
+	var r0 bookings.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, members.BookingLimits) (bookings.Booking, error)); ok {
+		return rf(ctx, holdID, limits)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, members.BookingLimits) bookings.Booking); ok {
+		r0 = rf(ctx, holdID, limits)
+	} else {
+		r0 = ret.Get(0).(bookings.Booking)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, members.BookingLimits) error); ok {
+		r1 = rf(ctx, holdID, limits)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByClassDate provides a mock function with given fields: ctx, classIDs, from, to
+func (_m *Repository) CountByClassDate(ctx context.Context, classIDs []string, from time.Time, to time.Time) ([]bookings.DateCounts, error) {
+	ret := _m.Called(ctx, classIDs, from, to)
+
+	var r0 []bookings.DateCounts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) ([]bookings.DateCounts, error)); ok {
+		return rf(ctx, classIDs, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) []bookings.DateCounts); ok {
+		r0 = rf(ctx, classIDs, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bookings.DateCounts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, classIDs, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByDate provides a mock function with given fields: ctx, classID, from, to
+func (_m *Repository) CountByDate(ctx context.Context, classID string, from time.Time, to time.Time) ([]bookings.DateCounts, error) {
+	ret := _m.Called(ctx, classID, from, to)
+
+	var r0 []bookings.DateCounts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]bookings.DateCounts, error)); ok {
+		return rf(ctx, classID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []bookings.DateCounts); ok {
+		r0 = rf(ctx, classID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bookings.DateCounts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, classID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetByID provides a mock function with given fields: ctx, bookingID
@@ -77,6 +216,78 @@ func (_m *Repository) GetByID(ctx context.Context, bookingID string) (bookings.B
 	return r0, r1
 }
 
+// GetHold provides a mock function with given fields: ctx, holdID
+func (_m *Repository) GetHold(ctx context.Context, holdID string) (bookings.Hold, error) {
+	ret := _m.Called(ctx, holdID)
+
+	var r0 bookings.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bookings.Hold, error)); ok {
+		return rf(ctx, holdID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bookings.Hold); ok {
+		r0 = rf(ctx, holdID)
+	} else {
+		r0 = ret.Get(0).(bookings.Hold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWaitlistEntry provides a mock function with given fields: ctx, entryID
+func (_m *Repository) GetWaitlistEntry(ctx context.Context, entryID string) (bookings.WaitlistEntry, error) {
+	ret := _m.Called(ctx, entryID)
+
+	var r0 bookings.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bookings.WaitlistEntry, error)); ok {
+		return rf(ctx, entryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bookings.WaitlistEntry); ok {
+		r0 = rf(ctx, entryID)
+	} else {
+		r0 = ret.Get(0).(bookings.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, entryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HoldClass provides a mock function with given fields: ctx, hold, ttl, limits
+func (_m *Repository) HoldClass(ctx context.Context, hold bookings.Hold, ttl time.Duration, limits members.BookingLimits) (bookings.Hold, error) {
+	ret := _m.Called(ctx, hold, ttl, limits)
+
+	var r0 bookings.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.Hold, time.Duration, members.BookingLimits) (bookings.Hold, error)); ok {
+		return rf(ctx, hold, ttl, limits)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.Hold, time.Duration, members.BookingLimits) bookings.Hold); ok {
+		r0 = rf(ctx, hold, ttl, limits)
+	} else {
+		r0 = ret.Get(0).(bookings.Hold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bookings.Hold, time.Duration, members.BookingLimits) error); ok {
+		r1 = rf(ctx, hold, ttl, limits)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsNotFoundErr provides a mock function with given fields: err
 func (_m *Repository) IsNotFoundErr(err error) bool {
 	ret := _m.Called(err)
@@ -91,25 +302,239 @@ func (_m *Repository) IsNotFoundErr(err error) bool {
 	return r0
 }
 
-// ListBookings provides a mock function with given fields: ctx, limit, offset
-func (_m *Repository) ListBookings(ctx context.Context, limit int, offset int) ([]bookings.Booking, error) {
-	ret := _m.Called(ctx, limit, offset)
+// JoinWaitlist provides a mock function with given fields: ctx, entry
+func (_m *Repository) JoinWaitlist(ctx context.Context, entry bookings.WaitlistEntry) (bookings.WaitlistEntry, error) {
+	ret := _m.Called(ctx, entry)
+
+	var r0 bookings.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.WaitlistEntry) (bookings.WaitlistEntry, error)); ok {
+		return rf(ctx, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.WaitlistEntry) bookings.WaitlistEntry); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Get(0).(bookings.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bookings.WaitlistEntry) error); ok {
+		r1 = rf(ctx, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LeaveWaitlist provides a mock function with given fields: ctx, classID, entryID
+func (_m *Repository) LeaveWaitlist(ctx context.Context, classID string, entryID string) error {
+	ret := _m.Called(ctx, classID, entryID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, classID, entryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListBookedUntil provides a mock function with given fields: ctx, classDate
+func (_m *Repository) ListBookedUntil(ctx context.Context, classDate time.Time) ([]bookings.Booking, error) {
+	ret := _m.Called(ctx, classDate)
+
+	var r0 []bookings.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]bookings.Booking, error)); ok {
+		return rf(ctx, classDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []bookings.Booking); ok {
+		r0 = rf(ctx, classDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bookings.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, classDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBookings provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *Repository) ListBookings(ctx context.Context, filter bookings.Filter, limit int, offset int) ([]bookings.Booking, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
+
+	var r0 []bookings.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.Filter, int, int) ([]bookings.Booking, error)); ok {
+		return rf(ctx, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bookings.Filter, int, int) []bookings.Booking); ok {
+		r0 = rf(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bookings.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bookings.Filter, int, int) error); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMemberEvents provides a mock function with given fields: ctx, memberID, limit, offset
+func (_m *Repository) ListMemberEvents(ctx context.Context, memberID string, limit int, offset int) ([]bookings.Event, error) {
+	ret := _m.Called(ctx, memberID, limit, offset)
+
+	var r0 []bookings.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]bookings.Event, error)); ok {
+		return rf(ctx, memberID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []bookings.Event); ok {
+		r0 = rf(ctx, memberID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bookings.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, memberID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWaitlist provides a mock function with given fields: ctx, classID, classDate
+func (_m *Repository) ListWaitlist(ctx context.Context, classID string, classDate time.Time) ([]bookings.WaitlistEntry, error) {
+	ret := _m.Called(ctx, classID, classDate)
+
+	var r0 []bookings.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]bookings.WaitlistEntry, error)); ok {
+		return rf(ctx, classID, classDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []bookings.WaitlistEntry); ok {
+		r0 = rf(ctx, classID, classDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bookings.WaitlistEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, classID, classDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkAttended provides a mock function with given fields: ctx, classID, classDate, memberIDs
+func (_m *Repository) MarkAttended(ctx context.Context, classID string, classDate time.Time, memberIDs []string) ([]bookings.Booking, error) {
+	ret := _m.Called(ctx, classID, classDate, memberIDs)
 
 	var r0 []bookings.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]bookings.Booking, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, []string) ([]bookings.Booking, error)); ok {
+		return rf(ctx, classID, classDate, memberIDs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []bookings.Booking); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, []string) []bookings.Booking); ok {
+		r0 = rf(ctx, classID, classDate, memberIDs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]bookings.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, []string) error); ok {
+		r1 = rf(ctx, classID, classDate, memberIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkNoShows provides a mock function with given fields: ctx, bookingIDs
+func (_m *Repository) MarkNoShows(ctx context.Context, bookingIDs []string) (int, error) {
+	ret := _m.Called(ctx, bookingIDs)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (int, error)); ok {
+		return rf(ctx, bookingIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) int); ok {
+		r0 = rf(ctx, bookingIDs)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, bookingIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishEvents provides a mock function with given fields: ctx, limit, publish
+func (_m *Repository) PublishEvents(ctx context.Context, limit int, publish func(context.Context, bookings.Event) error) (int, error) {
+	ret := _m.Called(ctx, limit, publish)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func(context.Context, bookings.Event) error) (int, error)); ok {
+		return rf(ctx, limit, publish)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, func(context.Context, bookings.Event) error) int); ok {
+		r0 = rf(ctx, limit, publish)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, func(context.Context, bookings.Event) error) error); ok {
+		r1 = rf(ctx, limit, publish)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseExpiredHolds provides a mock function with given fields: ctx, studioLimits
+func (_m *Repository) ReleaseExpiredHolds(ctx context.Context, studioLimits members.BookingLimits) (int, error) {
+	ret := _m.Called(ctx, studioLimits)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, members.BookingLimits) (int, error)); ok {
+		return rf(ctx, studioLimits)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, members.BookingLimits) int); ok {
+		r0 = rf(ctx, studioLimits)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, members.BookingLimits) error); ok {
+		r1 = rf(ctx, studioLimits)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,25 +1,264 @@
 package bookings
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+)
+
+type Status string
+
+const (
+	StatusBooked    Status = "booked"
+	StatusCancelled Status = "cancelled"
+	StatusAttended  Status = "attended"
+	StatusNoShow    Status = "no_show"
+	// StatusStudioCancelled marks bookings of sessions the studio cancelled.
+	StatusStudioCancelled Status = "studio_cancelled"
 )
 
+func (s Status) Valid() bool {
+	switch s {
+	case StatusBooked, StatusCancelled, StatusAttended, StatusNoShow, StatusStudioCancelled:
+		return true
+	}
+	return false
+}
+
 type Booking struct {
-	ID        string    `json:"ID,omitempty"`
-	MemberID  string    `json:"memberID,omitempty"`
-	ClassID   string    `json:"classID,omitempty"`
-	ClassDate time.Time `json:"classDate"`
-	BookedAt  time.Time `json:"bookedAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          string     `json:"ID,omitempty"`
+	MemberID    string     `json:"memberID,omitempty"`
+	ClassID     string     `json:"classID,omitempty"`
+	SessionID   string     `json:"sessionID,omitempty"`
+	ClassDate   time.Time  `json:"classDate"`
+	Status      Status     `json:"status,omitempty"`
+	BookedAt    time.Time  `json:"bookedAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+	AttendedAt  *time.Time `json:"attendedAt,omitempty"`
+	NoShowAt    *time.Time `json:"noShowAt,omitempty"`
+	// LateCancel tells whether the booking was cancelled inside the free-cancel cutoff.
+	LateCancel    bool            `json:"lateCancel,omitempty"`
+	CancelPenalty classes.Penalty `json:"cancelPenalty,omitempty"`
+	// CreditsUsed is how many credits the booking took from the member, such as the credit of a
+	// late cancel penalty.
+	CreditsUsed int `json:"creditsUsed,omitempty"`
+	// RefundedAt is when the credits the booking used were given back, because the studio
+	// cancelled the session.
+	RefundedAt *time.Time `json:"refundedAt,omitempty"`
+	// Guests is how many guests the member brings along, each one taking a spot of the session.
+	Guests     int      `json:"guests,omitempty"`
+	GuestNames []string `json:"guestNames,omitempty"`
+	// Version counts the updates of the booking.
+	Version int `json:"version,omitempty"`
+
+	// AllowOverlap makes booking skip the check of overlapping bookings of the member.
+	AllowOverlap bool `json:"-"`
+}
+
+// Spots returns how many spots of the session the booking takes, the member's and their guests'.
+func (b Booking) Spots() int {
+	return 1 + b.Guests
 }
 
 type BookClass struct {
 	MemberID  string    `json:"memberID,omitempty"`
 	ClassID   string    `json:"classID,omitempty"`
 	ClassDate time.Time `json:"classDate,omitempty"`
+	// AllowOverlap is the admin override that books the session even when it overlaps other
+	// bookings of the member. Handlers only set it for admins.
+	AllowOverlap bool `json:"-"`
+	// Guests is how many guests the member brings along. It can be left out when GuestNames
+	// names every guest.
+	Guests     int      `json:"guests,omitempty"`
+	GuestNames []string `json:"guestNames,omitempty"`
+}
+
+// Filter narrows down booking listings. Empty fields don't filter, and From and To are both
+// inclusive bounds on the class date.
+type Filter struct {
+	MemberID string
+	ClassID  string
+	From     *time.Time
+	To       *time.Time
+	Statuses []Status
 }
 
 type PageInfo struct {
 	Limit int
 	Page  int
 }
+
+type PolicySource string
+
+const (
+	PolicySourceClass  PolicySource = "class"
+	PolicySourceStudio PolicySource = "studio"
+)
+
+// Cancellation tells the caller which cancellation policy applied to a cancelled booking and
+// whether the member was penalized for cancelling late.
+type Cancellation struct {
+	Booking         Booking                    `json:"booking"`
+	PolicySource    PolicySource               `json:"policySource"`
+	Policy          classes.CancellationPolicy `json:"policy"`
+	FreeCancelUntil time.Time                  `json:"freeCancelUntil"`
+	Late            bool                       `json:"late"`
+	Penalty         classes.Penalty            `json:"penalty"`
+}
+
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting         WaitlistStatus = "waiting"
+	WaitlistStatusStudioCancelled WaitlistStatus = "studio_cancelled"
+)
+
+type WaitlistEntry struct {
+	ID          string         `json:"ID,omitempty"`
+	MemberID    string         `json:"memberID,omitempty"`
+	ClassID     string         `json:"classID,omitempty"`
+	ClassDate   time.Time      `json:"classDate"`
+	Position    int            `json:"position,omitempty"`
+	Status      WaitlistStatus `json:"status,omitempty"`
+	JoinedAt    time.Time      `json:"joinedAt"`
+	CancelledAt *time.Time     `json:"cancelledAt,omitempty"`
+}
+
+type JoinWaitlist struct {
+	MemberID  string    `json:"memberID,omitempty"`
+	ClassDate time.Time `json:"classDate,omitempty"`
+}
+
+type HoldStatus string
+
+const (
+	HoldStatusHeld      HoldStatus = "held"
+	HoldStatusConfirmed HoldStatus = "confirmed"
+	// HoldStatusReleased marks holds that expired before being confirmed, or whose session the
+	// studio cancelled.
+	HoldStatusReleased HoldStatus = "released"
+)
+
+// Hold reserves spots of a class date for a member, their own and their guests', until it expires
+// or is confirmed into a booking. Confirmed holds keep the ID of the booking they became.
+type Hold struct {
+	ID          string     `json:"ID,omitempty"`
+	MemberID    string     `json:"memberID,omitempty"`
+	ClassID     string     `json:"classID,omitempty"`
+	ClassDate   time.Time  `json:"classDate"`
+	Guests      int        `json:"guests,omitempty"`
+	GuestNames  []string   `json:"guestNames,omitempty"`
+	Status      HoldStatus `json:"status,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	ConfirmedAt *time.Time `json:"confirmedAt,omitempty"`
+	ReleasedAt  *time.Time `json:"releasedAt,omitempty"`
+	BookingID   *string    `json:"bookingID,omitempty"`
+
+	// AllowOverlap makes holding skip the check of overlapping bookings of the member.
+	AllowOverlap bool `json:"-"`
+}
+
+// Spots returns how many spots of the session the hold takes, the member's and their guests'.
+func (h Hold) Spots() int {
+	return 1 + h.Guests
+}
+
+type Attendance struct {
+	MemberIDs []string `json:"memberIDs,omitempty"`
+}
+
+// Availability tells how many spots are left in a class session and whether it can be booked.
+type Availability struct {
+	Date           time.Time `json:"date"`
+	SessionID      string    `json:"sessionID,omitempty"`
+	StartsAt       time.Time `json:"startsAt"`
+	Capacity       int       `json:"capacity"`
+	Booked         int       `json:"booked"`
+	SpotsLeft      int       `json:"spotsLeft"`
+	WaitlistLength int       `json:"waitlistLength"`
+	// BookingOpen is false once the session started, when it is full or when it was cancelled.
+	BookingOpen bool `json:"bookingOpen"`
+}
+
+// CalendarFilter narrows down the occurrences on the calendar. Empty fields don't filter.
+type CalendarFilter struct {
+	// LocationID keeps the classes taught in the rooms of the location.
+	LocationID string
+	// InstructorID keeps the occurrences the instructor teaches, either as the class instructor or
+	// as the instructor of the session.
+	InstructorID string
+	// MemberID adds the booking of the member to each occurrence they booked.
+	MemberID string
+}
+
+// Occurrence is a session of a class as the calendar shows it. MemberBookingID and
+// MemberBookingStatus are only set on the occurrences the calendar member booked.
+type Occurrence struct {
+	ClassID      string                `json:"classID"`
+	ClassName    string                `json:"className"`
+	SessionID    string                `json:"sessionID,omitempty"`
+	Date         time.Time             `json:"date"`
+	StartsAt     time.Time             `json:"startsAt"`
+	EndsAt       time.Time             `json:"endsAt"`
+	Status       classes.SessionStatus `json:"status"`
+	InstructorID *string               `json:"instructorID,omitempty"`
+	RoomID       *string               `json:"roomID,omitempty"`
+	Capacity     int                   `json:"capacity"`
+	SpotsLeft    int                   `json:"spotsLeft"`
+
+	MemberBookingID     string `json:"memberBookingID,omitempty"`
+	MemberBookingStatus Status `json:"memberBookingStatus,omitempty"`
+}
+
+// DateCounts holds how many spots active bookings take, guests included, and how many members
+// wait on a class date.
+type DateCounts struct {
+	ClassID    string
+	ClassDate  time.Time
+	Booked     int
+	Waitlisted int
+}
+
+type CancelSession struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// SessionCancellation is the outcome of the studio cancelling a session: the bookings and waitlist
+// entries it cancelled and the bookings whose credits were refunded.
+type SessionCancellation struct {
+	Session          classes.Session `json:"session"`
+	Bookings         []Booking       `json:"bookings"`
+	WaitlistEntries  []WaitlistEntry `json:"waitlistEntries"`
+	RefundedBookings []Booking       `json:"refundedBookings"`
+}
+
+type EventType string
+
+const (
+	EventSessionCancelled EventType = "session_cancelled"
+)
+
+// Event is a notification for a member, stored in the events outbox.
+type Event struct {
+	ID          string          `json:"ID,omitempty"`
+	Type        EventType       `json:"type,omitempty"`
+	MemberID    string          `json:"memberID,omitempty"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	PublishedAt *time.Time      `json:"publishedAt,omitempty"`
+}
+
+// SessionCancelledPayload is the payload of EventSessionCancelled events, telling the member what
+// the cancellation of a session changed for them.
+type SessionCancelledPayload struct {
+	ClassID          string    `json:"classID"`
+	SessionID        string    `json:"sessionID"`
+	ClassDate        time.Time `json:"classDate"`
+	Reason           string    `json:"reason"`
+	BookingIDs       []string  `json:"bookingIDs,omitempty"`
+	WaitlistEntryIDs []string  `json:"waitlistEntryIDs,omitempty"`
+	RefundedCredits  int       `json:"refundedCredits,omitempty"`
+}
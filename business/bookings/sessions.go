@@ -0,0 +1,90 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+)
+
+// CancelSession cancels a single session of a class on behalf of the studio, be it one of the class
+// schedule or one a schedule change dropped while members still had bookings on it. Every booked
+// booking and waitlist entry of the session is moved to studio-cancelled, the credits its cancelled
+// bookings used are refunded and each affected member gets a session cancelled event, all in one
+// transaction.
+func (u *Usecase) CancelSession(ctx context.Context, classID string, classDate time.Time, cancelSession CancelSession) (SessionCancellation, error) {
+	reason := strings.TrimSpace(cancelSession.Reason)
+	if reason == "" {
+		return SessionCancellation{}, ErrMissingCancelReason
+	}
+
+	class, err := u.classesUsecase.GetByID(ctx, classID)
+	if err != nil {
+		if errors.Is(err, classes.ErrNotFound) {
+			return SessionCancellation{}, ErrClassNotFound
+		}
+		return SessionCancellation{}, err
+	}
+
+	if class.OccursOn(classDate) {
+		if _, err := u.classesUsecase.EnsureSession(ctx, class, classDate); err != nil {
+			return SessionCancellation{}, fmt.Errorf("failed to get class session: %w", err)
+		}
+	} else if _, err := u.classesUsecase.GetStoredSession(ctx, classID, classDate); err != nil {
+		// Sessions a schedule change dropped are kept while members still have bookings on them.
+		if errors.Is(err, classes.ErrSessionNotFound) {
+			return SessionCancellation{}, ErrSessionNotFound
+		}
+		return SessionCancellation{}, fmt.Errorf("failed to get class session: %w", err)
+	}
+
+	cancellation, err := u.repository.CancelSession(ctx, classID, classDate, reason)
+	if err != nil {
+		if errors.Is(err, ErrSessionCancelled) {
+			return SessionCancellation{}, err
+		}
+		return SessionCancellation{}, fmt.Errorf("failed to cancel session in repository: %w", err)
+	}
+
+	return cancellation, nil
+}
+
+// eventsBatchSize is how many outbox events PublishEvents publishes at once.
+const eventsBatchSize = 100
+
+// EventPublisher delivers member events, such as session cancellations, to the members.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// PublishEvents relays the events stored in the outbox to the publisher, oldest first, returning
+// how many it published. Events that fail to publish stay in the outbox for the next call.
+func (u *Usecase) PublishEvents(ctx context.Context, publisher EventPublisher) (int, error) {
+	published, err := u.repository.PublishEvents(ctx, eventsBatchSize, publisher.Publish)
+	if err != nil {
+		return published, fmt.Errorf("failed to publish events from repository: %w", err)
+	}
+
+	return published, nil
+}
+
+func (u *Usecase) ListMemberEvents(ctx context.Context, memberID string, pageInfo PageInfo) ([]Event, error) {
+	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
+		pageInfo.Limit = 100
+	}
+
+	_, err := u.membersUsecase.GetByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, members.ErrNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	offset := pageInfo.Limit * pageInfo.Page
+	return u.repository.ListMemberEvents(ctx, memberID, pageInfo.Limit, offset)
+}
@@ -0,0 +1,159 @@
+package bookings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	classesmocks "github.com/daniel-oliveiravas/class-booking-service/business/classes/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	membersmocks "github.com/daniel-oliveiravas/class-booking-service/business/members/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_CancelSession(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	classDate := time.Now().UTC()
+	class := classes.Class{ID: uuid.NewString(), StartDate: classDate, EndDate: classDate.Add(time.Hour * 24 * 10)}
+
+	expected := bookings.SessionCancellation{
+		Session:  classes.Session{ClassID: class.ID, Status: classes.SessionStatusCancelled},
+		Bookings: []bookings.Booking{NewBooking()},
+	}
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+	repo.On("CancelSession", mock.Anything, class.ID, classDate, "instructor is sick").Return(expected, nil).Once()
+
+	cancellation, err := usecase.CancelSession(ctx, class.ID, classDate, bookings.CancelSession{Reason: " instructor is sick "})
+	require.NoError(t, err)
+	assert.Equal(t, expected, cancellation)
+}
+
+func TestUsecase_CancelSession_MissingReason(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	_, err := usecase.CancelSession(ctx, uuid.NewString(), time.Now(), bookings.CancelSession{Reason: "  "})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrMissingCancelReason))
+}
+
+func TestUsecase_CancelSession_NotAnOccurrence(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	classDate := time.Now().UTC()
+	class := classes.Class{ID: uuid.NewString(), StartDate: classDate, EndDate: classDate.Add(time.Hour * 24 * 10)}
+	notOccurring := time.Date(classDate.Year(), classDate.Month(), classDate.Day()+30, 0, 0, 0, 0, time.UTC)
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+	classesRepo.On("ListSessions", mock.Anything, class.ID, notOccurring, notOccurring).Return([]classes.Session{}, nil).Once()
+
+	_, err := usecase.CancelSession(ctx, class.ID, notOccurring, bookings.CancelSession{Reason: "holiday"})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrSessionNotFound))
+}
+
+func TestUsecase_CancelSession_DroppedFromSchedule(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	today := time.Now().UTC()
+	classDate := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	class := classes.Class{ID: uuid.NewString(), StartDate: classDate.AddDate(0, 0, 1), EndDate: classDate.AddDate(0, 0, 10)}
+	dropped := classes.Session{ID: uuid.NewString(), ClassID: class.ID, Date: classDate, Status: classes.SessionStatusUnscheduled}
+
+	expected := bookings.SessionCancellation{
+		Session:  classes.Session{ID: dropped.ID, ClassID: class.ID, Status: classes.SessionStatusCancelled},
+		Bookings: []bookings.Booking{NewBooking()},
+	}
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+	classesRepo.On("ListSessions", mock.Anything, class.ID, classDate, classDate).Return([]classes.Session{dropped}, nil).Once()
+	repo.On("CancelSession", mock.Anything, class.ID, classDate, "holiday").Return(expected, nil).Once()
+
+	cancellation, err := usecase.CancelSession(ctx, class.ID, classDate, bookings.CancelSession{Reason: "holiday"})
+	require.NoError(t, err)
+	assert.Equal(t, expected, cancellation)
+}
+
+func TestUsecase_BookClass_SessionCancelled(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	classDate := time.Now().UTC()
+	bookClass := bookings.BookClass{MemberID: uuid.NewString(), ClassID: uuid.NewString(), ClassDate: classDate}
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{ID: bookClass.ClassID, StartDate: classDate, EndDate: classDate}, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+	repo.On("BookClass", mock.Anything, mock.Anything, mock.Anything).Return(bookings.Booking{}, bookings.ErrSessionCancelled).Once()
+
+	_, err := usecase.BookClass(ctx, bookClass)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrSessionCancelled))
+}
+
+type publisherFunc func(ctx context.Context, event bookings.Event) error
+
+func (f publisherFunc) Publish(ctx context.Context, event bookings.Event) error {
+	return f(ctx, event)
+}
+
+func TestUsecase_PublishEvents(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	event := bookings.Event{ID: uuid.NewString(), Type: bookings.EventSessionCancelled, MemberID: uuid.NewString()}
+	var published []bookings.Event
+	publisher := publisherFunc(func(ctx context.Context, event bookings.Event) error {
+		published = append(published, event)
+		return nil
+	})
+	repo.On("PublishEvents", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, limit int, publish func(context.Context, bookings.Event) error) (int, error) {
+			return 1, publish(ctx, event)
+		}).Once()
+
+	count, err := usecase.PublishEvents(ctx, publisher)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []bookings.Event{event}, published)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
 	"github.com/daniel-oliveiravas/class-booking-service/business/members"
@@ -11,52 +13,214 @@ import (
 )
 
 var (
-	ErrInvalidClassDate = errors.New("invalid class date")
-	ErrNotFound         = errors.New("booking not found")
-	ErrMemberNotFound   = errors.New("member not found")
-	ErrClassNotFound    = errors.New("class not found")
+	ErrInvalidClassDate        = errors.New("invalid class date")
+	ErrNotFound                = errors.New("booking not found")
+	ErrMemberNotFound          = errors.New("member not found")
+	ErrClassNotFound           = errors.New("class not found")
+	ErrClassFull               = errors.New("class is full")
+	ErrClassNotFull            = errors.New("class still has available spots")
+	ErrAlreadyOnWaitlist       = errors.New("member is already on the waitlist")
+	ErrWaitlistEntryNotFound   = errors.New("waitlist entry not found")
+	ErrAlreadyBooked           = errors.New("member already booked this class date")
+	ErrInvalidStatusTransition = errors.New("invalid booking status transition")
+	ErrCheckInClosed           = errors.New("check-in is not open for this session")
+	ErrMemberNotBooked         = errors.New("member has no booking for this session")
+	ErrSessionNotFound         = errors.New("class session not found")
+	ErrSessionCancelled        = errors.New("class session was cancelled")
+	ErrMissingCancelReason     = errors.New("cancel reason is required")
+	ErrBookingLimitReached     = errors.New("member reached a booking limit")
+	ErrScheduleConflict        = errors.New("booking overlaps another booking of the member")
+	ErrInvalidGuests           = errors.New("invalid booking guests")
+	ErrHoldNotFound            = errors.New("booking hold not found")
+	ErrHoldExpired             = errors.New("booking hold expired")
+	ErrAlreadyHeld             = errors.New("member already holds spots in this class date")
+	ErrClassArchived           = errors.New("class was archived")
+	ErrInvalidDateRange        = errors.New("invalid date range")
 )
 
+// AlreadyBookedError matches ErrAlreadyBooked and carries the ID of the booking the member already holds.
+type AlreadyBookedError struct {
+	BookingID string
+}
+
+func (e *AlreadyBookedError) Error() string {
+	return fmt.Sprintf("%s: booking %s", ErrAlreadyBooked, e.BookingID)
+}
+
+func (e *AlreadyBookedError) Is(target error) bool {
+	return target == ErrAlreadyBooked
+}
+
+// Limit names one of the members.BookingLimits.
+type Limit string
+
+const (
+	LimitMaxActiveBookings          Limit = "maxActiveBookings"
+	LimitMaxBookingsPerDay          Limit = "maxBookingsPerDay"
+	LimitMaxBookingsPerClassPerWeek Limit = "maxBookingsPerClassPerWeek"
+	LimitMaxGuestsPerBooking        Limit = "maxGuestsPerBooking"
+)
+
+// LimitReachedError matches ErrBookingLimitReached and names the limit the member hit.
+type LimitReachedError struct {
+	Limit Limit
+	Max   int
+}
+
+func (e *LimitReachedError) Error() string {
+	return fmt.Sprintf("%s: %s is %d", ErrBookingLimitReached, e.Limit, e.Max)
+}
+
+func (e *LimitReachedError) Is(target error) bool {
+	return target == ErrBookingLimitReached
+}
+
+// ScheduleConflictError matches ErrScheduleConflict and carries the booking the new one overlaps.
+type ScheduleConflictError struct {
+	Booking Booking
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("%s: booking %s", ErrScheduleConflict, e.Booking.ID)
+}
+
+func (e *ScheduleConflictError) Is(target error) bool {
+	return target == ErrScheduleConflict
+}
+
+const (
+	checkInOpensBeforeDefault = time.Minute * 30
+	checkInClosesAfterDefault = time.Minute * 15
+	holdTTLDefault            = time.Minute * 10
+)
+
+type Config struct {
+	// CheckInOpensBefore is how long before a session starts members can check in.
+	CheckInOpensBefore time.Duration
+	// CheckInClosesAfter is how long after a session ends members can still check in. Bookings
+	// without a check-in once it closes are marked as no-show.
+	CheckInClosesAfter time.Duration
+	// CancellationPolicy is the studio-wide cancellation policy, used for classes without a policy of their own.
+	CancellationPolicy classes.CancellationPolicy
+	// Limits are the studio-wide booking limits, used for the limits the member's plan doesn't set.
+	Limits members.BookingLimits
+	// HoldTTL is how long a hold keeps its spots before it has to be confirmed.
+	HoldTTL time.Duration
+}
+
 type Usecase struct {
 	repository     Repository
 	membersUsecase *members.Usecase
 	classesUsecase *classes.Usecase
+	cfg            Config
 }
 
-func NewUsecase(repository Repository, membersUsecase *members.Usecase, classesUsecase *classes.Usecase) *Usecase {
+func NewUsecase(repository Repository, membersUsecase *members.Usecase, classesUsecase *classes.Usecase, cfg Config) *Usecase {
+	if cfg.CheckInOpensBefore == 0 {
+		cfg.CheckInOpensBefore = checkInOpensBeforeDefault
+	}
+
+	if cfg.CheckInClosesAfter == 0 {
+		cfg.CheckInClosesAfter = checkInClosesAfterDefault
+	}
+
+	if cfg.HoldTTL == 0 {
+		cfg.HoldTTL = holdTTLDefault
+	}
+
+	if cfg.CancellationPolicy.LateCancelPenalty == "" {
+		cfg.CancellationPolicy.LateCancelPenalty = classes.PenaltyNone
+	}
+
 	return &Usecase{repository: repository,
 		membersUsecase: membersUsecase,
 		classesUsecase: classesUsecase,
+		cfg:            cfg,
 	}
 }
 
 //go:generate mockery --name=Repository --filename=booking_repository.go
 type Repository interface {
-	BookClass(ctx context.Context, booking Booking) (Booking, error)
+	BookClass(ctx context.Context, booking Booking, limits members.BookingLimits) (Booking, error)
 	GetByID(ctx context.Context, bookingID string) (Booking, error)
 	IsNotFoundErr(err error) bool
-	DeleteBooking(ctx context.Context, bookingID string) error
-	ListBookings(ctx context.Context, limit int, offset int) ([]Booking, error)
+	CancelBooking(ctx context.Context, bookingID string, late bool, penalty classes.Penalty, studioLimits members.BookingLimits) (Booking, error)
+	ListBookings(ctx context.Context, filter Filter, limit int, offset int) ([]Booking, error)
+	JoinWaitlist(ctx context.Context, entry WaitlistEntry) (WaitlistEntry, error)
+	GetWaitlistEntry(ctx context.Context, entryID string) (WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, classID string, entryID string) error
+	ListWaitlist(ctx context.Context, classID string, classDate time.Time) ([]WaitlistEntry, error)
+	CheckIn(ctx context.Context, bookingID string) (Booking, error)
+	MarkAttended(ctx context.Context, classID string, classDate time.Time, memberIDs []string) ([]Booking, error)
+	ListBookedUntil(ctx context.Context, classDate time.Time) ([]Booking, error)
+	MarkNoShows(ctx context.Context, bookingIDs []string) (int, error)
+	CancelSession(ctx context.Context, classID string, classDate time.Time, reason string) (SessionCancellation, error)
+	ListMemberEvents(ctx context.Context, memberID string, limit int, offset int) ([]Event, error)
+	PublishEvents(ctx context.Context, limit int, publish func(ctx context.Context, event Event) error) (int, error)
+	CountByDate(ctx context.Context, classID string, from time.Time, to time.Time) ([]DateCounts, error)
+	CountByClassDate(ctx context.Context, classIDs []string, from time.Time, to time.Time) ([]DateCounts, error)
+	HoldClass(ctx context.Context, hold Hold, ttl time.Duration, limits members.BookingLimits) (Hold, error)
+	GetHold(ctx context.Context, holdID string) (Hold, error)
+	ConfirmHold(ctx context.Context, holdID string, limits members.BookingLimits) (Booking, error)
+	ReleaseExpiredHolds(ctx context.Context, studioLimits members.BookingLimits) (int, error)
 }
 
 func (u *Usecase) BookClass(ctx context.Context, bookClass BookClass) (Booking, error) {
+	booking, limits, err := u.prepareBooking(ctx, bookClass)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	classAdded, err := u.repository.BookClass(ctx, booking, limits)
+	if err != nil {
+		if errors.Is(err, ErrClassFull) || errors.Is(err, ErrAlreadyBooked) || errors.Is(err, ErrSessionCancelled) ||
+			errors.Is(err, ErrBookingLimitReached) || errors.Is(err, ErrScheduleConflict) {
+			return Booking{}, err
+		}
+		return Booking{}, fmt.Errorf("failed to add booking to repository: %w", err)
+	}
+
+	return classAdded, nil
+}
+
+// prepareBooking validates the booking of the class, guests included, and materializes the booked
+// session, returning the booking along with the booking limits of the member.
+func (u *Usecase) prepareBooking(ctx context.Context, bookClass BookClass) (Booking, members.BookingLimits, error) {
 	booking := Booking{
 		ID:        uuid.NewString(),
 		MemberID:  bookClass.MemberID,
 		ClassID:   bookClass.ClassID,
 		ClassDate: bookClass.ClassDate,
+
+		AllowOverlap: bookClass.AllowOverlap,
 	}
 
-	if err := u.validateBooking(ctx, booking); err != nil {
-		return Booking{}, err
+	guests, guestNames, err := validateGuests(bookClass)
+	if err != nil {
+		return Booking{}, members.BookingLimits{}, err
 	}
+	booking.Guests = guests
+	booking.GuestNames = guestNames
 
-	classAdded, err := u.repository.BookClass(ctx, booking)
+	member, class, err := u.validateBooking(ctx, &booking)
 	if err != nil {
-		return Booking{}, fmt.Errorf("failed to add booking to repository: %w", err)
+		return Booking{}, members.BookingLimits{}, err
 	}
 
-	return classAdded, nil
+	limits, err := u.membersUsecase.BookingLimits(ctx, member, u.cfg.Limits)
+	if err != nil {
+		return Booking{}, members.BookingLimits{}, err
+	}
+
+	if limits.MaxGuestsPerBooking != nil && booking.Guests > *limits.MaxGuestsPerBooking {
+		return Booking{}, members.BookingLimits{}, &LimitReachedError{Limit: LimitMaxGuestsPerBooking, Max: *limits.MaxGuestsPerBooking}
+	}
+
+	if _, err := u.classesUsecase.EnsureSession(ctx, class, booking.ClassDate); err != nil {
+		return Booking{}, members.BookingLimits{}, fmt.Errorf("failed to get class session: %w", err)
+	}
+
+	return booking, limits, nil
 }
 
 func (u *Usecase) GetByID(ctx context.Context, classID string) (Booking, error) {
@@ -72,39 +236,137 @@ func (u *Usecase) GetByID(ctx context.Context, classID string) (Booking, error)
 	return class, nil
 }
 
-func (u *Usecase) DeleteBooking(ctx context.Context, bookingID string) error {
-	return u.repository.DeleteBooking(ctx, bookingID)
+// CancelBooking moves the booking to cancelled, keeping it for history, and within the same
+// transaction promotes the first member waiting for that class date into a booking. Cancelling
+// after the free-cancel cutoff of the cancellation policy in effect records a late cancel and
+// applies the policy penalty to the member.
+func (u *Usecase) CancelBooking(ctx context.Context, bookingID string) (Cancellation, error) {
+	booking, err := u.GetByID(ctx, bookingID)
+	if err != nil {
+		return Cancellation{}, err
+	}
+
+	// Bookings outlive the classes deleted after they were booked.
+	class, err := u.classesUsecase.GetByIDIncludingDeleted(ctx, booking.ClassID)
+	if err != nil {
+		return Cancellation{}, fmt.Errorf("failed to get booked class: %w", err)
+	}
+
+	policy, source := u.cancellationPolicy(class)
+	freeCancelUntil := policy.FreeCancelUntil(class.SessionStart(booking.ClassDate))
+
+	late := time.Now().After(freeCancelUntil)
+	penalty := classes.PenaltyNone
+	if late {
+		penalty = policy.LateCancelPenalty
+	}
+
+	cancelled, err := u.repository.CancelBooking(ctx, bookingID, late, penalty, u.cfg.Limits)
+	if err != nil {
+		if errors.Is(err, ErrInvalidStatusTransition) {
+			return Cancellation{}, err
+		}
+		return Cancellation{}, fmt.Errorf("failed to cancel booking in repository: %w", err)
+	}
+
+	// A booking cancelled before keeps the outcome of its first cancellation.
+	cancellation := Cancellation{
+		Booking:         cancelled,
+		PolicySource:    source,
+		Policy:          policy,
+		FreeCancelUntil: freeCancelUntil,
+		Late:            cancelled.LateCancel,
+		Penalty:         cancelled.CancelPenalty,
+	}
+	if cancellation.Penalty == "" {
+		cancellation.Penalty = classes.PenaltyNone
+	}
+
+	return cancellation, nil
+}
+
+// cancellationPolicy returns the cancellation policy of the class, falling back to the studio-wide one.
+func (u *Usecase) cancellationPolicy(class classes.Class) (classes.CancellationPolicy, PolicySource) {
+	if class.CancellationPolicy != nil {
+		return *class.CancellationPolicy, PolicySourceClass
+	}
+
+	return u.cfg.CancellationPolicy, PolicySourceStudio
 }
 
-func (u *Usecase) ListBookings(ctx context.Context, pageInfo PageInfo) ([]Booking, error) {
+func (u *Usecase) ListBookings(ctx context.Context, filter Filter, pageInfo PageInfo) ([]Booking, error) {
 	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
 		pageInfo.Limit = 100
 	}
 
 	offset := pageInfo.Limit * pageInfo.Page
-	return u.repository.ListBookings(ctx, pageInfo.Limit, offset)
+	return u.repository.ListBookings(ctx, filter, pageInfo.Limit, offset)
 }
 
-func (u *Usecase) validateBooking(ctx context.Context, booking Booking) error {
-	_, err := u.membersUsecase.GetByID(ctx, booking.MemberID)
+// validateBooking checks the member and class of the booking exist, that the class isn't archived
+// and that it runs on the booked date, returning the booking member and the booked class. The
+// booking is left with the calendar date of the class it was booked on, see classes.Class.CalendarDate.
+func (u *Usecase) validateBooking(ctx context.Context, booking *Booking) (members.Member, classes.Class, error) {
+	member, err := u.membersUsecase.GetByID(ctx, booking.MemberID)
 	if err != nil {
 		if errors.Is(err, members.ErrNotFound) {
-			return ErrMemberNotFound
+			return members.Member{}, classes.Class{}, ErrMemberNotFound
 		}
 
-		return err
+		return members.Member{}, classes.Class{}, err
 	}
 
 	class, err := u.classesUsecase.GetByID(ctx, booking.ClassID)
 	if err != nil {
 		if errors.Is(err, classes.ErrNotFound) {
-			return ErrClassNotFound
+			return members.Member{}, classes.Class{}, ErrClassNotFound
 		}
+
+		return members.Member{}, classes.Class{}, err
+	}
+
+	if class.ArchivedAt != nil {
+		return members.Member{}, classes.Class{}, ErrClassArchived
+	}
+
+	// Instants have to fall within the class, while dates only have to be one of its calendar dates,
+	// even when the class starts later that day.
+	if !classes.IsDate(booking.ClassDate) && (booking.ClassDate.Before(class.StartDate) || booking.ClassDate.After(class.EndDate)) {
+		return members.Member{}, classes.Class{}, ErrInvalidClassDate
+	}
+
+	booking.ClassDate = class.CalendarDate(booking.ClassDate)
+	if !class.OccursOn(booking.ClassDate) {
+		return members.Member{}, classes.Class{}, fmt.Errorf("class doesn't run on %s: %w", booking.ClassDate.Format("2006-01-02"), ErrInvalidClassDate)
+	}
+
+	return member, class, nil
+}
+
+// validateGuests returns how many guests the booking brings and their names. The guest count
+// defaults to the number of names, and can be higher when some guests aren't named.
+func validateGuests(bookClass BookClass) (int, []string, error) {
+	if bookClass.Guests < 0 {
+		return 0, nil, fmt.Errorf("'guests' can't be negative: %w", ErrInvalidGuests)
+	}
+
+	guestNames := make([]string, 0, len(bookClass.GuestNames))
+	for _, name := range bookClass.GuestNames {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return 0, nil, fmt.Errorf("'guestNames' can't have empty names: %w", ErrInvalidGuests)
+		}
+		guestNames = append(guestNames, name)
+	}
+
+	guests := bookClass.Guests
+	if guests == 0 {
+		guests = len(guestNames)
 	}
 
-	if booking.ClassDate.Before(class.StartDate) || booking.ClassDate.After(class.EndDate) {
-		return ErrInvalidClassDate
+	if len(guestNames) > guests {
+		return 0, nil, fmt.Errorf("'guestNames' has %d names for %d guests: %w", len(guestNames), guests, ErrInvalidGuests)
 	}
 
-	return nil
+	return guests, guestNames, nil
 }
@@ -27,7 +27,7 @@ func TestUsecase_BookClass_MemberNotFound(t *testing.T) {
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
 
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	now := time.Now()
 	bookClass := bookings.BookClass{
@@ -53,7 +53,7 @@ func TestUsecase_BookClass_ClassNotFound(t *testing.T) {
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
 
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	now := time.Now()
 	bookClass := bookings.BookClass{
@@ -81,7 +81,7 @@ func TestUsecase_BookClass_DateBeforeStartClass(t *testing.T) {
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
 
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	now := time.Now()
 	bookClass := bookings.BookClass{
@@ -106,7 +106,7 @@ func TestUsecase_BookClass_DateAfterEndClass(t *testing.T) {
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
 
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	now := time.Now()
 	bookClass := bookings.BookClass{
@@ -123,6 +123,40 @@ func TestUsecase_BookClass_DateAfterEndClass(t *testing.T) {
 	require.True(t, errors.Is(err, bookings.ErrInvalidClassDate))
 }
 
+func TestUsecase_BookClass_DateNotAnOccurrence(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	// 2023-07-11 is a Tuesday.
+	bookClass := bookings.BookClass{
+		MemberID:  uuid.NewString(),
+		ClassID:   uuid.NewString(),
+		ClassDate: time.Date(2023, 7, 11, 0, 0, 0, 0, time.UTC),
+	}
+	class := classes.Class{
+		StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC),
+		Recurrence: &classes.Recurrence{
+			Weekdays:        []classes.Weekday{classes.Monday, classes.Wednesday},
+			StartTime:       "18:00",
+			DurationMinutes: 60,
+		},
+	}
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(class, nil).Once()
+
+	_, err := usecase.BookClass(ctx, bookClass)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrInvalidClassDate))
+}
+
 func TestUsecase_BookClass_ValidDate(t *testing.T) {
 	ctx := context.Background()
 	membersRepo := membersmocks.NewRepository(t)
@@ -131,7 +165,7 @@ func TestUsecase_BookClass_ValidDate(t *testing.T) {
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
 
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	now := time.Now()
 	bookClass := bookings.BookClass{
@@ -142,18 +176,282 @@ func TestUsecase_BookClass_ValidDate(t *testing.T) {
 
 	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
 	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1)}, nil).Once()
-	repo.On("BookClass", mock.Anything, mock.Anything).Return(NewBooking(), nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+	repo.On("BookClass", mock.Anything, mock.Anything, mock.Anything).Return(NewBooking(), nil).Once()
 
 	_, err := usecase.BookClass(ctx, bookClass)
 	require.NoError(t, err)
 }
 
+func TestUsecase_BookClass_DateInClassTimezone(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// New York springs forward on 2023-03-12, and 23:30 of that day is already 2023-03-13 in UTC.
+	class := classes.Class{
+		ID:         uuid.NewString(),
+		StartDate:  time.Date(2023, 3, 1, 6, 30, 0, 0, newYork),
+		EndDate:    time.Date(2023, 3, 31, 7, 30, 0, 0, newYork),
+		Capacity:   10,
+		Timezone:   "America/New_York",
+		Recurrence: &classes.Recurrence{Frequency: classes.FrequencyDaily, StartTime: "06:30", DurationMinutes: 60},
+	}
+	bookClass := bookings.BookClass{
+		MemberID:  uuid.NewString(),
+		ClassID:   class.ID,
+		ClassDate: time.Date(2023, 3, 12, 23, 30, 0, 0, newYork).UTC(),
+	}
+	classDate := time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(class, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.MatchedBy(func(session classes.Session) bool {
+		return session.Date.Equal(classDate) && session.StartsAt.Equal(time.Date(2023, 3, 12, 10, 30, 0, 0, time.UTC))
+	})).Return(classes.Session{}, nil).Once()
+	repo.On("BookClass", mock.Anything, mock.MatchedBy(func(booking bookings.Booking) bool {
+		return booking.ClassDate.Equal(classDate)
+	}), mock.Anything).Return(NewBooking(), nil).Once()
+
+	_, err = usecase.BookClass(ctx, bookClass)
+	require.NoError(t, err)
+}
+
+func TestUsecase_BookClass_DateOnlyInNegativeOffsetTimezone(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Midnight UTC of 2023-03-12 is still 2023-03-11 in New York, and it's before the class starts
+	// on its first day, but a date without a time of day is the calendar date of the class.
+	class := classes.Class{
+		ID:        uuid.NewString(),
+		StartDate: time.Date(2023, 3, 12, 18, 0, 0, 0, newYork),
+		EndDate:   time.Date(2023, 3, 31, 19, 0, 0, 0, newYork),
+		Capacity:  10,
+		Timezone:  "America/New_York",
+	}
+	classDate := time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC)
+	bookClass := bookings.BookClass{
+		MemberID:  uuid.NewString(),
+		ClassID:   class.ID,
+		ClassDate: classDate,
+	}
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(class, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.MatchedBy(func(session classes.Session) bool {
+		return session.Date.Equal(classDate) && session.StartsAt.Equal(time.Date(2023, 3, 12, 22, 0, 0, 0, time.UTC))
+	})).Return(classes.Session{}, nil).Once()
+	repo.On("BookClass", mock.Anything, mock.MatchedBy(func(booking bookings.Booking) bool {
+		return booking.ClassDate.Equal(classDate)
+	}), mock.Anything).Return(NewBooking(), nil).Once()
+
+	_, err = usecase.BookClass(ctx, bookClass)
+	require.NoError(t, err)
+}
+
+func TestUsecase_BookClass_ClassFull(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	now := time.Now()
+	bookClass := bookings.BookClass{
+		MemberID:  uuid.NewString(),
+		ClassID:   uuid.NewString(),
+		ClassDate: now,
+	}
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1), Capacity: 1}, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+	repo.On("BookClass", mock.Anything, mock.Anything, mock.Anything).Return(bookings.Booking{}, bookings.ErrClassFull).Once()
+
+	_, err := usecase.BookClass(ctx, bookClass)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrClassFull))
+}
+
+func TestUsecase_BookClass_AlreadyBooked(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	now := time.Now()
+	bookClass := bookings.BookClass{
+		MemberID:  uuid.NewString(),
+		ClassID:   uuid.NewString(),
+		ClassDate: now,
+	}
+
+	existingBookingID := uuid.NewString()
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1), Capacity: 1}, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+	repo.On("BookClass", mock.Anything, mock.Anything, mock.Anything).Return(bookings.Booking{}, &bookings.AlreadyBookedError{BookingID: existingBookingID}).Once()
+
+	_, err := usecase.BookClass(ctx, bookClass)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrAlreadyBooked))
+
+	var alreadyBooked *bookings.AlreadyBookedError
+	require.True(t, errors.As(err, &alreadyBooked))
+	assert.Equal(t, existingBookingID, alreadyBooked.BookingID)
+}
+
+func TestUsecase_BookClass_ScheduleConflict(t *testing.T) {
+	testCases := []struct {
+		name         string
+		allowOverlap bool
+	}{
+		{name: "overlapping session"},
+		{name: "admin override", allowOverlap: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			membersRepo := membersmocks.NewRepository(t)
+			membersUsecase := members.NewUsecase(membersRepo)
+			classesRepo := classesmocks.NewRepository(t)
+			classesUsecase := classes.NewUsecase(classesRepo)
+			repo := mocks.NewRepository(t)
+
+			usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+			now := time.Now()
+			class := classes.Class{ID: uuid.NewString(), StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1)}
+			bookClass := bookings.BookClass{
+				MemberID:     uuid.NewString(),
+				ClassID:      class.ID,
+				ClassDate:    now,
+				AllowOverlap: tc.allowOverlap,
+			}
+			otherBooking := bookings.Booking{ID: uuid.NewString(), MemberID: bookClass.MemberID, Status: bookings.StatusBooked}
+
+			membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+			classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+			classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+			// The repository checks the overlap while holding the member lock, unless the booking allows it.
+			repo.On("BookClass", mock.Anything, mock.MatchedBy(func(booking bookings.Booking) bool {
+				return booking.AllowOverlap == tc.allowOverlap
+			}), mock.Anything).Return(func(ctx context.Context, booking bookings.Booking, limits members.BookingLimits) (bookings.Booking, error) {
+				if booking.AllowOverlap {
+					return booking, nil
+				}
+				return bookings.Booking{}, &bookings.ScheduleConflictError{Booking: otherBooking}
+			}).Once()
+
+			_, err := usecase.BookClass(ctx, bookClass)
+			if tc.allowOverlap {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.True(t, errors.Is(err, bookings.ErrScheduleConflict))
+
+			var scheduleConflict *bookings.ScheduleConflictError
+			require.True(t, errors.As(err, &scheduleConflict))
+			assert.Equal(t, otherBooking.ID, scheduleConflict.Booking.ID)
+		})
+	}
+}
+
+func TestUsecase_BookClass_Guests(t *testing.T) {
+	maxGuests := 2
+	testCases := []struct {
+		name               string
+		bookClass          bookings.BookClass
+		expectedGuests     int
+		expectedGuestNames []string
+		expectedErr        error
+	}{
+		{name: "guest count", bookClass: bookings.BookClass{Guests: 2}, expectedGuests: 2, expectedGuestNames: []string{}},
+		{name: "guest names", bookClass: bookings.BookClass{GuestNames: []string{" Ana ", "Bia"}}, expectedGuests: 2, expectedGuestNames: []string{"Ana", "Bia"}},
+		{name: "some guests named", bookClass: bookings.BookClass{Guests: 2, GuestNames: []string{"Ana"}}, expectedGuests: 2, expectedGuestNames: []string{"Ana"}},
+		{name: "more names than guests", bookClass: bookings.BookClass{Guests: 1, GuestNames: []string{"Ana", "Bia"}}, expectedErr: bookings.ErrInvalidGuests},
+		{name: "negative guests", bookClass: bookings.BookClass{Guests: -1}, expectedErr: bookings.ErrInvalidGuests},
+		{name: "empty guest name", bookClass: bookings.BookClass{GuestNames: []string{" "}}, expectedErr: bookings.ErrInvalidGuests},
+		{name: "over the guest limit", bookClass: bookings.BookClass{Guests: 3}, expectedErr: bookings.ErrBookingLimitReached},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			membersRepo := membersmocks.NewRepository(t)
+			membersUsecase := members.NewUsecase(membersRepo)
+			classesRepo := classesmocks.NewRepository(t)
+			classesUsecase := classes.NewUsecase(classesRepo)
+			repo := mocks.NewRepository(t)
+
+			usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{
+				Limits: members.BookingLimits{MaxGuestsPerBooking: &maxGuests},
+			})
+
+			now := time.Now()
+			bookClass := tc.bookClass
+			bookClass.MemberID = uuid.NewString()
+			bookClass.ClassID = uuid.NewString()
+			bookClass.ClassDate = now
+
+			if !errors.Is(tc.expectedErr, bookings.ErrInvalidGuests) {
+				membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+				classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1)}, nil).Once()
+			}
+			if tc.expectedErr == nil {
+				classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+				repo.On("BookClass", mock.Anything, mock.MatchedBy(func(booking bookings.Booking) bool {
+					return booking.Guests == tc.expectedGuests && assert.ObjectsAreEqual(tc.expectedGuestNames, booking.GuestNames)
+				}), mock.Anything).Return(NewBooking(), nil).Once()
+			}
+
+			_, err := usecase.BookClass(ctx, bookClass)
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.True(t, errors.Is(err, tc.expectedErr))
+		})
+	}
+}
+
 func NewBooking() bookings.Booking {
 	return bookings.Booking{
 		ID:        uuid.NewString(),
 		MemberID:  uuid.NewString(),
 		ClassID:   uuid.NewString(),
 		ClassDate: time.Time{},
+		Status:    bookings.StatusBooked,
 		BookedAt:  time.Time{},
 		UpdatedAt: time.Time{},
 	}
@@ -166,7 +464,7 @@ func TestUsecase_GetByID(t *testing.T) {
 	classesRepo := classesmocks.NewRepository(t)
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	bookingID := uuid.NewString()
 	expectedClass := NewBooking()
@@ -184,7 +482,7 @@ func TestUsecase_GetByID_NotFound(t *testing.T) {
 	classesRepo := classesmocks.NewRepository(t)
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	bookingID := uuid.NewString()
 
@@ -197,19 +495,64 @@ func TestUsecase_GetByID_NotFound(t *testing.T) {
 	require.True(t, errors.Is(err, bookings.ErrNotFound))
 }
 
-func TestUsecase_DeleteBooking(t *testing.T) {
+func TestUsecase_CancelBooking(t *testing.T) {
 	ctx := context.Background()
 	membersRepo := membersmocks.NewRepository(t)
 	membersUsecase := members.NewUsecase(membersRepo)
 	classesRepo := classesmocks.NewRepository(t)
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
-	bookingID := uuid.NewString()
-	repo.On("DeleteBooking", mock.Anything, bookingID).Return(nil).Once()
-	err := usecase.DeleteBooking(ctx, bookingID)
+	classDate := time.Now().UTC().Add(time.Hour * 24 * 2)
+	booking := NewBooking()
+	booking.ClassDate = classDate
+	class := classes.Class{ID: booking.ClassID, StartDate: classDate, EndDate: classDate.Add(time.Hour * 24 * 10)}
+
+	cancelled := booking
+	cancelled.Status = bookings.StatusCancelled
+	cancelled.CancelPenalty = classes.PenaltyNone
+	repo.On("GetByID", mock.Anything, booking.ID).Return(booking, nil).Once()
+	classesRepo.On("GetByIDIncludingDeleted", mock.Anything, booking.ClassID).Return(class, nil).Once()
+	repo.On("CancelBooking", mock.Anything, booking.ID, false, classes.PenaltyNone, members.BookingLimits{}).Return(cancelled, nil).Once()
+
+	cancellation, err := usecase.CancelBooking(ctx, booking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bookings.PolicySourceStudio, cancellation.PolicySource)
+	assert.False(t, cancellation.Late)
+	assert.Equal(t, classes.PenaltyNone, cancellation.Penalty)
+}
+
+func TestUsecase_CancelBooking_LateCancel(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	studioPolicy := classes.CancellationPolicy{FreeCancelCutoffMinutes: 60, LateCancelPenalty: classes.PenaltyStrike}
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{CancellationPolicy: studioPolicy})
+
+	classDate := time.Now().UTC().Add(time.Hour * 6)
+	booking := NewBooking()
+	booking.ClassDate = classDate
+	classPolicy := classes.CancellationPolicy{FreeCancelCutoffMinutes: 12 * 60, LateCancelPenalty: classes.PenaltyCredit}
+	class := classes.Class{ID: booking.ClassID, StartDate: classDate, EndDate: classDate.Add(time.Hour * 24 * 10), CancellationPolicy: &classPolicy}
+
+	cancelled := booking
+	cancelled.Status = bookings.StatusCancelled
+	cancelled.LateCancel = true
+	cancelled.CancelPenalty = classes.PenaltyCredit
+	repo.On("GetByID", mock.Anything, booking.ID).Return(booking, nil).Once()
+	classesRepo.On("GetByIDIncludingDeleted", mock.Anything, booking.ClassID).Return(class, nil).Once()
+	repo.On("CancelBooking", mock.Anything, booking.ID, true, classes.PenaltyCredit, members.BookingLimits{}).Return(cancelled, nil).Once()
+
+	cancellation, err := usecase.CancelBooking(ctx, booking.ID)
 	require.NoError(t, err)
+	assert.Equal(t, bookings.PolicySourceClass, cancellation.PolicySource)
+	assert.Equal(t, classPolicy, cancellation.Policy)
+	assert.True(t, cancellation.Late)
+	assert.Equal(t, classes.PenaltyCredit, cancellation.Penalty)
 }
 
 func TestUsecase_ListBookings(t *testing.T) {
@@ -219,17 +562,80 @@ func TestUsecase_ListBookings(t *testing.T) {
 	classesRepo := classesmocks.NewRepository(t)
 	classesUsecase := classes.NewUsecase(classesRepo)
 	repo := mocks.NewRepository(t)
-	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
 
 	expectedBookings := make([]bookings.Booking, 0)
 	expectedBookings = append(expectedBookings, NewBooking(), NewBooking())
-	repo.On("ListBookings", mock.Anything, 100, 0).Return(expectedBookings, nil).Once()
+	filter := bookings.Filter{Statuses: []bookings.Status{bookings.StatusBooked}}
+	repo.On("ListBookings", mock.Anything, filter, 100, 0).Return(expectedBookings, nil).Once()
 
 	pageInfo := bookings.PageInfo{
 		Limit: 200,
 		Page:  0,
 	}
-	all, err := usecase.ListBookings(ctx, pageInfo)
+	all, err := usecase.ListBookings(ctx, filter, pageInfo)
 	require.NoError(t, err)
 	require.NotEmpty(t, all)
 }
+
+func TestUsecase_BookClass_PlanLimits(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+
+	maxActive, maxPerDay := 10, 1
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{
+		Limits: members.BookingLimits{MaxActiveBookings: &maxActive},
+	})
+
+	now := time.Now()
+	plan := members.Plan{ID: uuid.NewString(), Limits: members.BookingLimits{MaxBookingsPerDay: &maxPerDay}}
+	member := members.Member{ID: uuid.NewString(), PlanID: &plan.ID}
+	bookClass := bookings.BookClass{
+		MemberID:  member.ID,
+		ClassID:   uuid.NewString(),
+		ClassDate: now,
+	}
+
+	membersRepo.On("GetByID", mock.Anything, member.ID).Return(member, nil).Once()
+	membersRepo.On("GetPlanByID", mock.Anything, plan.ID).Return(plan, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).Return(classes.Class{ID: bookClass.ClassID, StartDate: now, EndDate: now}, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, mock.Anything).Return(classes.Session{}, nil).Once()
+
+	limitReached := &bookings.LimitReachedError{Limit: bookings.LimitMaxBookingsPerDay, Max: maxPerDay}
+	expectedLimits := members.BookingLimits{MaxActiveBookings: &maxActive, MaxBookingsPerDay: &maxPerDay}
+	repo.On("BookClass", mock.Anything, mock.Anything, expectedLimits).Return(bookings.Booking{}, limitReached).Once()
+
+	_, err := usecase.BookClass(ctx, bookClass)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrBookingLimitReached))
+
+	var reached *bookings.LimitReachedError
+	require.True(t, errors.As(err, &reached))
+	assert.Equal(t, bookings.LimitMaxBookingsPerDay, reached.Limit)
+}
+
+func TestUsecase_BookClass_ClassArchived(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	classDate := time.Now().UTC()
+	archivedAt := classDate.Add(-time.Hour)
+	bookClass := bookings.BookClass{MemberID: uuid.NewString(), ClassID: uuid.NewString(), ClassDate: classDate}
+
+	membersRepo.On("GetByID", mock.Anything, bookClass.MemberID).Return(members.Member{ID: bookClass.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, bookClass.ClassID).
+		Return(classes.Class{ID: bookClass.ClassID, StartDate: classDate, EndDate: classDate, ArchivedAt: &archivedAt}, nil).Once()
+
+	_, err := usecase.BookClass(ctx, bookClass)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrClassArchived))
+}
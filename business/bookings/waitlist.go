@@ -0,0 +1,68 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (u *Usecase) JoinWaitlist(ctx context.Context, classID string, joinWaitlist JoinWaitlist) (WaitlistEntry, error) {
+	booking := Booking{MemberID: joinWaitlist.MemberID, ClassID: classID, ClassDate: joinWaitlist.ClassDate}
+	_, _, err := u.validateBooking(ctx, &booking)
+	if err != nil {
+		return WaitlistEntry{}, err
+	}
+
+	entry := WaitlistEntry{
+		ID:        uuid.NewString(),
+		MemberID:  booking.MemberID,
+		ClassID:   classID,
+		ClassDate: booking.ClassDate,
+	}
+
+	entryAdded, err := u.repository.JoinWaitlist(ctx, entry)
+	if err != nil {
+		if errors.Is(err, ErrClassNotFull) || errors.Is(err, ErrAlreadyOnWaitlist) || errors.Is(err, ErrAlreadyBooked) ||
+			errors.Is(err, ErrSessionCancelled) {
+			return WaitlistEntry{}, err
+		}
+		return WaitlistEntry{}, fmt.Errorf("failed to add waitlist entry to repository: %w", err)
+	}
+
+	return entryAdded, nil
+}
+
+func (u *Usecase) GetWaitlistEntry(ctx context.Context, entryID string) (WaitlistEntry, error) {
+	entry, err := u.repository.GetWaitlistEntry(ctx, entryID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return WaitlistEntry{}, ErrWaitlistEntryNotFound
+		}
+
+		return WaitlistEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// LeaveWaitlist takes the entry off the waitlist of the class. Entries of other classes aren't
+// found.
+func (u *Usecase) LeaveWaitlist(ctx context.Context, classID string, entryID string) error {
+	err := u.repository.LeaveWaitlist(ctx, classID, entryID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return ErrWaitlistEntryNotFound
+		}
+
+		return fmt.Errorf("failed to delete waitlist entry from repository: %w", err)
+	}
+
+	return nil
+}
+
+func (u *Usecase) ListWaitlist(ctx context.Context, classID string, classDate time.Time) ([]WaitlistEntry, error) {
+	return u.repository.ListWaitlist(ctx, classID, classDate)
+}
@@ -0,0 +1,163 @@
+package bookings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	classesmocks "github.com/daniel-oliveiravas/class-booking-service/business/classes/mocks"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	membersmocks "github.com/daniel-oliveiravas/class-booking-service/business/members/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_JoinWaitlist(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	now := time.Now()
+	classID := uuid.NewString()
+	joinWaitlist := bookings.JoinWaitlist{
+		MemberID:  uuid.NewString(),
+		ClassDate: now,
+	}
+
+	membersRepo.On("GetByID", mock.Anything, joinWaitlist.MemberID).Return(members.Member{ID: joinWaitlist.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, classID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1)}, nil).Once()
+
+	expectedEntry := NewWaitlistEntry()
+	repo.On("JoinWaitlist", mock.Anything, mock.MatchedBy(func(entry bookings.WaitlistEntry) bool {
+		return entry.ClassID == classID && entry.MemberID == joinWaitlist.MemberID && entry.ID != ""
+	})).Return(expectedEntry, nil).Once()
+
+	entry, err := usecase.JoinWaitlist(ctx, classID, joinWaitlist)
+	require.NoError(t, err)
+	assert.Equal(t, expectedEntry, entry)
+}
+
+func TestUsecase_JoinWaitlist_ClassNotFull(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	now := time.Now()
+	classID := uuid.NewString()
+	joinWaitlist := bookings.JoinWaitlist{
+		MemberID:  uuid.NewString(),
+		ClassDate: now,
+	}
+
+	membersRepo.On("GetByID", mock.Anything, joinWaitlist.MemberID).Return(members.Member{ID: joinWaitlist.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, classID).Return(classes.Class{StartDate: now.Add(time.Hour * -2), EndDate: now.Add(time.Hour * 1)}, nil).Once()
+	repo.On("JoinWaitlist", mock.Anything, mock.Anything).Return(bookings.WaitlistEntry{}, bookings.ErrClassNotFull).Once()
+
+	_, err := usecase.JoinWaitlist(ctx, classID, joinWaitlist)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrClassNotFull))
+}
+
+func TestUsecase_JoinWaitlist_InvalidClassDate(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	now := time.Now()
+	classID := uuid.NewString()
+	joinWaitlist := bookings.JoinWaitlist{
+		MemberID:  uuid.NewString(),
+		ClassDate: now,
+	}
+
+	membersRepo.On("GetByID", mock.Anything, joinWaitlist.MemberID).Return(members.Member{ID: joinWaitlist.MemberID}, nil).Once()
+	classesRepo.On("GetByID", mock.Anything, classID).Return(classes.Class{StartDate: now.Add(time.Hour)}, nil).Once()
+
+	_, err := usecase.JoinWaitlist(ctx, classID, joinWaitlist)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, bookings.ErrInvalidClassDate))
+}
+
+func TestUsecase_GetWaitlistEntry_NotFound(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	entryID := uuid.NewString()
+
+	expectedErr := pgx.ErrNoRows
+	repo.On("GetWaitlistEntry", mock.Anything, entryID).Return(bookings.WaitlistEntry{}, expectedErr).Once()
+	repo.On("IsNotFoundErr", expectedErr).Return(true).Once()
+
+	entry, err := usecase.GetWaitlistEntry(ctx, entryID)
+	require.Error(t, err)
+	require.Empty(t, entry.ID)
+	require.True(t, errors.Is(err, bookings.ErrWaitlistEntryNotFound))
+}
+
+func TestUsecase_LeaveWaitlist(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	classID := uuid.NewString()
+	entryID := uuid.NewString()
+	repo.On("LeaveWaitlist", mock.Anything, classID, entryID).Return(nil).Once()
+	err := usecase.LeaveWaitlist(ctx, classID, entryID)
+	require.NoError(t, err)
+}
+
+func TestUsecase_LeaveWaitlist_EntryNotFound(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := membersmocks.NewRepository(t)
+	membersUsecase := members.NewUsecase(membersRepo)
+	classesRepo := classesmocks.NewRepository(t)
+	classesUsecase := classes.NewUsecase(classesRepo)
+	repo := mocks.NewRepository(t)
+	usecase := bookings.NewUsecase(repo, membersUsecase, classesUsecase, bookings.Config{})
+
+	classID := uuid.NewString()
+	entryID := uuid.NewString()
+	repo.On("LeaveWaitlist", mock.Anything, classID, entryID).Return(pgx.ErrNoRows).Once()
+	repo.On("IsNotFoundErr", pgx.ErrNoRows).Return(true).Once()
+	err := usecase.LeaveWaitlist(ctx, classID, entryID)
+	require.ErrorIs(t, err, bookings.ErrWaitlistEntryNotFound)
+}
+
+func NewWaitlistEntry() bookings.WaitlistEntry {
+	return bookings.WaitlistEntry{
+		ID:        uuid.NewString(),
+		MemberID:  uuid.NewString(),
+		ClassID:   uuid.NewString(),
+		ClassDate: time.Time{},
+		Position:  1,
+		JoinedAt:  time.Time{},
+	}
+}
@@ -5,22 +5,37 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/daniel-oliveiravas/class-booking-service/business/bookings"
+	pgbookings "github.com/daniel-oliveiravas/class-booking-service/business/bookings/integration/postgres"
 	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
+const classColumns = `id, created_at, updated_at, name, start_date, end_date, capacity, cancellation_cutoff_minutes, late_cancel_penalty, recurrence, version, archived_at, instructor_id, room_id, timezone, description, category, tags, level, duration_minutes, image_url`
+
+// orphanedSessionReason is the cancel reason of the sessions class updates drop from the schedule.
+const orphanedSessionReason = "the session was removed from the class schedule"
+
+// deletedClassReason is the cancel reason of the sessions of deleted classes.
+const deletedClassReason = "the class was deleted"
+
 type ClassesRepository struct {
 	logger *zap.SugaredLogger
 	db     *pgxpool.Pool
+	// bookings cancels the sessions of the class within the transactions changing it.
+	bookings *pgbookings.BookingsRepository
 }
 
 func NewClassesRepository(logger *zap.SugaredLogger, db *pgxpool.Pool) *ClassesRepository {
 	return &ClassesRepository{
-		logger: logger,
-		db:     db,
+		logger:   logger,
+		db:       db,
+		bookings: pgbookings.NewBookingsRepository(logger, db),
 	}
 }
 
@@ -34,15 +49,53 @@ func (r *ClassesRepository) Add(ctx context.Context, class classes.Class) (class
 
 	defer tx.Rollback(ctx)
 
-	insertClass := `INSERT INTO classes (id, name, start_date, end_date, capacity) 
-				VALUES ($1, $2, $3, $4, $5)
-				RETURNING id, name, created_at, updated_at, start_date, end_date, capacity`
-	row := tx.QueryRow(ctx, insertClass, class.ID, class.Name, class.StartDate, class.EndDate, class.Capacity)
+	if class.InstructorID != nil {
+		if err := r.lockInstructorTxn(ctx, tx, *class.InstructorID); err != nil {
+			return classes.Class{}, err
+		}
+	}
 
-	var storesClass classes.Class
-	err = row.Scan(&storesClass.ID, &storesClass.Name, &storesClass.CreatedAt, &storesClass.UpdatedAt, &storesClass.StartDate, &storesClass.EndDate, &storesClass.Capacity)
+	if class.RoomID != nil {
+		roomCapacity, err := r.lockRoomTxn(ctx, tx, *class.RoomID)
+		if err != nil {
+			return classes.Class{}, err
+		}
+
+		if class.Capacity > roomCapacity {
+			return classes.Class{}, fmt.Errorf("room holds %d people: %w", roomCapacity, classes.ErrCapacityAboveRoom)
+		}
+	}
+
+	// Classes without a timezone of their own run in the timezone of the location of their room.
+	cutoffMinutes, penalty := cancellationPolicyValues(class.CancellationPolicy)
+	insertClass := `INSERT INTO classes (id, name, start_date, end_date, capacity, cancellation_cutoff_minutes, late_cancel_penalty, recurrence, instructor_id, room_id, timezone,
+						description, category, tags, level, duration_minutes, image_url) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+						COALESCE(NULLIF($11, ''), (SELECT l.timezone FROM rooms r JOIN locations l ON l.id = r.location_id WHERE r.id = $10), 'UTC'),
+						$12, $13, COALESCE($14::text[], '{}'), $15, $16, $17)
+				RETURNING ` + classColumns
+	row := tx.QueryRow(ctx, insertClass, class.ID, class.Name, class.StartDate, class.EndDate, class.Capacity, cutoffMinutes, penalty, class.Recurrence,
+		class.InstructorID, class.RoomID, class.Timezone, class.Description, class.Category, class.Tags, class.Level, class.DurationMinutes, class.ImageURL)
+
+	storesClass, err := scanClass(row)
 	if err != nil {
-		return classes.Class{}, fmt.Errorf("failed to scan classes row to classes.Class: %w", err)
+		return classes.Class{}, err
+	}
+
+	if err := r.syncSessionsTxn(ctx, tx, storesClass); err != nil {
+		return classes.Class{}, err
+	}
+
+	if storesClass.InstructorID != nil {
+		if err := r.checkInstructorConflictsTxn(ctx, tx, *storesClass.InstructorID, storesClass.ID, nil); err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	if storesClass.RoomID != nil {
+		if err := r.checkRoomConflictsTxn(ctx, tx, *storesClass.RoomID, storesClass.ID, nil); err != nil {
+			return classes.Class{}, err
+		}
 	}
 
 	err = tx.Commit(ctx)
@@ -73,22 +126,82 @@ func (r *ClassesRepository) GetByID(ctx context.Context, classID string) (classe
 	return class, nil
 }
 
+// GetByIDIncludingDeleted gets the class even when it was deleted.
+func (r *ClassesRepository) GetByIDIncludingDeleted(ctx context.Context, classID string) (classes.Class, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return classes.Class{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + classColumns + ` FROM classes WHERE id = $1;`
+	class, err := scanClass(txn.QueryRow(ctx, query, classID))
+	if err != nil {
+		return classes.Class{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return classes.Class{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return class, nil
+}
+
 func (r *ClassesRepository) getByIdTxn(ctx context.Context, txn pgx.Tx, classID string) (classes.Class, error) {
-	query := `SELECT id, created_at, updated_at, name, start_date, end_date, capacity FROM classes WHERE id = $1;`
+	query := `SELECT ` + classColumns + ` FROM classes WHERE id = $1 AND deleted_at IS NULL;`
 
 	row := txn.QueryRow(ctx, query, classID)
+	return scanClass(row)
+}
+
+func scanClass(row pgx.Row) (classes.Class, error) {
 	var class classes.Class
-	err := row.Scan(&class.ID, &class.CreatedAt, &class.UpdatedAt, &class.Name, &class.StartDate, &class.EndDate, &class.Capacity)
+	var cutoffMinutes *int
+	var penalty *classes.Penalty
+	err := row.Scan(&class.ID, &class.CreatedAt, &class.UpdatedAt, &class.Name, &class.StartDate, &class.EndDate, &class.Capacity,
+		&cutoffMinutes, &penalty, &class.Recurrence, &class.Version, &class.ArchivedAt, &class.InstructorID, &class.RoomID, &class.Timezone,
+		&class.Description, &class.Category, &class.Tags, &class.Level, &class.DurationMinutes, &class.ImageURL)
 	if err != nil {
 		return classes.Class{}, fmt.Errorf("failed to scan classes row to classes.Class: %w", err)
 	}
+
+	if _, err := time.LoadLocation(class.Timezone); err != nil {
+		return classes.Class{}, fmt.Errorf("class %s has unknown timezone %q: %w", class.ID, class.Timezone, err)
+	}
+
+	if cutoffMinutes != nil && penalty != nil {
+		class.CancellationPolicy = &classes.CancellationPolicy{
+			FreeCancelCutoffMinutes: *cutoffMinutes,
+			LateCancelPenalty:       *penalty,
+		}
+	}
 	return class, nil
 }
 
+// cancellationPolicyValues returns the column values of a class cancellation policy, which are
+// both NULL when the class follows the studio-wide policy.
+func cancellationPolicyValues(policy *classes.CancellationPolicy) (*int, *classes.Penalty) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	return &policy.FreeCancelCutoffMinutes, &policy.LateCancelPenalty
+}
+
 func (r *ClassesRepository) IsNotFoundErr(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows)
 }
 
+// Update updates the class and regenerates its sessions. It fails with
+// classes.ErrCapacityBelowBookings when the new capacity is below the spots taken on an upcoming
+// date without a session capacity override and, unless the update is forced, with
+// classes.ErrBookingsOutsideSchedule when upcoming bookings fall outside the new schedule. It fails
+// with classes.ErrInstructorConflict when the class instructor would teach overlapping sessions,
+// with classes.ErrRoomConflict when overlapping sessions would share the class room and with
+// classes.ErrCapacityAboveRoom when sessions would take more people than their room holds.
 func (r *ClassesRepository) Update(ctx context.Context, classID string, updateClass classes.UpdateClass) (classes.Class, error) {
 	values := make([]interface{}, 0)
 	columns := make([]string, 0)
@@ -113,8 +226,89 @@ func (r *ClassesRepository) Update(ctx context.Context, classID string, updateCl
 		columns = append(columns, "capacity")
 	}
 
+	if updateClass.Description != nil {
+		values = append(values, *updateClass.Description)
+		columns = append(columns, "description")
+	}
+
+	if updateClass.Category != nil {
+		values = append(values, *updateClass.Category)
+		columns = append(columns, "category")
+	}
+
+	if updateClass.Tags != nil {
+		tags := *updateClass.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		values = append(values, tags)
+		columns = append(columns, "tags")
+	}
+
+	if updateClass.Level != nil {
+		values = append(values, *updateClass.Level)
+		columns = append(columns, "level")
+	}
+
+	if updateClass.DurationMinutes != nil {
+		values = append(values, *updateClass.DurationMinutes)
+		columns = append(columns, "duration_minutes")
+	}
+
+	if updateClass.ImageURL != nil {
+		values = append(values, *updateClass.ImageURL)
+		columns = append(columns, "image_url")
+	}
+
+	if updateClass.CancellationPolicy != nil {
+		policy := updateClass.CancellationPolicy
+		if policy.IsEmpty() {
+			policy = nil
+		}
+		cutoffMinutes, penalty := cancellationPolicyValues(policy)
+		values = append(values, cutoffMinutes, penalty)
+		columns = append(columns, "cancellation_cutoff_minutes", "late_cancel_penalty")
+	}
+
+	if updateClass.Recurrence != nil {
+		values = append(values, updateClass.Recurrence)
+		columns = append(columns, "recurrence")
+	}
+
+	if updateClass.InstructorID != nil {
+		var instructorID *string
+		if *updateClass.InstructorID != "" {
+			instructorID = updateClass.InstructorID
+		}
+		values = append(values, instructorID)
+		columns = append(columns, "instructor_id")
+	}
+
+	if updateClass.RoomID != nil {
+		var roomID *string
+		if *updateClass.RoomID != "" {
+			roomID = updateClass.RoomID
+		}
+		values = append(values, roomID)
+		columns = append(columns, "room_id")
+	}
+
+	if updateClass.Timezone != nil {
+		values = append(values, *updateClass.Timezone)
+		columns = append(columns, "timezone")
+	}
+
 	if len(values) == 0 {
-		return classes.Class{}, nil
+		class, err := r.GetByID(ctx, classID)
+		if err != nil {
+			return classes.Class{}, err
+		}
+
+		if !updateClass.MatchesVersion(class.Version) {
+			return classes.Class{}, fmt.Errorf("class is at version %d: %w", class.Version, classes.ErrVersionMismatch)
+		}
+
+		return class, nil
 	}
 
 	updateStatements := make([]string, 0)
@@ -123,8 +317,8 @@ func (r *ClassesRepository) Update(ctx context.Context, classID string, updateCl
 	}
 
 	values = append(values, classID)
-	var statement = "UPDATE classes SET " + strings.Join(updateStatements, ", ") + fmt.Sprintf(" WHERE id = $%d", len(values)) +
-		" RETURNING id, created_at, updated_at, name, start_date, end_date, capacity"
+	var statement = "UPDATE classes SET " + strings.Join(updateStatements, ", ") + fmt.Sprintf(", updated_at = now(), version = version + 1 WHERE id = $%d", len(values)) +
+		" RETURNING " + classColumns
 
 	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadWrite,
@@ -135,11 +329,98 @@ func (r *ClassesRepository) Update(ctx context.Context, classID string, updateCl
 
 	defer txn.Rollback(ctx)
 
-	row := r.db.QueryRow(ctx, statement, values...)
-	var class classes.Class
-	err = row.Scan(&class.ID, &class.CreatedAt, &class.UpdatedAt, &class.Name, &class.StartDate, &class.EndDate, &class.Capacity)
+	// Locking the class serializes the update with bookings of the same class.
+	var version int
+	var roomID *string
+	lockClass := `SELECT version, room_id FROM classes WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	err = txn.QueryRow(ctx, lockClass, classID).Scan(&version, &roomID)
 	if err != nil {
-		return classes.Class{}, fmt.Errorf("failed to scan classes row to classes.Class: %w", err)
+		return classes.Class{}, fmt.Errorf("failed to lock class: %w", err)
+	}
+
+	if !updateClass.MatchesVersion(version) {
+		return classes.Class{}, fmt.Errorf("class is at version %d: %w", version, classes.ErrVersionMismatch)
+	}
+
+	if updateClass.InstructorID != nil && *updateClass.InstructorID != "" {
+		if err := r.lockInstructorTxn(ctx, txn, *updateClass.InstructorID); err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	if updateClass.RoomID != nil {
+		roomID = nil
+		if *updateClass.RoomID != "" {
+			roomID = updateClass.RoomID
+		}
+	}
+
+	// The room is locked whenever its sessions or the people they take might change, so that the
+	// room can't shrink meanwhile.
+	roomCapacity := 0
+	if roomID != nil && (updateClass.RoomID != nil || updateClass.Capability != nil || updateClass.ChangesSchedule()) {
+		if roomCapacity, err = r.lockRoomTxn(ctx, txn, *roomID); err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	if updateClass.Capability != nil {
+		if err := r.checkCapacityTxn(ctx, txn, classID, *updateClass.Capability); err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	row := txn.QueryRow(ctx, statement, values...)
+	class, err := scanClass(row)
+	if err != nil {
+		return classes.Class{}, err
+	}
+
+	// The class may have changed since the update was validated, so the class it leaves behind is
+	// validated again while locked.
+	if err := classes.ValidateClass(class); err != nil {
+		return classes.Class{}, err
+	}
+
+	if roomCapacity > 0 && class.Capacity > roomCapacity {
+		return classes.Class{}, fmt.Errorf("room holds %d people: %w", roomCapacity, classes.ErrCapacityAboveRoom)
+	}
+
+	if updateClass.ChangesSchedule() && updateClass.Force == "" {
+		if err := r.checkBookingsInScheduleTxn(ctx, txn, class); err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	if err := r.syncSessionsTxn(ctx, txn, class); err != nil {
+		return classes.Class{}, err
+	}
+
+	if updateClass.ChangesSchedule() && updateClass.Force == classes.OrphanedBookingsCancel {
+		err := r.cancelTakenSessionsTxn(ctx, txn, class, func(classDate time.Time) bool {
+			return !class.OccursOn(classDate)
+		}, orphanedSessionReason)
+		if err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	if class.InstructorID != nil && (updateClass.InstructorID != nil || updateClass.ChangesSchedule()) {
+		if err := r.checkInstructorConflictsTxn(ctx, txn, *class.InstructorID, classID, nil); err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	if class.RoomID != nil && (updateClass.RoomID != nil || updateClass.ChangesSchedule()) {
+		if err := r.checkRoomConflictsTxn(ctx, txn, *class.RoomID, classID, nil); err != nil {
+			return classes.Class{}, err
+		}
+	}
+
+	if updateClass.RoomID != nil || updateClass.Capability != nil || updateClass.ChangesSchedule() {
+		if err := r.checkRoomCapacityTxn(ctx, txn, classID, nil); err != nil {
+			return classes.Class{}, err
+		}
 	}
 
 	if err := txn.Commit(ctx); err != nil {
@@ -149,6 +430,163 @@ func (r *ClassesRepository) Update(ctx context.Context, classID string, updateCl
 	return class, nil
 }
 
+// checkCapacityTxn checks the capacity fits the spots taken by the active bookings and holds of
+// every upcoming date of the class, guests included. Dates with a session capacity override keep
+// their own capacity and aren't checked. Upcoming dates start from today in the class timezone.
+func (r *ClassesRepository) checkCapacityTxn(ctx context.Context, txn pgx.Tx, classID string, capacity int) error {
+	query := `WITH today AS (SELECT (now() AT TIME ZONE timezone)::date AS local_date FROM classes WHERE id = $1)
+			  SELECT taken.class_date, SUM(taken.spots)
+				FROM (SELECT class_date, 1 + guests AS spots
+						FROM bookings
+					  WHERE class_id = $1 AND class_date >= (SELECT local_date FROM today) AND status IN ('booked', 'attended', 'no_show')
+					  UNION ALL
+					  SELECT class_date, 1 + guests AS spots
+						FROM booking_holds
+					  WHERE class_id = $1 AND class_date >= (SELECT local_date FROM today) AND status = 'held' AND expires_at > now()) taken
+				LEFT JOIN class_sessions s ON s.class_id = $1 AND s.session_date = taken.class_date
+			  WHERE s.capacity IS NULL
+			  GROUP BY taken.class_date
+			  HAVING SUM(taken.spots) > $2
+			  ORDER BY taken.class_date
+			  LIMIT 1`
+	var classDate time.Time
+	var booked int
+	err := txn.QueryRow(ctx, query, classID, capacity).Scan(&classDate, &booked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to count class bookings: %w", err)
+	}
+
+	return fmt.Errorf("class has %d bookings on %s: %w", booked, classDate.Format("2006-01-02"), classes.ErrCapacityBelowBookings)
+}
+
+// checkBookingsInScheduleTxn checks the class still runs on every upcoming date it has active
+// bookings on.
+func (r *ClassesRepository) checkBookingsInScheduleTxn(ctx context.Context, txn pgx.Tx, class classes.Class) error {
+	query := `SELECT DISTINCT b.class_date FROM bookings b
+				JOIN classes c ON c.id = b.class_id
+			  WHERE b.class_id = $1 AND b.class_date >= (now() AT TIME ZONE c.timezone)::date AND b.status = 'booked'
+			  ORDER BY b.class_date`
+	rows, err := txn.Query(ctx, query, class.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query class booking dates: %w", err)
+	}
+
+	orphaned := make([]string, 0)
+	for rows.Next() {
+		var classDate time.Time
+		if err := rows.Scan(&classDate); err != nil {
+			return fmt.Errorf("failed to scan class booking date: %w", err)
+		}
+
+		if !class.OccursOn(classDate) {
+			orphaned = append(orphaned, classDate.Format("2006-01-02"))
+		}
+	}
+
+	if len(orphaned) > 0 {
+		return fmt.Errorf("bookings on %s: %w", strings.Join(orphaned, ", "), classes.ErrBookingsOutsideSchedule)
+	}
+
+	return nil
+}
+
+// cancelTakenSessionsTxn cancels the sessions of the class on the upcoming dates with active
+// bookings, holds or waitlist entries that cancel selects, as the studio would, along with the
+// rest of the transaction. Sessions a schedule change already deleted are stored again first, so
+// their holds and waitlist entries are released and their members told.
+func (r *ClassesRepository) cancelTakenSessionsTxn(ctx context.Context, txn pgx.Tx, class classes.Class, cancel func(classDate time.Time) bool,
+	reason string) error {
+	query := `SELECT DISTINCT taken.class_date
+				FROM (SELECT class_date FROM bookings WHERE class_id = $1 AND status = 'booked'
+					  UNION ALL
+					  SELECT class_date FROM booking_holds WHERE class_id = $1 AND status = 'held' AND expires_at > now()
+					  UNION ALL
+					  SELECT class_date FROM waitlist_entries WHERE class_id = $1 AND status = 'waiting') taken
+				JOIN classes c ON c.id = $1
+			  WHERE taken.class_date >= (now() AT TIME ZONE c.timezone)::date
+				AND NOT EXISTS (SELECT 1 FROM class_sessions s
+								WHERE s.class_id = $1 AND s.session_date = taken.class_date AND s.status = 'cancelled')
+			  ORDER BY taken.class_date`
+	rows, err := txn.Query(ctx, query, class.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query class taken dates: %w", err)
+	}
+
+	dates := make([]time.Time, 0)
+	for rows.Next() {
+		var classDate time.Time
+		if err := rows.Scan(&classDate); err != nil {
+			return fmt.Errorf("failed to scan class taken date: %w", err)
+		}
+
+		if cancel(classDate) {
+			dates = append(dates, classDate)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query class taken dates: %w", err)
+	}
+
+	for _, classDate := range dates {
+		session := class.Session(classDate)
+		insertSession := `INSERT INTO class_sessions (class_id, session_date, starts_at, ends_at, status)
+					VALUES ($1, $2, $3, $4, $5)
+					ON CONFLICT (class_id, session_date) DO NOTHING`
+		_, err := txn.Exec(ctx, insertSession, class.ID, classDate, session.StartsAt, session.EndsAt, classes.SessionStatusUnscheduled)
+		if err != nil {
+			return fmt.Errorf("failed to insert session on %s: %w", classDate.Format("2006-01-02"), err)
+		}
+
+		if _, err := r.bookings.CancelSessionTxn(ctx, txn, class.ID, classDate, reason); err != nil {
+			return fmt.Errorf("failed to cancel session on %s: %w", classDate.Format("2006-01-02"), err)
+		}
+	}
+
+	return nil
+}
+
+// Archive marks the class as archived. Archiving an archived class leaves it untouched, while
+// archiving a deleted one fails with classes.ErrClassDeleted.
+func (r *ClassesRepository) Archive(ctx context.Context, classID string) (classes.Class, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return classes.Class{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	statement := `UPDATE classes SET archived_at = now(), updated_at = now(), version = version + 1
+				  WHERE id = $1 AND archived_at IS NULL AND deleted_at IS NULL
+				  RETURNING ` + classColumns
+	class, err := scanClass(txn.QueryRow(ctx, statement, classID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		if err := r.checkNotDeletedTxn(ctx, txn, classID); err != nil {
+			return classes.Class{}, err
+		}
+		class, err = r.getByIdTxn(ctx, txn, classID)
+	}
+	if err != nil {
+		return classes.Class{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return classes.Class{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return class, nil
+}
+
+// Delete archives the class and marks it as deleted, hiding it everywhere. The sessions with
+// upcoming bookings, holds or waitlist entries are cancelled, as the studio would, in the same
+// transaction, releasing the holds and cancelling the waitlist entries. Bookings, holds and
+// waitlist entries are kept as the members' history. Deleting a deleted class fails with
+// classes.ErrClassDeleted and colliding with concurrent bookings with classes.ErrDeleteConflict.
 func (r *ClassesRepository) Delete(ctx context.Context, classID string) error {
 	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadWrite,
@@ -159,20 +597,65 @@ func (r *ClassesRepository) Delete(ctx context.Context, classID string) error {
 
 	defer txn.Rollback(ctx)
 
-	statement := `DELETE FROM classes WHERE id = $1`
-	_, err = txn.Exec(ctx, statement, classID)
+	// Locking the class serializes the deletion with bookings of the same class, and archiving it
+	// first stops the bookings that wait for the lock.
+	statement := `UPDATE classes SET archived_at = COALESCE(archived_at, now()), deleted_at = now(), updated_at = now(), version = version + 1
+				  WHERE id = $1 AND deleted_at IS NULL
+				  RETURNING ` + classColumns
+	class, err := scanClass(txn.QueryRow(ctx, statement, classID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		if err := r.checkNotDeletedTxn(ctx, txn, classID); err != nil {
+			return err
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("failed to delete class: %w", err)
+		return deleteErr(fmt.Errorf("failed to delete class: %w", err))
+	}
+
+	err = r.cancelTakenSessionsTxn(ctx, txn, class, func(time.Time) bool {
+		return true
+	}, deletedClassReason)
+	if err != nil {
+		return deleteErr(err)
 	}
 
 	if err := txn.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction. :%w", err)
+		return deleteErr(fmt.Errorf("failed to commit transaction. :%w", err))
+	}
+
+	return nil
+}
+
+// checkNotDeletedTxn returns classes.ErrClassDeleted when the class was deleted and pgx.ErrNoRows
+// when there is no such class.
+func (r *ClassesRepository) checkNotDeletedTxn(ctx context.Context, txn pgx.Tx, classID string) error {
+	var deleted bool
+	err := txn.QueryRow(ctx, `SELECT deleted_at IS NOT NULL FROM classes WHERE id = $1`, classID).Scan(&deleted)
+	if err != nil {
+		return fmt.Errorf("failed to check class deletion: %w", err)
+	}
+
+	if deleted {
+		return fmt.Errorf("class %s: %w", classID, classes.ErrClassDeleted)
 	}
 
 	return nil
 }
 
-func (r *ClassesRepository) List(ctx context.Context, limit int, offset int) ([]classes.Class, error) {
+// deleteErr marks the errors of deleting a class that collided with concurrent bookings, such as
+// deadlocks, with classes.ErrDeleteConflict.
+func deleteErr(err error) error {
+	var pgErr *pgconn.PgError
+	if (errors.As(err, &pgErr) && (pgErr.Code == "40001" || pgErr.Code == "40P01")) || errors.Is(err, bookings.ErrSessionCancelled) {
+		return fmt.Errorf("%w: %w", classes.ErrDeleteConflict, err)
+	}
+
+	return err
+}
+
+// List lists the active classes matching the filter, ordered by name and then ID so that pages
+// don't skip or repeat classes.
+func (r *ClassesRepository) List(ctx context.Context, filter classes.Filter, limit int, offset int) ([]classes.Class, error) {
 	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadOnly,
 	})
@@ -180,21 +663,47 @@ func (r *ClassesRepository) List(ctx context.Context, limit int, offset int) ([]
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	query := `SELECT id, created_at, updated_at, name, start_date, end_date, capacity
+	values := make([]interface{}, 0)
+	conditions := []string{"archived_at IS NULL"}
+
+	if filter.LocationID != "" {
+		values = append(values, filter.LocationID)
+		conditions = append(conditions, fmt.Sprintf(`(room_id IN (SELECT id FROM rooms WHERE location_id = $%[1]d)
+			OR id IN (SELECT s.class_id FROM class_sessions s JOIN rooms rm ON rm.id = s.room_id
+					  WHERE rm.location_id = $%[1]d AND s.status <> 'cancelled'))`, len(values)))
+	}
+
+	if filter.Category != "" {
+		values = append(values, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(values)))
+	}
+
+	if filter.Tag != "" {
+		values = append(values, []string{filter.Tag})
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d", len(values)))
+	}
+
+	if filter.Level != "" {
+		values = append(values, filter.Level)
+		conditions = append(conditions, fmt.Sprintf("level = $%d", len(values)))
+	}
+
+	values = append(values, limit, offset)
+	query := `SELECT ` + classColumns + `
 				FROM classes
-			  LIMIT $1 OFFSET $2;`
+			  WHERE ` + strings.Join(conditions, " AND ") + `
+			  ORDER BY name, id` + fmt.Sprintf(" LIMIT $%d OFFSET $%d;", len(values)-1, len(values))
 
-	rows, err := txn.Query(ctx, query, limit, offset)
+	rows, err := txn.Query(ctx, query, values...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query classes: %w", err)
 	}
 
 	allClasses := make([]classes.Class, 0)
 	for rows.Next() {
-		var class classes.Class
-		err := rows.Scan(&class.ID, &class.CreatedAt, &class.UpdatedAt, &class.Name, &class.StartDate, &class.EndDate, &class.Capacity)
+		class, err := scanClass(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan classes row to classes.Class: %w", err)
+			return nil, err
 		}
 
 		allClasses = append(allClasses, class)
@@ -206,3 +715,65 @@ func (r *ClassesRepository) List(ctx context.Context, limit int, offset int) ([]
 
 	return allClasses, nil
 }
+
+// GetRoomTimezone returns the timezone of the location of the room, or classes.ErrRoomNotFound
+// when there's no such room.
+func (r *ClassesRepository) GetRoomTimezone(ctx context.Context, roomID string) (string, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	var timezone string
+	query := `SELECT l.timezone FROM rooms r JOIN locations l ON l.id = r.location_id WHERE r.id = $1`
+	err = txn.QueryRow(ctx, query, roomID).Scan(&timezone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("room %s: %w", roomID, classes.ErrRoomNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get room timezone: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return timezone, nil
+}
+
+// ListRoomIDs lists the IDs of the rooms of the location.
+func (r *ClassesRepository) ListRoomIDs(ctx context.Context, locationID string) ([]string, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	rows, err := txn.Query(ctx, `SELECT id FROM rooms WHERE location_id = $1 ORDER BY id`, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rooms: %w", err)
+	}
+
+	roomIDs := make([]string, 0)
+	for rows.Next() {
+		var roomID string
+		if err := rows.Scan(&roomID); err != nil {
+			return nil, fmt.Errorf("failed to scan rooms row: %w", err)
+		}
+
+		roomIDs = append(roomIDs, roomID)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return roomIDs, nil
+}
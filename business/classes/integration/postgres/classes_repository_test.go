@@ -72,6 +72,78 @@ func TestRepository_AddClass(t *testing.T) {
 	assert.Equal(t, class.Capacity, addedClass.Capacity)
 }
 
+func TestRepository_AddClass_WithRecurrenceAndCancellationPolicy(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	class := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      uuid.NewString(),
+		StartDate: now,
+		EndDate:   now.Add(time.Hour * 24 * 30),
+		Capacity:  20,
+		CancellationPolicy: &classes.CancellationPolicy{
+			FreeCancelCutoffMinutes: 720,
+			LateCancelPenalty:       classes.PenaltyCredit,
+		},
+		Recurrence: &classes.Recurrence{
+			Frequency:       classes.FrequencyWeekly,
+			Interval:        2,
+			Weekdays:        []classes.Weekday{classes.Tuesday, classes.Thursday},
+			StartTime:       "18:30",
+			DurationMinutes: 50,
+		},
+	}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	classFound, err := repo.GetByID(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Equal(t, class.CancellationPolicy, classFound.CancellationPolicy)
+	assert.Equal(t, class.Recurrence, classFound.Recurrence)
+}
+
+func TestRepository_UpdateClass_ClearCancellationPolicy(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	class := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      uuid.NewString(),
+		StartDate: now,
+		EndDate:   now,
+		Capacity:  20,
+		CancellationPolicy: &classes.CancellationPolicy{
+			FreeCancelCutoffMinutes: 720,
+			LateCancelPenalty:       classes.PenaltyCredit,
+		},
+	}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	updatedClass, err := repo.Update(ctx, class.ID, classes.UpdateClass{CancellationPolicy: &classes.CancellationPolicy{}})
+	require.NoError(t, err)
+	assert.Nil(t, updatedClass.CancellationPolicy)
+
+	classFound, err := repo.GetByID(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Nil(t, classFound.CancellationPolicy)
+}
+
 func TestRepository_GetByID(t *testing.T) {
 	if os.Getenv("INTEGRATION") == "" {
 		t.Skip("skipping integration tests: set INTEGRATION environment variable")
@@ -100,8 +172,8 @@ func TestRepository_GetByID(t *testing.T) {
 	assert.NotEmpty(t, classFound.CreatedAt)
 	assert.NotEmpty(t, classFound.UpdatedAt)
 	assert.Equal(t, class.Name, classFound.Name)
-	assert.Equal(t, class.StartDate, classFound.StartDate)
-	assert.Equal(t, class.EndDate, classFound.EndDate)
+	assert.WithinDuration(t, class.StartDate, classFound.StartDate, time.Microsecond)
+	assert.WithinDuration(t, class.EndDate, classFound.EndDate, time.Microsecond)
 	assert.Equal(t, class.Capacity, classFound.Capacity)
 }
 
@@ -184,6 +256,11 @@ func TestRepository_DeleteClass(t *testing.T) {
 
 	_, err = repo.GetByID(ctx, class.ID)
 	require.True(t, repo.IsNotFoundErr(err))
+
+	classFound, err := repo.GetByIDIncludingDeleted(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Equal(t, class.ID, classFound.ID)
+	assert.NotNil(t, classFound.ArchivedAt)
 }
 
 func TestRepository_ListClass(t *testing.T) {
@@ -207,7 +284,438 @@ func TestRepository_ListClass(t *testing.T) {
 	_, err := repo.Add(ctx, class)
 	require.NoError(t, err)
 
-	allClasses, err := repo.List(ctx, 100, 0)
+	allClasses, err := repo.List(ctx, classes.Filter{}, 100, 0)
 	require.NoError(t, err)
 	require.NotEmpty(t, allClasses)
 }
+
+func TestRepository_ListClass_Pages(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	category := uuid.NewString()
+	for i := 0; i < 101; i++ {
+		_, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: "Yoga", Category: category, StartDate: now, EndDate: now, Capacity: 20})
+		require.NoError(t, err)
+	}
+
+	firstPage, err := repo.List(ctx, classes.Filter{Category: category}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 100)
+
+	secondPage, err := repo.List(ctx, classes.Filter{Category: category}, 100, 100)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+
+	classIDs := make(map[string]bool)
+	for _, class := range append(firstPage, secondPage...) {
+		classIDs[class.ID] = true
+	}
+	assert.Len(t, classIDs, 101)
+}
+
+func TestRepository_UpdateClass_IfVersion(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	class, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 20})
+	require.NoError(t, err)
+	assert.Equal(t, 1, class.Version)
+
+	newCapacity := 45
+	updatedClass, err := repo.Update(ctx, class.ID, classes.UpdateClass{Capability: &newCapacity, IfVersions: []int{class.Version}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updatedClass.Version)
+
+	staleCapacity := 10
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{Capability: &staleCapacity, IfVersions: []int{class.Version}})
+	require.ErrorIs(t, err, classes.ErrVersionMismatch)
+
+	classFound, err := repo.GetByID(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Equal(t, newCapacity, classFound.Capacity)
+	assert.Equal(t, 2, classFound.Version)
+}
+
+func TestRepository_ArchiveClass(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	class, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 20})
+	require.NoError(t, err)
+
+	archivedClass, err := repo.Archive(ctx, class.ID)
+	require.NoError(t, err)
+	require.NotNil(t, archivedClass.ArchivedAt)
+
+	rearchivedClass, err := repo.Archive(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Equal(t, archivedClass.ArchivedAt, rearchivedClass.ArchivedAt)
+	assert.Equal(t, archivedClass.Version, rearchivedClass.Version)
+	assert.Equal(t, archivedClass.UpdatedAt, rearchivedClass.UpdatedAt)
+
+	classFound, err := repo.GetByID(ctx, class.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, classFound.ArchivedAt)
+
+	allClasses, err := repo.List(ctx, classes.Filter{}, 100000, 0)
+	require.NoError(t, err)
+	for _, listed := range allClasses {
+		assert.NotEqual(t, class.ID, listed.ID)
+	}
+
+	_, err = repo.Archive(ctx, uuid.NewString())
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_DeleteClass_UpcomingBookings(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	classDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	nextDate := classDate.AddDate(0, 0, 1)
+	class, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: classDate, EndDate: nextDate, Capacity: 20})
+	require.NoError(t, err)
+
+	memberID := uuid.NewString()
+	bookingID := uuid.NewString()
+	_, err = db.Exec(ctx, `INSERT INTO members (id, name) VALUES ($1, $2)`, memberID, uuid.NewString())
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id)
+		SELECT $1, $2, class_id, session_date, id FROM class_sessions WHERE class_id = $3 AND session_date = $4`,
+		bookingID, memberID, class.ID, classDate)
+	require.NoError(t, err)
+
+	holdID := uuid.NewString()
+	entryID := uuid.NewString()
+	_, err = db.Exec(ctx, `INSERT INTO booking_holds (id, member_id, class_id, class_date, expires_at) VALUES ($1, $2, $3, $4, now() + interval '1 hour')`,
+		holdID, memberID, class.ID, nextDate)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO waitlist_entries (id, member_id, class_id, class_date) VALUES ($1, $2, $3, $4)`,
+		entryID, memberID, class.ID, nextDate)
+	require.NoError(t, err)
+
+	err = repo.Delete(ctx, class.ID)
+	require.NoError(t, err)
+
+	_, err = repo.GetByID(ctx, class.ID)
+	require.True(t, repo.IsNotFoundErr(err))
+
+	var status string
+	err = db.QueryRow(ctx, `SELECT status FROM bookings WHERE id = $1`, bookingID).Scan(&status)
+	require.NoError(t, err)
+	assert.Equal(t, "studio_cancelled", status)
+
+	var sessionStatus string
+	err = db.QueryRow(ctx, `SELECT status FROM class_sessions WHERE class_id = $1 AND session_date = $2`, class.ID, classDate).Scan(&sessionStatus)
+	require.NoError(t, err)
+	assert.Equal(t, string(classes.SessionStatusCancelled), sessionStatus)
+
+	var holdStatus, entryStatus string
+	err = db.QueryRow(ctx, `SELECT status FROM booking_holds WHERE id = $1`, holdID).Scan(&holdStatus)
+	require.NoError(t, err)
+	assert.Equal(t, "released", holdStatus)
+	err = db.QueryRow(ctx, `SELECT status FROM waitlist_entries WHERE id = $1`, entryID).Scan(&entryStatus)
+	require.NoError(t, err)
+	assert.Equal(t, "studio_cancelled", entryStatus)
+
+	var events int
+	err = db.QueryRow(ctx, `SELECT count(*) FROM events WHERE member_id = $1`, memberID).Scan(&events)
+	require.NoError(t, err)
+	assert.Equal(t, 2, events)
+
+	err = repo.Delete(ctx, class.ID)
+	assert.ErrorIs(t, err, classes.ErrClassDeleted)
+
+	_, err = repo.Archive(ctx, class.ID)
+	assert.ErrorIs(t, err, classes.ErrClassDeleted)
+
+	err = repo.Delete(ctx, uuid.NewString())
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_InstructorConflicts(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	instructorID := uuid.NewString()
+	_, err := db.Exec(ctx, `INSERT INTO instructors (id, name) VALUES ($1, $2)`, instructorID, uuid.NewString())
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	recurrence := func(startTime string) *classes.Recurrence {
+		return &classes.Recurrence{
+			Frequency:       classes.FrequencyDaily,
+			Interval:        1,
+			StartTime:       startTime,
+			DurationMinutes: 60,
+		}
+	}
+
+	morning, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: tomorrow, EndDate: tomorrow.AddDate(0, 0, 7),
+		Capacity: 20, Recurrence: recurrence("08:00"), InstructorID: &instructorID})
+	require.NoError(t, err)
+	assert.Equal(t, instructorID, *morning.InstructorID)
+
+	_, err = repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: tomorrow, EndDate: tomorrow,
+		Capacity: 20, Recurrence: recurrence("08:30"), InstructorID: &instructorID})
+	require.ErrorIs(t, err, classes.ErrInstructorConflict)
+
+	evening, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: tomorrow, EndDate: tomorrow.AddDate(0, 0, 7),
+		Capacity: 20, Recurrence: recurrence("18:00")})
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, evening.ID, classes.UpdateClass{InstructorID: &instructorID})
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, evening.ID, classes.UpdateClass{Recurrence: recurrence("08:15")})
+	require.ErrorIs(t, err, classes.ErrInstructorConflict)
+
+	unassign := ""
+	evening, err = repo.Update(ctx, evening.ID, classes.UpdateClass{InstructorID: &unassign})
+	require.NoError(t, err)
+	assert.Nil(t, evening.InstructorID)
+
+	_, err = repo.Update(ctx, evening.ID, classes.UpdateClass{Recurrence: recurrence("08:15")})
+	require.NoError(t, err)
+
+	_, err = repo.UpdateSession(ctx, evening.ID, tomorrow, classes.UpdateSession{InstructorID: &instructorID})
+	require.ErrorIs(t, err, classes.ErrInstructorConflict)
+
+	unknownInstructorID := uuid.NewString()
+	_, err = repo.UpdateSession(ctx, evening.ID, tomorrow, classes.UpdateSession{InstructorID: &unknownInstructorID})
+	require.ErrorIs(t, err, classes.ErrInstructorNotFound)
+
+	_, err = db.Exec(ctx, `UPDATE class_sessions SET status = 'cancelled' WHERE class_id = $1 AND session_date = $2`, morning.ID, tomorrow)
+	require.NoError(t, err)
+
+	session, err := repo.UpdateSession(ctx, evening.ID, tomorrow, classes.UpdateSession{InstructorID: &instructorID})
+	require.NoError(t, err)
+	assert.Equal(t, instructorID, *session.InstructorID)
+}
+
+func TestRepository_RoomConstraints(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	locationID := uuid.NewString()
+	roomID := uuid.NewString()
+	_, err := db.Exec(ctx, `INSERT INTO locations (id, name, address, timezone) VALUES ($1, $2, $3, $4)`,
+		locationID, uuid.NewString(), "Av. Paulista, 1000", "America/Sao_Paulo")
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO rooms (id, location_id, name, capacity) VALUES ($1, $2, $3, $4)`, roomID, locationID, "Studio A", 20)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	newClass := func(startTime string, capacity int) classes.Class {
+		return classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: tomorrow, EndDate: tomorrow.AddDate(0, 0, 7),
+			Capacity: capacity, RoomID: &roomID, Recurrence: &classes.Recurrence{
+				Frequency:       classes.FrequencyDaily,
+				Interval:        1,
+				StartTime:       startTime,
+				DurationMinutes: 60,
+			}}
+	}
+
+	_, err = repo.Add(ctx, newClass("08:00", 25))
+	require.ErrorIs(t, err, classes.ErrCapacityAboveRoom)
+
+	unknownRoomClass := newClass("08:00", 10)
+	unknownRoomID := uuid.NewString()
+	unknownRoomClass.RoomID = &unknownRoomID
+	_, err = repo.Add(ctx, unknownRoomClass)
+	require.ErrorIs(t, err, classes.ErrRoomNotFound)
+
+	morning, err := repo.Add(ctx, newClass("08:00", 20))
+	require.NoError(t, err)
+	assert.Equal(t, roomID, *morning.RoomID)
+
+	_, err = repo.Add(ctx, newClass("08:30", 10))
+	require.ErrorIs(t, err, classes.ErrRoomConflict)
+
+	capacity := 21
+	_, err = repo.Update(ctx, morning.ID, classes.UpdateClass{Capability: &capacity})
+	require.ErrorIs(t, err, classes.ErrCapacityAboveRoom)
+
+	sessionCapacity := 30
+	_, err = repo.UpdateSession(ctx, morning.ID, tomorrow, classes.UpdateSession{Capacity: &sessionCapacity})
+	require.ErrorIs(t, err, classes.ErrCapacityAboveRoom)
+
+	eveningClass := newClass("18:00", 10)
+	eveningClass.RoomID = nil
+	evening, err := repo.Add(ctx, eveningClass)
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, evening.ID, classes.UpdateClass{RoomID: &roomID})
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, evening.ID, classes.UpdateClass{Recurrence: newClass("08:15", 10).Recurrence})
+	require.ErrorIs(t, err, classes.ErrRoomConflict)
+
+	inLocation, err := repo.List(ctx, classes.Filter{LocationID: locationID}, 100, 0)
+	require.NoError(t, err)
+	assert.Len(t, inLocation, 2)
+
+	// A class without a room is listed in the location of a room one of its sessions moved to.
+	nightClass := newClass("21:00", 10)
+	nightClass.RoomID = nil
+	night, err := repo.Add(ctx, nightClass)
+	require.NoError(t, err)
+
+	_, err = repo.UpdateSession(ctx, night.ID, tomorrow, classes.UpdateSession{RoomID: &roomID})
+	require.NoError(t, err)
+
+	inLocation, err = repo.List(ctx, classes.Filter{LocationID: locationID}, 100, 0)
+	require.NoError(t, err)
+	assert.Len(t, inLocation, 3)
+
+	elsewhere, err := repo.List(ctx, classes.Filter{LocationID: uuid.NewString()}, 100, 0)
+	require.NoError(t, err)
+	assert.Empty(t, elsewhere)
+}
+
+func TestRepository_ClassTimezone(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	locationID := uuid.NewString()
+	roomID := uuid.NewString()
+	_, err := db.Exec(ctx, `INSERT INTO locations (id, name, address, timezone) VALUES ($1, $2, $3, $4)`,
+		locationID, uuid.NewString(), "5th Avenue, 100", "America/New_York")
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO rooms (id, location_id, name, capacity) VALUES ($1, $2, $3, $4)`, roomID, locationID, "Studio A", 20)
+	require.NoError(t, err)
+
+	timezone, err := repo.GetRoomTimezone(ctx, roomID)
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", timezone)
+
+	_, err = repo.GetRoomTimezone(ctx, uuid.NewString())
+	require.ErrorIs(t, err, classes.ErrRoomNotFound)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// New York springs forward on 2023-03-12.
+	class, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), Capacity: 10, RoomID: &roomID,
+		StartDate: time.Date(2023, 3, 11, 6, 30, 0, 0, newYork), EndDate: time.Date(2023, 3, 12, 7, 30, 0, 0, newYork)})
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", class.Timezone)
+
+	sessions, err := repo.ListSessions(ctx, class.ID, time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.True(t, sessions[0].StartsAt.Equal(time.Date(2023, 3, 11, 11, 30, 0, 0, time.UTC)))
+	assert.True(t, sessions[1].StartsAt.Equal(time.Date(2023, 3, 12, 10, 30, 0, 0, time.UTC)))
+
+	unassigned, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), Capacity: 10,
+		StartDate: class.StartDate, EndDate: class.EndDate})
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", unassigned.Timezone)
+
+	saoPaulo := "America/Sao_Paulo"
+	moved, err := repo.Update(ctx, class.ID, classes.UpdateClass{Timezone: &saoPaulo})
+	require.NoError(t, err)
+	assert.Equal(t, saoPaulo, moved.Timezone)
+
+	sessions, err = repo.ListSessions(ctx, class.ID, time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.True(t, sessions[1].StartsAt.Equal(time.Date(2023, 3, 12, 11, 30, 0, 0, time.UTC)))
+
+	_, err = db.Exec(ctx, `UPDATE classes SET timezone = 'America/Atlantis' WHERE id = $1`, class.ID)
+	require.Error(t, err)
+}
+
+func TestRepository_ClassMetadata(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	class, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 20,
+		Recurrence:  &classes.Recurrence{Frequency: classes.FrequencyDaily, Interval: 1, StartTime: "18:00", DurationMinutes: 60},
+		Description: "Power flow.", Category: "yoga", Tags: []string{"hot", "evening"}, Level: classes.LevelIntermediate,
+		DurationMinutes: 60, ImageURL: "https://images.example.com/yoga.png"})
+	require.NoError(t, err)
+	assert.Equal(t, "Power flow.", class.Description)
+	assert.Equal(t, "yoga", class.Category)
+	assert.Equal(t, []string{"hot", "evening"}, class.Tags)
+	assert.Equal(t, classes.LevelIntermediate, class.Level)
+	assert.Equal(t, 60, class.DurationMinutes)
+	assert.Equal(t, "https://images.example.com/yoga.png", class.ImageURL)
+
+	untagged, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now, Capacity: 20})
+	require.NoError(t, err)
+	assert.Empty(t, untagged.Tags)
+
+	found, err := repo.List(ctx, classes.Filter{Category: "yoga", Tag: "hot", Level: classes.LevelIntermediate}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, class.ID, found[0].ID)
+
+	noTags := []string{}
+	advanced := classes.LevelAdvanced
+	updated, err := repo.Update(ctx, class.ID, classes.UpdateClass{Tags: &noTags, Level: &advanced})
+	require.NoError(t, err)
+	assert.Empty(t, updated.Tags)
+	assert.Equal(t, classes.LevelAdvanced, updated.Level)
+	assert.Equal(t, "yoga", updated.Category)
+
+	found, err = repo.List(ctx, classes.Filter{Tag: "hot"}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
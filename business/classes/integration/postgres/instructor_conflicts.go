@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/jackc/pgx/v5"
+)
+
+// lockInstructorTxn locks the instructor row, returning classes.ErrInstructorNotFound when there
+// is no such instructor. Holding the lock serializes concurrent assignments of the same
+// instructor, so conflict checks cannot be raced by another transaction.
+func (r *ClassesRepository) lockInstructorTxn(ctx context.Context, txn pgx.Tx, instructorID string) error {
+	lockInstructor := `SELECT id FROM instructors WHERE id = $1 FOR UPDATE`
+	err := txn.QueryRow(ctx, lockInstructor, instructorID).Scan(&instructorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("instructor %s: %w", instructorID, classes.ErrInstructorNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock instructor: %w", err)
+	}
+
+	return nil
+}
+
+// checkInstructorConflictsTxn returns classes.ErrInstructorConflict when an upcoming session of
+// the class the instructor teaches overlaps another session they teach. Only the session on
+// sessionDate is checked when it's set. Cancelled sessions and archived classes don't conflict.
+func (r *ClassesRepository) checkInstructorConflictsTxn(ctx context.Context, txn pgx.Tx, instructorID string, classID string, sessionDate *time.Time) error {
+	query := `WITH taught AS (SELECT s.id, s.class_id, s.session_date, s.starts_at, s.ends_at
+								FROM class_sessions s
+								JOIN classes c ON c.id = s.class_id
+							  WHERE COALESCE(s.instructor_id, c.instructor_id) = $1 AND s.status <> 'cancelled'
+								AND c.archived_at IS NULL AND s.ends_at > now())
+			  SELECT a.session_date, b.class_id, b.session_date
+				FROM taught a
+				JOIN taught b ON b.id <> a.id AND a.starts_at < b.ends_at AND b.starts_at < a.ends_at
+			  WHERE a.class_id = $2 AND ($3::date IS NULL OR a.session_date = $3)
+			  ORDER BY a.starts_at
+			  LIMIT 1`
+	var date, otherDate time.Time
+	var otherClassID string
+	err := txn.QueryRow(ctx, query, instructorID, classID, sessionDate).Scan(&date, &otherClassID, &otherDate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check instructor schedule: %w", err)
+	}
+
+	return fmt.Errorf("session on %s overlaps the session of class %s on %s: %w", date.Format("2006-01-02"), otherClassID,
+		otherDate.Format("2006-01-02"), classes.ErrInstructorConflict)
+}
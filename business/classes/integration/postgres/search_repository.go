@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/jackc/pgx/v5"
+)
+
+// searchMatches finds the active classes whose search document matches the query in $1. The
+// documents weigh names the most, then tags and instructor names, then descriptions.
+const searchMatches = `WITH matches AS (
+				SELECT c.id, c.name, c.description, c.category, c.level, c.room_id, q.query,
+					   ts_rank_cd(c.search_document, q.query) AS rank
+				  FROM classes c
+				  CROSS JOIN websearch_to_tsquery('english', $1) AS q (query)
+				 WHERE c.archived_at IS NULL AND c.search_document @@ q.query)`
+
+// searchPage ranks the matches and returns a page of them with a snippet of their name and
+// description, the matching words delimited by snippetStart and snippetStop.
+const searchPage = searchMatches + `
+			  SELECT m.id, m.rank,
+					 ts_headline('english', concat_ws(': ', m.name, NULLIF(m.description, '')), m.query,
+								 'StartSel=' || $4::text || ', StopSel=' || $5::text || ', MaxWords=25, MinWords=10, MaxFragments=2')
+				FROM matches m
+			   ORDER BY m.rank DESC, m.name, m.id
+			   LIMIT $2 OFFSET $3`
+
+// searchFacets counts the matches, in total and by category, level and location.
+const searchFacets = searchMatches + `
+			  SELECT 'total', '', '', COUNT(*) FROM matches
+			  UNION ALL
+			  SELECT 'category', category, '', COUNT(*) FROM matches WHERE category <> '' GROUP BY category
+			  UNION ALL
+			  SELECT 'level', level, '', COUNT(*) FROM matches WHERE level <> '' GROUP BY level
+			  UNION ALL
+			  SELECT 'location', l.id, l.name, COUNT(*)
+				FROM matches m
+				JOIN rooms r ON r.id = m.room_id
+				JOIN locations l ON l.id = r.location_id
+			   GROUP BY l.id, l.name
+			  ORDER BY 4 DESC, 2`
+
+// snippetStart and snippetStop delimit the matching words in the snippets ts_headline returns.
+// Being control characters, they survive HTML escaping the snippets, and are then replaced by
+// <mark> tags.
+const (
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
+)
+
+var snippetMarks = strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>")
+
+// highlightSnippet escapes the snippet as HTML and marks its matching words.
+func highlightSnippet(snippet string) string {
+	return snippetMarks.Replace(html.EscapeString(snippet))
+}
+
+// Search runs a full-text search over the name, description and tags of the active classes and the
+// name of their instructors, returning a page of the matches ranked along with facet counts over
+// every match.
+func (r *ClassesRepository) Search(ctx context.Context, query string, limit int, offset int) (classes.SearchResults, error) {
+	// The page and the facets are counted over the same snapshot.
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return classes.SearchResults{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	rows, err := txn.Query(ctx, searchPage, query, limit, offset, snippetStart, snippetStop)
+	if err != nil {
+		return classes.SearchResults{}, fmt.Errorf("failed to search classes: %w", err)
+	}
+
+	results := make([]classes.SearchResult, 0)
+	classIDs := make([]string, 0)
+	for rows.Next() {
+		var result classes.SearchResult
+		if err := rows.Scan(&result.Class.ID, &result.Rank, &result.Snippet); err != nil {
+			return classes.SearchResults{}, fmt.Errorf("failed to scan class search result: %w", err)
+		}
+
+		result.Snippet = highlightSnippet(result.Snippet)
+
+		results = append(results, result)
+		classIDs = append(classIDs, result.Class.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return classes.SearchResults{}, fmt.Errorf("failed to search classes: %w", err)
+	}
+
+	classesByID, err := r.getByIDsTxn(ctx, txn, classIDs)
+	if err != nil {
+		return classes.SearchResults{}, err
+	}
+
+	for index := range results {
+		class, ok := classesByID[results[index].Class.ID]
+		if !ok {
+			return classes.SearchResults{}, fmt.Errorf("class %s matching the search wasn't found", results[index].Class.ID)
+		}
+		results[index].Class = class
+	}
+
+	searchResults := classes.SearchResults{
+		Results: results,
+		Facets: classes.SearchFacets{
+			Categories: make([]classes.Facet, 0),
+			Levels:     make([]classes.Facet, 0),
+			Locations:  make([]classes.Facet, 0),
+		},
+	}
+
+	rows, err = txn.Query(ctx, searchFacets, query)
+	if err != nil {
+		return classes.SearchResults{}, fmt.Errorf("failed to count class search facets: %w", err)
+	}
+
+	for rows.Next() {
+		var kind string
+		var facet classes.Facet
+		if err := rows.Scan(&kind, &facet.Value, &facet.Label, &facet.Count); err != nil {
+			return classes.SearchResults{}, fmt.Errorf("failed to scan class search facet: %w", err)
+		}
+
+		switch kind {
+		case "total":
+			searchResults.Total = facet.Count
+		case "category":
+			searchResults.Facets.Categories = append(searchResults.Facets.Categories, facet)
+		case "level":
+			searchResults.Facets.Levels = append(searchResults.Facets.Levels, facet)
+		case "location":
+			searchResults.Facets.Locations = append(searchResults.Facets.Locations, facet)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return classes.SearchResults{}, fmt.Errorf("failed to count class search facets: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return classes.SearchResults{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return searchResults, nil
+}
+
+// getByIDsTxn returns the classes with the given IDs by ID.
+func (r *ClassesRepository) getByIDsTxn(ctx context.Context, txn pgx.Tx, classIDs []string) (map[string]classes.Class, error) {
+	query := `SELECT ` + classColumns + ` FROM classes WHERE id = ANY ($1)`
+	rows, err := txn.Query(ctx, query, classIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query classes: %w", err)
+	}
+
+	classesByID := make(map[string]classes.Class, len(classIDs))
+	for rows.Next() {
+		class, err := scanClass(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		classesByID[class.ID] = class
+	}
+
+	return classesByID, rows.Err()
+}
@@ -0,0 +1,120 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_Search(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	locationID := uuid.NewString()
+	roomID := uuid.NewString()
+	_, err := db.Exec(ctx, `INSERT INTO locations (id, name, address, timezone) VALUES ($1, $2, $3, $4)`,
+		locationID, "Paulista", "Av. Paulista, 1000", "America/Sao_Paulo")
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO rooms (id, location_id, name, capacity) VALUES ($1, $2, $3, $4)`, roomID, locationID, "Studio A", 20)
+	require.NoError(t, err)
+
+	instructorID := uuid.NewString()
+	_, err = db.Exec(ctx, `INSERT INTO instructors (id, name) VALUES ($1, $2)`, instructorID, "Marina Flores")
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	addClass := func(class classes.Class) classes.Class {
+		class.ID = uuid.NewString()
+		class.StartDate = now
+		class.EndDate = now
+		class.Capacity = 10
+		added, err := repo.Add(ctx, class)
+		require.NoError(t, err)
+		return added
+	}
+
+	hotYoga := addClass(classes.Class{Name: "Hot Yoga", Description: "Flowing postures in a heated room.", Category: "yoga",
+		Level: classes.LevelBeginner, RoomID: &roomID})
+	yinYoga := addClass(classes.Class{Name: "Yin", Description: "Long holds to stretch after running.", Category: "yoga",
+		Tags: []string{"yoga", "stretching"}, Level: classes.LevelAll})
+	spin := addClass(classes.Class{Name: "Spin", Description: "Cycling intervals.", Category: "spin", InstructorID: &instructorID})
+	archived := addClass(classes.Class{Name: "Yoga Nidra", Category: "yoga"})
+	_, err = repo.Archive(ctx, archived.ID)
+	require.NoError(t, err)
+
+	results, err := repo.Search(ctx, "yoga", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, results.Total)
+	require.Len(t, results.Results, 2)
+	// Matching the name ranks above matching a tag.
+	assert.Equal(t, hotYoga.ID, results.Results[0].Class.ID)
+	assert.Equal(t, "Hot Yoga", results.Results[0].Class.Name)
+	assert.Contains(t, results.Results[0].Snippet, "<mark>Yoga</mark>")
+	assert.Equal(t, yinYoga.ID, results.Results[1].Class.ID)
+	assert.Greater(t, results.Results[0].Rank, results.Results[1].Rank)
+
+	assert.Equal(t, []classes.Facet{{Value: "yoga", Count: 2}}, results.Facets.Categories)
+	assert.ElementsMatch(t, []classes.Facet{{Value: "beginner", Count: 1}, {Value: "all", Count: 1}}, results.Facets.Levels)
+	assert.Equal(t, []classes.Facet{{Value: locationID, Label: "Paulista", Count: 1}}, results.Facets.Locations)
+
+	page, err := repo.Search(ctx, "yoga", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	require.Len(t, page.Results, 1)
+	assert.Equal(t, yinYoga.ID, page.Results[0].Class.ID)
+
+	// Words are stemmed, and instructors are searched by name.
+	results, err = repo.Search(ctx, "runs", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, yinYoga.ID, results.Results[0].Class.ID)
+
+	results, err = repo.Search(ctx, "marina", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, spin.ID, results.Results[0].Class.ID)
+
+	results, err = repo.Search(ctx, "yoga -stretching", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, hotYoga.ID, results.Results[0].Class.ID)
+
+	results, err = repo.Search(ctx, "pilates", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results.Results)
+	assert.Equal(t, 0, results.Total)
+	assert.Empty(t, results.Facets.Categories)
+
+	// Renaming an instructor updates the classes they teach.
+	_, err = db.Exec(ctx, `UPDATE instructors SET name = 'Paula Souza' WHERE id = $1`, instructorID)
+	require.NoError(t, err)
+
+	results, err = repo.Search(ctx, "paula", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, spin.ID, results.Results[0].Class.ID)
+
+	// Snippets are escaped, so only the marks are HTML.
+	barre := addClass(classes.Class{Name: "Barre", Description: `<img src="x" onerror="alert(1)"> & more`})
+	results, err = repo.Search(ctx, "barre", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, barre.ID, results.Results[0].Class.ID)
+	assert.Contains(t, results.Results[0].Snippet, "<mark>Barre</mark>")
+	assert.Contains(t, results.Results[0].Snippet, "&lt;img")
+	assert.NotContains(t, results.Results[0].Snippet, "<img")
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/jackc/pgx/v5"
+)
+
+// lockRoomTxn locks the room row and returns its capacity, or classes.ErrRoomNotFound when there
+// is no such room. Like instructors, rooms are locked after the class.
+func (r *ClassesRepository) lockRoomTxn(ctx context.Context, txn pgx.Tx, roomID string) (int, error) {
+	var capacity int
+	lockRoom := `SELECT capacity FROM rooms WHERE id = $1 FOR UPDATE`
+	err := txn.QueryRow(ctx, lockRoom, roomID).Scan(&capacity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("room %s: %w", roomID, classes.ErrRoomNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock room: %w", err)
+	}
+
+	return capacity, nil
+}
+
+// checkRoomConflictsTxn returns classes.ErrRoomConflict when an upcoming session of the class in
+// the room overlaps another session in the room. Only the session on sessionDate is checked when
+// it's set. Session rooms override the room of their class. Like instructor conflicts, cancelled
+// sessions and archived classes don't conflict.
+func (r *ClassesRepository) checkRoomConflictsTxn(ctx context.Context, txn pgx.Tx, roomID string, classID string, sessionDate *time.Time) error {
+	query := `WITH taken AS (SELECT s.id, s.class_id, s.session_date, s.starts_at, s.ends_at
+							   FROM class_sessions s
+							   JOIN classes c ON c.id = s.class_id
+							 WHERE COALESCE(s.room_id, c.room_id) = $1 AND s.status <> 'cancelled'
+							   AND c.archived_at IS NULL AND s.ends_at > now())
+			  SELECT a.session_date, b.class_id, b.session_date
+				FROM taken a
+				JOIN taken b ON b.id <> a.id AND a.starts_at < b.ends_at AND b.starts_at < a.ends_at
+			  WHERE a.class_id = $2 AND ($3::date IS NULL OR a.session_date = $3)
+			  ORDER BY a.starts_at
+			  LIMIT 1`
+	var date, otherDate time.Time
+	var otherClassID string
+	err := txn.QueryRow(ctx, query, roomID, classID, sessionDate).Scan(&date, &otherClassID, &otherDate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check room schedule: %w", err)
+	}
+
+	return fmt.Errorf("session on %s overlaps the session of class %s on %s: %w", date.Format("2006-01-02"), otherClassID,
+		otherDate.Format("2006-01-02"), classes.ErrRoomConflict)
+}
+
+// checkRoomCapacityTxn returns classes.ErrCapacityAboveRoom when an upcoming session of the class
+// takes more people than its room holds. Only the session on sessionDate is checked when it's set.
+func (r *ClassesRepository) checkRoomCapacityTxn(ctx context.Context, txn pgx.Tx, classID string, sessionDate *time.Time) error {
+	query := `SELECT s.session_date, COALESCE(s.capacity, c.capacity), rm.capacity
+				FROM class_sessions s
+				JOIN classes c ON c.id = s.class_id
+				JOIN rooms rm ON rm.id = COALESCE(s.room_id, c.room_id)
+			  WHERE s.class_id = $1 AND ($2::date IS NULL OR s.session_date = $2) AND s.status = 'scheduled'
+				AND s.ends_at > now() AND COALESCE(s.capacity, c.capacity) > rm.capacity
+			  ORDER BY s.starts_at
+			  LIMIT 1`
+	var date time.Time
+	var capacity, roomCapacity int
+	err := txn.QueryRow(ctx, query, classID, sessionDate).Scan(&date, &capacity, &roomCapacity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check room capacity: %w", err)
+	}
+
+	return fmt.Errorf("session on %s takes %d people but its room holds %d: %w", date.Format("2006-01-02"), capacity, roomCapacity,
+		classes.ErrCapacityAboveRoom)
+}
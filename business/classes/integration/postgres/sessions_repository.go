@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/jackc/pgx/v5"
+)
+
+const sessionColumns = `id, class_id, session_date, starts_at, ends_at, status, created_at, updated_at, capacity, instructor_id, room_id, notes, cancelled_at, cancel_reason`
+
+func scanSession(row pgx.Row) (classes.Session, error) {
+	var session classes.Session
+	err := row.Scan(&session.ID, &session.ClassID, &session.Date, &session.StartsAt, &session.EndsAt, &session.Status,
+		&session.CreatedAt, &session.UpdatedAt, &session.Capacity, &session.InstructorID, &session.RoomID, &session.Notes,
+		&session.CancelledAt, &session.CancelReason)
+	if err != nil {
+		return classes.Session{}, fmt.Errorf("failed to scan class_sessions row to classes.Session: %w", err)
+	}
+	return session, nil
+}
+
+// EnsureSession stores the session unless the class already has a session on its date, and
+// returns the stored one.
+func (r *ClassesRepository) EnsureSession(ctx context.Context, session classes.Session) (classes.Session, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return classes.Session{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	insertSession := `INSERT INTO class_sessions (class_id, session_date, starts_at, ends_at)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (class_id, session_date) DO NOTHING`
+	_, err = txn.Exec(ctx, insertSession, session.ClassID, session.Date, session.StartsAt, session.EndsAt)
+	if err != nil {
+		return classes.Session{}, fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	query := `SELECT ` + sessionColumns + ` FROM class_sessions WHERE class_id = $1 AND session_date = $2`
+	storedSession, err := scanSession(txn.QueryRow(ctx, query, session.ClassID, session.Date))
+	if err != nil {
+		return classes.Session{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return classes.Session{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedSession, nil
+}
+
+func (r *ClassesRepository) ListSessions(ctx context.Context, classID string, from time.Time, to time.Time) ([]classes.Session, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + sessionColumns + `
+				FROM class_sessions
+			  WHERE class_id = $1 AND session_date BETWEEN $2 AND $3
+			  ORDER BY session_date`
+	rows, err := txn.Query(ctx, query, classID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+
+	sessions := make([]classes.Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return sessions, nil
+}
+
+// ListClassesSessions lists the stored sessions of the classes from one date to another.
+func (r *ClassesRepository) ListClassesSessions(ctx context.Context, classIDs []string, from time.Time, to time.Time) ([]classes.Session, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + sessionColumns + `
+				FROM class_sessions
+			  WHERE class_id = ANY($1) AND session_date BETWEEN $2 AND $3
+			  ORDER BY class_id, session_date`
+	rows, err := txn.Query(ctx, query, classIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+
+	sessions := make([]classes.Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return sessions, nil
+}
+
+// UpdateSession stores the session overrides. A capacity override can't go below the spots taken
+// by the active bookings and holds of the session, guests included, nor above the capacity of the
+// session room. An instructor or room can't be assigned to a session overlapping another session
+// they teach or taking place in it.
+func (r *ClassesRepository) UpdateSession(ctx context.Context, classID string, date time.Time, updateSession classes.UpdateSession) (classes.Session, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return classes.Session{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	assignsInstructor := updateSession.InstructorID != nil && *updateSession.InstructorID != ""
+	assignsRoom := updateSession.RoomID != nil && *updateSession.RoomID != ""
+	if assignsInstructor || assignsRoom {
+		// Class updates lock the class, then the instructor, the room and the sessions, so
+		// assignments lock them in the same order.
+		lockClass := `SELECT id FROM classes WHERE id = $1 FOR UPDATE`
+		if _, err := txn.Exec(ctx, lockClass, classID); err != nil {
+			return classes.Session{}, fmt.Errorf("failed to lock class: %w", err)
+		}
+	}
+
+	var sessionID string
+	lockSession := `SELECT id FROM class_sessions WHERE class_id = $1 AND session_date = $2 FOR UPDATE`
+	err = txn.QueryRow(ctx, lockSession, classID, date).Scan(&sessionID)
+	if err != nil {
+		return classes.Session{}, fmt.Errorf("failed to lock session: %w", err)
+	}
+
+	values := make([]interface{}, 0)
+	columns := make([]string, 0)
+
+	if updateSession.Capacity != nil {
+		// Sessions leaving their own capacity go back to the class capacity, which has to fit their
+		// bookings as well.
+		var sessionCapacity *int
+		if *updateSession.Capacity != 0 {
+			sessionCapacity = updateSession.Capacity
+		}
+
+		var capacity, booked int
+		countBookings := `SELECT COALESCE($4::int, (SELECT capacity FROM classes WHERE id = $2)),
+						(SELECT COALESCE(SUM(1 + guests), 0) FROM bookings WHERE session_id = $1 AND status IN ('booked', 'attended', 'no_show'))
+						+ (SELECT COALESCE(SUM(1 + guests), 0) FROM booking_holds
+						   WHERE class_id = $2 AND class_date = $3 AND status = 'held' AND expires_at > now())`
+		err = txn.QueryRow(ctx, countBookings, sessionID, classID, date, sessionCapacity).Scan(&capacity, &booked)
+		if err != nil {
+			return classes.Session{}, fmt.Errorf("failed to count session bookings: %w", err)
+		}
+
+		if capacity < booked {
+			return classes.Session{}, fmt.Errorf("session has %d bookings: %w", booked, classes.ErrCapacityBelowBookings)
+		}
+
+		values = append(values, sessionCapacity)
+		columns = append(columns, "capacity")
+	}
+
+	if updateSession.InstructorID != nil {
+		var instructorID *string
+		if assignsInstructor {
+			if err := r.lockInstructorTxn(ctx, txn, *updateSession.InstructorID); err != nil {
+				return classes.Session{}, err
+			}
+			instructorID = updateSession.InstructorID
+		}
+		values = append(values, instructorID)
+		columns = append(columns, "instructor_id")
+	}
+
+	if updateSession.RoomID != nil {
+		var roomID *string
+		if assignsRoom {
+			if _, err := r.lockRoomTxn(ctx, txn, *updateSession.RoomID); err != nil {
+				return classes.Session{}, err
+			}
+			roomID = updateSession.RoomID
+		}
+		values = append(values, roomID)
+		columns = append(columns, "room_id")
+	}
+
+	if updateSession.Notes != nil {
+		var notes *string
+		if *updateSession.Notes != "" {
+			notes = updateSession.Notes
+		}
+		values = append(values, notes)
+		columns = append(columns, "notes")
+	}
+
+	updateStatements := make([]string, 0)
+	for index := range columns {
+		updateStatements = append(updateStatements, fmt.Sprintf("%s = $%d", columns[index], index+1))
+	}
+	updateStatements = append(updateStatements, "updated_at = now()")
+
+	values = append(values, sessionID)
+	statement := "UPDATE class_sessions SET " + strings.Join(updateStatements, ", ") + fmt.Sprintf(" WHERE id = $%d", len(values)) +
+		" RETURNING " + sessionColumns
+
+	session, err := scanSession(txn.QueryRow(ctx, statement, values...))
+	if err != nil {
+		return classes.Session{}, err
+	}
+
+	if assignsInstructor {
+		if err := r.checkInstructorConflictsTxn(ctx, txn, *updateSession.InstructorID, classID, &date); err != nil {
+			return classes.Session{}, err
+		}
+	}
+
+	if updateSession.RoomID != nil {
+		// Sessions leaving their own room go back to the class room, which can be taken as well.
+		roomID := updateSession.RoomID
+		if !assignsRoom {
+			classRoom := `SELECT room_id FROM classes WHERE id = $1`
+			if err := txn.QueryRow(ctx, classRoom, classID).Scan(&roomID); err != nil {
+				return classes.Session{}, fmt.Errorf("failed to get class room: %w", err)
+			}
+		}
+
+		if roomID != nil {
+			if err := r.checkRoomConflictsTxn(ctx, txn, *roomID, classID, &date); err != nil {
+				return classes.Session{}, err
+			}
+		}
+	}
+
+	if updateSession.RoomID != nil || updateSession.Capacity != nil {
+		if err := r.checkRoomCapacityTxn(ctx, txn, classID, &date); err != nil {
+			return classes.Session{}, err
+		}
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return classes.Session{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return session, nil
+}
+
+// syncSessionsTxn regenerates the sessions of a class from its schedule. Existing sessions keep
+// their ID and overrides and only get their times updated. Sessions that dropped out of the
+// schedule are deleted, unless members booked them, in which case they are kept as unscheduled.
+func (r *ClassesRepository) syncSessionsTxn(ctx context.Context, txn pgx.Tx, class classes.Class) error {
+	sessions := class.Sessions(class.LocalDate(class.StartDate), class.LocalDate(class.EndDate))
+
+	dates := make([]time.Time, 0, len(sessions))
+	startsAt := make([]time.Time, 0, len(sessions))
+	endsAt := make([]time.Time, 0, len(sessions))
+	for _, session := range sessions {
+		dates = append(dates, session.Date)
+		startsAt = append(startsAt, session.StartsAt)
+		endsAt = append(endsAt, session.EndsAt)
+	}
+
+	upsertSessions := `INSERT INTO class_sessions (class_id, session_date, starts_at, ends_at)
+				SELECT $1, generated.session_date, generated.starts_at, generated.ends_at
+				FROM unnest($2::date[], $3::timestamptz[], $4::timestamptz[]) AS generated (session_date, starts_at, ends_at)
+				ON CONFLICT (class_id, session_date) DO UPDATE
+					SET starts_at  = EXCLUDED.starts_at,
+						ends_at    = EXCLUDED.ends_at,
+						status     = CASE WHEN class_sessions.status = $5 THEN $6 ELSE class_sessions.status END,
+						updated_at = now()`
+	_, err := txn.Exec(ctx, upsertSessions, class.ID, dates, startsAt, endsAt, classes.SessionStatusUnscheduled, classes.SessionStatusScheduled)
+	if err != nil {
+		return fmt.Errorf("failed to upsert class sessions: %w", err)
+	}
+
+	deleteSessions := `DELETE FROM class_sessions s
+				WHERE s.class_id = $1 AND NOT (s.session_date = ANY ($2::date[]))
+				AND NOT EXISTS (SELECT 1 FROM bookings b WHERE b.session_id = s.id)`
+	_, err = txn.Exec(ctx, deleteSessions, class.ID, dates)
+	if err != nil {
+		return fmt.Errorf("failed to delete unscheduled class sessions: %w", err)
+	}
+
+	unscheduleSessions := `UPDATE class_sessions SET status = $3, updated_at = now()
+				WHERE class_id = $1 AND NOT (session_date = ANY ($2::date[])) AND status = $4`
+	_, err = txn.Exec(ctx, unscheduleSessions, class.ID, dates, classes.SessionStatusUnscheduled, classes.SessionStatusScheduled)
+	if err != nil {
+		return fmt.Errorf("failed to unschedule booked class sessions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_AddClass_GeneratesSessions(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	// 2023-07-03 is a Monday.
+	startDate := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	class := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      uuid.NewString(),
+		StartDate: startDate,
+		EndDate:   startDate.AddDate(0, 0, 13),
+		Capacity:  20,
+		Recurrence: &classes.Recurrence{
+			Weekdays:        []classes.Weekday{classes.Monday, classes.Thursday},
+			StartTime:       "18:00",
+			DurationMinutes: 60,
+		},
+	}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	sessions, err := repo.ListSessions(ctx, class.ID, class.StartDate, class.EndDate)
+	require.NoError(t, err)
+	require.Len(t, sessions, 4)
+	assert.Equal(t, time.Date(2023, 7, 3, 18, 0, 0, 0, time.UTC), sessions[0].StartsAt)
+	assert.Equal(t, time.Date(2023, 7, 3, 19, 0, 0, 0, time.UTC), sessions[0].EndsAt)
+	assert.Equal(t, classes.SessionStatusScheduled, sessions[0].Status)
+}
+
+func TestRepository_UpdateClass_RegeneratesSessions(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	startDate := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	class := classes.Class{
+		ID:        uuid.NewString(),
+		Name:      uuid.NewString(),
+		StartDate: startDate,
+		EndDate:   startDate.AddDate(0, 0, 6),
+		Capacity:  20,
+		Recurrence: &classes.Recurrence{
+			Weekdays:        []classes.Weekday{classes.Monday, classes.Wednesday, classes.Friday},
+			StartTime:       "18:00",
+			DurationMinutes: 60,
+		},
+	}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	notes := "bring a mat"
+	monday, err := repo.UpdateSession(ctx, class.ID, startDate, classes.UpdateSession{Notes: &notes})
+	require.NoError(t, err)
+
+	wednesday := startDate.AddDate(0, 0, 2)
+	memberID := uuid.NewString()
+	_, err = db.Exec(ctx, `INSERT INTO members (id, name) VALUES ($1, $2)`, memberID, uuid.NewString())
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id)
+		SELECT $1, $2, class_id, session_date, id FROM class_sessions WHERE class_id = $3 AND session_date = $4`,
+		uuid.NewString(), memberID, class.ID, wednesday)
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{
+		Recurrence: &classes.Recurrence{
+			Weekdays:        []classes.Weekday{classes.Monday},
+			StartTime:       "07:00",
+			DurationMinutes: 45,
+		},
+	})
+	require.NoError(t, err)
+
+	sessions, err := repo.ListSessions(ctx, class.ID, class.StartDate, class.EndDate)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	assert.Equal(t, monday.ID, sessions[0].ID)
+	assert.Equal(t, &notes, sessions[0].Notes)
+	assert.Equal(t, time.Date(2023, 7, 3, 7, 0, 0, 0, time.UTC), sessions[0].StartsAt)
+	assert.Equal(t, classes.SessionStatusScheduled, sessions[0].Status)
+
+	assert.Equal(t, wednesday, sessions[1].Date)
+	assert.Equal(t, classes.SessionStatusUnscheduled, sessions[1].Status)
+}
+
+func TestRepository_UpdateSession_CapacityBelowBookings(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	startDate := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	class := classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: startDate, EndDate: startDate, Capacity: 20}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		memberID := uuid.NewString()
+		_, err = db.Exec(ctx, `INSERT INTO members (id, name) VALUES ($1, $2)`, memberID, uuid.NewString())
+		require.NoError(t, err)
+		_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id)
+			SELECT $1, $2, class_id, session_date, id FROM class_sessions WHERE class_id = $3 AND session_date = $4`,
+			uuid.NewString(), memberID, class.ID, startDate)
+		require.NoError(t, err)
+	}
+
+	capacity := 1
+	_, err = repo.UpdateSession(ctx, class.ID, startDate, classes.UpdateSession{Capacity: &capacity})
+	require.ErrorIs(t, err, classes.ErrCapacityBelowBookings)
+
+	capacity = 2
+	session, err := repo.UpdateSession(ctx, class.ID, startDate, classes.UpdateSession{Capacity: &capacity})
+	require.NoError(t, err)
+	assert.Equal(t, &capacity, session.Capacity)
+}
+
+func TestRepository_UpdateSession_ResetOverrides(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	startDate := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	class := classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: startDate, EndDate: startDate, Capacity: 1}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	capacity := 3
+	notes := "bring a mat"
+	session, err := repo.UpdateSession(ctx, class.ID, startDate, classes.UpdateSession{Capacity: &capacity, Notes: &notes})
+	require.NoError(t, err)
+	require.Equal(t, &capacity, session.Capacity)
+	require.Equal(t, &notes, session.Notes)
+
+	for i := 0; i < 2; i++ {
+		memberID := uuid.NewString()
+		_, err = db.Exec(ctx, `INSERT INTO members (id, name) VALUES ($1, $2)`, memberID, uuid.NewString())
+		require.NoError(t, err)
+		_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id) VALUES ($1, $2, $3, $4, $5)`,
+			uuid.NewString(), memberID, class.ID, startDate, session.ID)
+		require.NoError(t, err)
+	}
+
+	reset := 0
+	_, err = repo.UpdateSession(ctx, class.ID, startDate, classes.UpdateSession{Capacity: &reset})
+	require.ErrorIs(t, err, classes.ErrCapacityBelowBookings)
+
+	classCapacity := 2
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{Capability: &classCapacity})
+	require.NoError(t, err)
+
+	empty := ""
+	session, err = repo.UpdateSession(ctx, class.ID, startDate, classes.UpdateSession{Capacity: &reset, Notes: &empty})
+	require.NoError(t, err)
+	assert.Nil(t, session.Capacity)
+	assert.Nil(t, session.Notes)
+}
+
+func TestRepository_UpdateClass_CapacityBelowBookings(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	class := classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: startDate, EndDate: startDate.AddDate(0, 0, 1), Capacity: 20}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		memberID := uuid.NewString()
+		_, err = db.Exec(ctx, `INSERT INTO members (id, name) VALUES ($1, $2)`, memberID, uuid.NewString())
+		require.NoError(t, err)
+		_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id)
+			SELECT $1, $2, class_id, session_date, id FROM class_sessions WHERE class_id = $3 AND session_date = $4`,
+			uuid.NewString(), memberID, class.ID, startDate)
+		require.NoError(t, err)
+	}
+
+	capacity := 1
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{Capability: &capacity})
+	require.ErrorIs(t, err, classes.ErrCapacityBelowBookings)
+
+	sessionCapacity := 5
+	_, err = repo.UpdateSession(ctx, class.ID, startDate, classes.UpdateSession{Capacity: &sessionCapacity})
+	require.NoError(t, err)
+
+	updatedClass, err := repo.Update(ctx, class.ID, classes.UpdateClass{Capability: &capacity})
+	require.NoError(t, err)
+	assert.Equal(t, capacity, updatedClass.Capacity)
+}
+
+func TestRepository_UpdateClass_BookingsOutsideSchedule(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	lastDate := startDate.AddDate(0, 0, 6)
+	class := classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: startDate, EndDate: lastDate, Capacity: 20}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	memberID := uuid.NewString()
+	_, err = db.Exec(ctx, `INSERT INTO members (id, name) VALUES ($1, $2)`, memberID, uuid.NewString())
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id)
+		SELECT $1, $2, class_id, session_date, id FROM class_sessions WHERE class_id = $3 AND session_date = $4`,
+		uuid.NewString(), memberID, class.ID, lastDate)
+	require.NoError(t, err)
+
+	newEndDate := startDate.AddDate(0, 0, 2)
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{EndDate: &newEndDate})
+	require.ErrorIs(t, err, classes.ErrBookingsOutsideSchedule)
+
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{EndDate: &newEndDate, Force: classes.OrphanedBookingsKeep})
+	require.NoError(t, err)
+
+	sessions, err := repo.ListSessions(ctx, class.ID, startDate, lastDate)
+	require.NoError(t, err)
+	require.Len(t, sessions, 4)
+	assert.Equal(t, lastDate, sessions[3].Date)
+	assert.Equal(t, classes.SessionStatusUnscheduled, sessions[3].Status)
+}
+
+func TestRepository_UpdateClass_CancelOrphanedBookings(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	lastDate := startDate.AddDate(0, 0, 6)
+	class := classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: startDate, EndDate: lastDate, Capacity: 20}
+	_, err := repo.Add(ctx, class)
+	require.NoError(t, err)
+
+	memberID := uuid.NewString()
+	bookingID := uuid.NewString()
+	_, err = db.Exec(ctx, `INSERT INTO members (id, name) VALUES ($1, $2)`, memberID, uuid.NewString())
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO bookings (id, member_id, class_id, class_date, session_id)
+		SELECT $1, $2, class_id, session_date, id FROM class_sessions WHERE class_id = $3 AND session_date = $4`,
+		bookingID, memberID, class.ID, lastDate)
+	require.NoError(t, err)
+
+	newEndDate := startDate.AddDate(0, 0, 2)
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{EndDate: &newEndDate, Force: classes.OrphanedBookingsCancel})
+	require.NoError(t, err)
+
+	sessions, err := repo.ListSessions(ctx, class.ID, startDate, lastDate)
+	require.NoError(t, err)
+	require.Len(t, sessions, 4)
+	assert.Equal(t, lastDate, sessions[3].Date)
+	assert.Equal(t, classes.SessionStatusCancelled, sessions[3].Status)
+
+	var status string
+	err = db.QueryRow(ctx, `SELECT status FROM bookings WHERE id = $1`, bookingID).Scan(&status)
+	require.NoError(t, err)
+	assert.Equal(t, "studio_cancelled", status)
+
+	var events int
+	err = db.QueryRow(ctx, `SELECT COUNT(*) FROM events WHERE member_id = $1 AND type = 'session_cancelled'`, memberID).Scan(&events)
+	require.NoError(t, err)
+	assert.Equal(t, 1, events)
+}
+
+func TestRepository_UpdateClass_RevalidatesLockedClass(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewClassesRepository(logger.Sugar(), db)
+
+	now := time.Now().UTC()
+	class, err := repo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now.AddDate(0, 0, 7), Capacity: 20})
+	require.NoError(t, err)
+
+	// Another update moved the start date meanwhile, so the end date the usecase validated
+	// against the class it read ends up before the start.
+	startDate := now.AddDate(0, 0, 5)
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{StartDate: &startDate})
+	require.NoError(t, err)
+
+	endDate := now.AddDate(0, 0, 2)
+	_, err = repo.Update(ctx, class.ID, classes.UpdateClass{EndDate: &endDate})
+	require.ErrorIs(t, err, classes.ErrInvalidData)
+
+	classFound, err := repo.GetByID(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Equal(t, class.EndDate.Unix(), classFound.EndDate.Unix())
+}
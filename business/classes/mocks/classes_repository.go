@@ -8,6 +8,8 @@ import (
 	classes "github.com/daniel-oliveiravas/class-booking-service/business/classes"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -39,6 +41,30 @@ func (_m *Repository) Add(ctx context.Context, class classes.Class) (classes.Cla
 	return r0, r1
 }
 
+// Archive provides a mock function with given fields: ctx, classID
+func (_m *Repository) Archive(ctx context.Context, classID string) (classes.Class, error) {
+	ret := _m.Called(ctx, classID)
+
+	var r0 classes.Class
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (classes.Class, error)); ok {
+		return rf(ctx, classID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) classes.Class); ok {
+		r0 = rf(ctx, classID)
+	} else {
+		r0 = ret.Get(0).(classes.Class)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, classID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, classID
 func (_m *Repository) Delete(ctx context.Context, classID string) error {
 	ret := _m.Called(ctx, classID)
@@ -53,6 +79,30 @@ func (_m *Repository) Delete(ctx context.Context, classID string) error {
 	return r0
 }
 
+// EnsureSession provides a mock function with given fields: ctx, session
+func (_m *Repository) EnsureSession(ctx context.Context, session classes.Session) (classes.Session, error) {
+	ret := _m.Called(ctx, session)
+
+	var r0 classes.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, classes.Session) (classes.Session, error)); ok {
+		return rf(ctx, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, classes.Session) classes.Session); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Get(0).(classes.Session)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, classes.Session) error); ok {
+		r1 = rf(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, classID
 func (_m *Repository) GetByID(ctx context.Context, classID string) (classes.Class, error) {
 	ret := _m.Called(ctx, classID)
@@ -77,6 +127,54 @@ func (_m *Repository) GetByID(ctx context.Context, classID string) (classes.Clas
 	return r0, r1
 }
 
+// GetByIDIncludingDeleted provides a mock function with given fields: ctx, classID
+func (_m *Repository) GetByIDIncludingDeleted(ctx context.Context, classID string) (classes.Class, error) {
+	ret := _m.Called(ctx, classID)
+
+	var r0 classes.Class
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (classes.Class, error)); ok {
+		return rf(ctx, classID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) classes.Class); ok {
+		r0 = rf(ctx, classID)
+	} else {
+		r0 = ret.Get(0).(classes.Class)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, classID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoomTimezone provides a mock function with given fields: ctx, roomID
+func (_m *Repository) GetRoomTimezone(ctx context.Context, roomID string) (string, error) {
+	ret := _m.Called(ctx, roomID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, roomID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, roomID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roomID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsNotFoundErr provides a mock function with given fields: err
 func (_m *Repository) IsNotFoundErr(err error) bool {
 	ret := _m.Called(err)
@@ -91,25 +189,127 @@ func (_m *Repository) IsNotFoundErr(err error) bool {
 	return r0
 }
 
-// List provides a mock function with given fields: ctx, limit, offset
-func (_m *Repository) List(ctx context.Context, limit int, offset int) ([]classes.Class, error) {
-	ret := _m.Called(ctx, limit, offset)
+// List provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *Repository) List(ctx context.Context, filter classes.Filter, limit int, offset int) ([]classes.Class, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
 
 	var r0 []classes.Class
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]classes.Class, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, classes.Filter, int, int) ([]classes.Class, error)); ok {
+		return rf(ctx, filter, limit, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []classes.Class); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, classes.Filter, int, int) []classes.Class); ok {
+		r0 = rf(ctx, filter, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]classes.Class)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, classes.Filter, int, int) error); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClassesSessions provides a mock function with given fields: ctx, classIDs, from, to
+func (_m *Repository) ListClassesSessions(ctx context.Context, classIDs []string, from time.Time, to time.Time) ([]classes.Session, error) {
+	ret := _m.Called(ctx, classIDs, from, to)
+
+	var r0 []classes.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) ([]classes.Session, error)); ok {
+		return rf(ctx, classIDs, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) []classes.Session); ok {
+		r0 = rf(ctx, classIDs, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]classes.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, classIDs, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoomIDs provides a mock function with given fields: ctx, locationID
+func (_m *Repository) ListRoomIDs(ctx context.Context, locationID string) ([]string, error) {
+	ret := _m.Called(ctx, locationID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, locationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSessions provides a mock function with given fields: ctx, classID, from, to
+func (_m *Repository) ListSessions(ctx context.Context, classID string, from time.Time, to time.Time) ([]classes.Session, error) {
+	ret := _m.Called(ctx, classID, from, to)
+
+	var r0 []classes.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]classes.Session, error)); ok {
+		return rf(ctx, classID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []classes.Session); ok {
+		r0 = rf(ctx, classID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]classes.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, classID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, query, limit, offset
+func (_m *Repository) Search(ctx context.Context, query string, limit int, offset int) (classes.SearchResults, error) {
+	ret := _m.Called(ctx, query, limit, offset)
+
+	var r0 classes.SearchResults
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (classes.SearchResults, error)); ok {
+		return rf(ctx, query, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) classes.SearchResults); ok {
+		r0 = rf(ctx, query, limit, offset)
+	} else {
+		r0 = ret.Get(0).(classes.SearchResults)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, query, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -141,6 +341,30 @@ func (_m *Repository) Update(ctx context.Context, classID string, updateClass cl
 	return r0, r1
 }
 
+// UpdateSession provides a mock function with given fields: ctx, classID, date, updateSession
+func (_m *Repository) UpdateSession(ctx context.Context, classID string, date time.Time, updateSession classes.UpdateSession) (classes.Session, error) {
+	ret := _m.Called(ctx, classID, date, updateSession)
+
+	var r0 classes.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, classes.UpdateSession) (classes.Session, error)); ok {
+		return rf(ctx, classID, date, updateSession)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, classes.UpdateSession) classes.Session); ok {
+		r0 = rf(ctx, classID, date, updateSession)
+	} else {
+		r0 = ret.Get(0).(classes.Session)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, classes.UpdateSession) error); ok {
+		r1 = rf(ctx, classID, date, updateSession)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
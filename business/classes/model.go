@@ -1,6 +1,7 @@
 package classes
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -12,13 +13,267 @@ type Class struct {
 	StartDate time.Time `json:"startDate"`
 	EndDate   time.Time `json:"endDate"`
 	Capacity  int       `json:"capacity,omitempty"`
+	// Description, Category, Tags, Level, DurationMinutes and ImageURL describe the class to the
+	// members browsing classes. Categories and tags are lowercase.
+	Description     string   `json:"description,omitempty"`
+	Category        string   `json:"category,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	Level           Level    `json:"level,omitempty"`
+	DurationMinutes int      `json:"durationMinutes,omitempty"`
+	ImageURL        string   `json:"imageURL,omitempty"`
+	// CancellationPolicy overrides the studio-wide cancellation policy for this class when set.
+	CancellationPolicy *CancellationPolicy `json:"cancellationPolicy,omitempty"`
+	// Recurrence restricts the dates and times of day the class runs on. Classes without a
+	// recurrence run every day of their range.
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	// Version counts the updates of the class.
+	Version int `json:"version,omitempty"`
+	// InstructorID is the instructor teaching the sessions of the class that don't name an
+	// instructor of their own.
+	InstructorID *string `json:"instructorID,omitempty"`
+	// RoomID is the room the sessions of the class that don't name a room of their own are taught
+	// in. The class capacity can't exceed the room capacity.
+	RoomID *string `json:"roomID,omitempty"`
+	// Timezone is the IANA name of the timezone the class runs in. Class dates and the times of day
+	// sessions start and end at are in this timezone.
+	Timezone string `json:"timezone,omitempty"`
+	// ArchivedAt is when the class was archived. Archived classes are hidden from listings and
+	// can't be booked anymore.
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+}
+
+// Location returns the timezone the class runs in. Classes without a timezone run in UTC. Class
+// timezones are checked when classes are stored and loaded, so an unknown timezone is a bug and
+// Location panics rather than running the class in the wrong timezone.
+func (c Class) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		panic(fmt.Sprintf("class %s has unknown timezone %q: %v", c.ID, c.Timezone, err))
+	}
+
+	return loc
+}
+
+// LocalDate returns the calendar date of the instant t in the timezone of the class.
+func (c Class) LocalDate(t time.Time) time.Time {
+	return dateOf(t.In(c.Location()))
+}
+
+// CalendarDate returns the calendar date of the class the class date t stands for. Dates without
+// a time of day, at midnight of their own offset like 2023-03-12T00:00:00Z, already are calendar
+// dates of the class, while instants with a time of day are taken in the timezone of the class.
+func (c Class) CalendarDate(t time.Time) time.Time {
+	if IsDate(t) {
+		return dateOf(t)
+	}
+
+	return c.LocalDate(t)
+}
+
+// IsDate tells whether t is a date without a time of day, at midnight of its own offset.
+func IsDate(t time.Time) bool {
+	return t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+}
+
+// OccursOn tells whether the class runs on the given calendar date.
+func (c Class) OccursOn(date time.Time) bool {
+	day := dateOf(date)
+	if day.Before(c.LocalDate(c.StartDate)) || day.After(c.LocalDate(c.EndDate)) {
+		return false
+	}
+
+	if c.Recurrence == nil {
+		return true
+	}
+
+	return c.Recurrence.occursOn(c.StartDate.In(c.Location()), date)
+}
+
+// SessionStart returns when the class starts on the given calendar date, at the recurrence start
+// time or otherwise at the time of day of StartDate, both in the timezone of the class.
+func (c Class) SessionStart(date time.Time) time.Time {
+	loc := c.Location()
+	if c.Recurrence != nil {
+		return c.Recurrence.start(date, loc)
+	}
+
+	return atTimeOfDay(date, c.StartDate.In(loc))
+}
+
+// SessionEnd returns when the class ends on the given calendar date, after the recurrence duration
+// or otherwise at the time of day of EndDate in the timezone of the class. Classes without a
+// recurrence ending at or before their starting time of day run until the end of the date.
+func (c Class) SessionEnd(date time.Time) time.Time {
+	loc := c.Location()
+	start := c.SessionStart(date)
+	if c.Recurrence != nil {
+		return start.Add(c.Recurrence.duration())
+	}
+
+	end := atTimeOfDay(date, c.EndDate.In(loc))
+	if !end.After(start) {
+		return time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, loc)
+	}
+
+	return end
+}
+
+// Session returns the session of the class on the given date, without any overrides.
+func (c Class) Session(date time.Time) Session {
+	return Session{
+		ClassID:  c.ID,
+		Date:     dateOf(date),
+		StartsAt: c.SessionStart(date),
+		EndsAt:   c.SessionEnd(date),
+		Status:   SessionStatusScheduled,
+	}
+}
+
+// Sessions returns the sessions of the class from one calendar date to another, both inclusive.
+func (c Class) Sessions(from time.Time, to time.Time) []Session {
+	first := dateOf(from)
+	if start := c.LocalDate(c.StartDate); first.Before(start) {
+		first = start
+	}
+
+	last := dateOf(to)
+	if end := c.LocalDate(c.EndDate); last.After(end) {
+		last = end
+	}
+
+	sessions := make([]Session, 0)
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		if c.OccursOn(date) {
+			sessions = append(sessions, c.Session(date))
+		}
+	}
+
+	return sessions
+}
+
+func atTimeOfDay(date time.Time, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, clock.Location())
+}
+
+// Level is how much experience a class asks of its members.
+type Level string
+
+const (
+	LevelBeginner     Level = "beginner"
+	LevelIntermediate Level = "intermediate"
+	LevelAdvanced     Level = "advanced"
+	LevelAll          Level = "all"
+)
+
+func (l Level) Valid() bool {
+	switch l {
+	case LevelBeginner, LevelIntermediate, LevelAdvanced, LevelAll:
+		return true
+	}
+	return false
+}
+
+type Penalty string
+
+const (
+	PenaltyNone   Penalty = "none"
+	PenaltyStrike Penalty = "strike"
+	PenaltyCredit Penalty = "credit"
+)
+
+func (p Penalty) Valid() bool {
+	switch p {
+	case PenaltyNone, PenaltyStrike, PenaltyCredit:
+		return true
+	}
+	return false
+}
+
+// CancellationPolicy defines until when a booking can be cancelled for free and what a late
+// cancellation costs the member.
+type CancellationPolicy struct {
+	// FreeCancelCutoffMinutes is how many minutes before the session starts cancelling stops being free.
+	FreeCancelCutoffMinutes int     `json:"freeCancelCutoffMinutes"`
+	LateCancelPenalty       Penalty `json:"lateCancelPenalty"`
+}
+
+// IsEmpty tells whether the policy sets nothing, which class updates take as clearing the class policy.
+func (p CancellationPolicy) IsEmpty() bool {
+	return p == CancellationPolicy{}
+}
+
+// FreeCancelUntil returns the last moment a booking starting at sessionStart can be cancelled for free.
+func (p CancellationPolicy) FreeCancelUntil(sessionStart time.Time) time.Time {
+	return sessionStart.Add(-time.Duration(p.FreeCancelCutoffMinutes) * time.Minute)
+}
+
+type SessionStatus string
+
+const (
+	SessionStatusScheduled SessionStatus = "scheduled"
+	// SessionStatusUnscheduled marks sessions that dropped out of the class schedule but are kept
+	// because members booked them.
+	SessionStatusUnscheduled SessionStatus = "unscheduled"
+	// SessionStatusCancelled marks sessions the studio cancelled. Cancelled sessions can't be booked.
+	SessionStatusCancelled SessionStatus = "cancelled"
+)
+
+// Session is a single occurrence of a class. Sessions are generated from the class schedule and
+// can override the class capacity, instructor, room and notes.
+type Session struct {
+	ID        string        `json:"ID,omitempty"`
+	ClassID   string        `json:"classID,omitempty"`
+	Date      time.Time     `json:"date"`
+	StartsAt  time.Time     `json:"startsAt"`
+	EndsAt    time.Time     `json:"endsAt"`
+	Status    SessionStatus `json:"status,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	// Capacity overrides the class capacity for this session when set.
+	Capacity     *int       `json:"capacity,omitempty"`
+	InstructorID *string    `json:"instructorID,omitempty"`
+	RoomID       *string    `json:"roomID,omitempty"`
+	Notes        *string    `json:"notes,omitempty"`
+	CancelledAt  *time.Time `json:"cancelledAt,omitempty"`
+	CancelReason *string    `json:"cancelReason,omitempty"`
+}
+
+// UpdateSession changes the overrides of a session. Set fields with empty values reset their
+// override, so the session follows the class again.
+type UpdateSession struct {
+	// Capacity changes the capacity of the session. A zero capacity leaves the session to the
+	// class capacity.
+	Capacity *int `json:"capacity,omitempty"`
+	// InstructorID changes the instructor of the session. An empty instructor ID leaves the
+	// session to the class instructor.
+	InstructorID *string `json:"instructorID,omitempty"`
+	// RoomID changes the room of the session. An empty room ID leaves the session in the class room.
+	RoomID *string `json:"roomID,omitempty"`
+	// Notes changes the notes of the session. Empty notes clear them.
+	Notes *string `json:"notes,omitempty"`
 }
 
 type NewClass struct {
-	Name      string    `json:"name,omitempty"`
-	StartDate time.Time `json:"startDate"`
-	EndDate   time.Time `json:"endDate"`
-	Capacity  int       `json:"capacity,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	StartDate          time.Time           `json:"startDate"`
+	EndDate            time.Time           `json:"endDate"`
+	Capacity           int                 `json:"capacity,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	Category           string              `json:"category,omitempty"`
+	Tags               []string            `json:"tags,omitempty"`
+	Level              Level               `json:"level,omitempty"`
+	DurationMinutes    int                 `json:"durationMinutes,omitempty"`
+	ImageURL           string              `json:"imageURL,omitempty"`
+	CancellationPolicy *CancellationPolicy `json:"cancellationPolicy,omitempty"`
+	Recurrence         *Recurrence         `json:"recurrence,omitempty"`
+	InstructorID       *string             `json:"instructorID,omitempty"`
+	RoomID             *string             `json:"roomID,omitempty"`
+	// Timezone defaults to the timezone of the location of the class room, or UTC without a room.
+	Timezone string `json:"timezone,omitempty"`
 }
 
 type UpdateClass struct {
@@ -26,6 +281,146 @@ type UpdateClass struct {
 	StartDate  *time.Time `json:"startDate,omitempty"`
 	EndDate    *time.Time `json:"endDate,omitempty"`
 	Capability *int       `json:"capability,omitempty"`
+
+	// Description, Category, Level and ImageURL are cleared when set empty, as are Tags and
+	// DurationMinutes when set to no tags and zero minutes.
+	Description     *string   `json:"description,omitempty"`
+	Category        *string   `json:"category,omitempty"`
+	Tags            *[]string `json:"tags,omitempty"`
+	Level           *Level    `json:"level,omitempty"`
+	DurationMinutes *int      `json:"durationMinutes,omitempty"`
+	ImageURL        *string   `json:"imageURL,omitempty"`
+
+	// CancellationPolicy changes the cancellation policy of the class. An empty policy clears it,
+	// so the class follows the studio-wide policy again.
+	CancellationPolicy *CancellationPolicy `json:"cancellationPolicy,omitempty"`
+	Recurrence         *Recurrence         `json:"recurrence,omitempty"`
+	// InstructorID changes the instructor of the class. An empty instructor ID unassigns the
+	// class instructor.
+	InstructorID *string `json:"instructorID,omitempty"`
+	// RoomID changes the room of the class. An empty room ID unassigns the class room.
+	RoomID *string `json:"roomID,omitempty"`
+	// Timezone changes the timezone the class runs in.
+	Timezone *string `json:"timezone,omitempty"`
+
+	// Force says what to do with the upcoming bookings on dates the update drops from the class
+	// schedule. Such updates fail with ErrBookingsOutsideSchedule without it.
+	Force OrphanedBookings `json:"force,omitempty"`
+
+	// IfVersions makes the update fail with ErrVersionMismatch unless the class is still at one of
+	// these versions. The update is unconditional without them.
+	IfVersions []int `json:"-"`
+}
+
+// MatchesVersion tells whether the update applies to the class at the version.
+func (u UpdateClass) MatchesVersion(version int) bool {
+	if u.IfVersions == nil {
+		return true
+	}
+
+	for _, ifVersion := range u.IfVersions {
+		if ifVersion == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply returns the class with the fields the update sets.
+func (u UpdateClass) apply(class Class) Class {
+	if u.Name != nil {
+		class.Name = *u.Name
+	}
+
+	if u.StartDate != nil {
+		class.StartDate = *u.StartDate
+	}
+
+	if u.EndDate != nil {
+		class.EndDate = *u.EndDate
+	}
+
+	if u.Capability != nil {
+		class.Capacity = *u.Capability
+	}
+
+	if u.Description != nil {
+		class.Description = *u.Description
+	}
+
+	if u.Category != nil {
+		class.Category = *u.Category
+	}
+
+	if u.Tags != nil {
+		class.Tags = *u.Tags
+	}
+
+	if u.Level != nil {
+		class.Level = *u.Level
+	}
+
+	if u.DurationMinutes != nil {
+		class.DurationMinutes = *u.DurationMinutes
+	}
+
+	if u.ImageURL != nil {
+		class.ImageURL = *u.ImageURL
+	}
+
+	if u.CancellationPolicy != nil {
+		class.CancellationPolicy = u.CancellationPolicy
+		if u.CancellationPolicy.IsEmpty() {
+			class.CancellationPolicy = nil
+		}
+	}
+
+	if u.Recurrence != nil {
+		class.Recurrence = u.Recurrence
+	}
+
+	if u.Timezone != nil {
+		class.Timezone = *u.Timezone
+	}
+
+	return class
+}
+
+// ChangesSchedule tells whether the update changes the dates the class runs on.
+func (u UpdateClass) ChangesSchedule() bool {
+	return u.StartDate != nil || u.EndDate != nil || u.Recurrence != nil || u.Timezone != nil
+}
+
+// OrphanedBookings is what a class update does with the upcoming bookings on dates it drops from
+// the class schedule.
+type OrphanedBookings string
+
+const (
+	// OrphanedBookingsKeep keeps the bookings on their sessions, which become unscheduled.
+	OrphanedBookingsKeep OrphanedBookings = "keep"
+	// OrphanedBookingsCancel cancels the sessions the bookings are on along with the update, as
+	// the studio would.
+	OrphanedBookingsCancel OrphanedBookings = "cancel"
+)
+
+func (o OrphanedBookings) Valid() bool {
+	switch o {
+	case OrphanedBookingsKeep, OrphanedBookingsCancel:
+		return true
+	}
+	return false
+}
+
+// Filter narrows down the classes listed. Empty fields don't filter.
+type Filter struct {
+	// LocationID lists the classes taught in the rooms of the location, either in the class room or
+	// in the room of one of their sessions.
+	LocationID string
+	Category   string
+	// Tag lists the classes tagged with it.
+	Tag   string
+	Level Level
 }
 
 type PageInfo struct {
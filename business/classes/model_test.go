@@ -0,0 +1,273 @@
+package classes_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClass_Session(t *testing.T) {
+	tests := []struct {
+		name      string
+		class     classes.Class
+		date      time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name: "time_of_day_from_start_and_end_dates",
+			class: classes.Class{
+				StartDate: time.Date(2023, 6, 1, 18, 30, 0, 0, time.UTC),
+				EndDate:   time.Date(2023, 8, 31, 19, 30, 0, 0, time.UTC),
+			},
+			date:      time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2023, 7, 10, 18, 30, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 7, 10, 19, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "whole_day_when_end_is_not_after_start",
+			class: classes.Class{
+				StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC),
+			},
+			date:      time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 7, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "start_time_and_duration_from_recurrence",
+			class: classes.Class{
+				StartDate:  time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:    time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC),
+				Recurrence: &classes.Recurrence{StartTime: "07:15", DurationMinutes: 45},
+			},
+			date:      time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2023, 7, 10, 7, 15, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 7, 10, 8, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStart, tt.class.SessionStart(tt.date))
+			assert.Equal(t, tt.wantEnd, tt.class.SessionEnd(tt.date))
+		})
+	}
+}
+
+func TestClass_OccursOn(t *testing.T) {
+	// 2023-06-05 is a Monday.
+	startDate := time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		recurrence *classes.Recurrence
+		date       time.Time
+		want       bool
+	}{
+		{
+			name: "every_date_without_recurrence",
+			date: time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "outside_class_range",
+			date: time.Date(2023, 9, 4, 0, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name:       "weekly_on_matching_weekday",
+			recurrence: &classes.Recurrence{Weekdays: []classes.Weekday{classes.Monday, classes.Wednesday}},
+			date:       time.Date(2023, 6, 14, 18, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "weekly_on_other_weekday",
+			recurrence: &classes.Recurrence{Weekdays: []classes.Weekday{classes.Monday, classes.Wednesday}},
+			date:       time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC),
+			want:       false,
+		},
+		{
+			name:       "weekly_defaults_to_start_weekday",
+			recurrence: &classes.Recurrence{Frequency: classes.FrequencyWeekly},
+			date:       time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "every_other_week_on_off_week",
+			recurrence: &classes.Recurrence{Interval: 2, Weekdays: []classes.Weekday{classes.Friday}},
+			date:       time.Date(2023, 6, 16, 0, 0, 0, 0, time.UTC),
+			want:       false,
+		},
+		{
+			name:       "every_other_week_on_matching_week",
+			recurrence: &classes.Recurrence{Interval: 2, Weekdays: []classes.Weekday{classes.Friday}},
+			date:       time.Date(2023, 6, 23, 0, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "every_third_day",
+			recurrence: &classes.Recurrence{Frequency: classes.FrequencyDaily, Interval: 3},
+			date:       time.Date(2023, 6, 8, 0, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "daily_restricted_to_weekdays",
+			recurrence: &classes.Recurrence{Frequency: classes.FrequencyDaily, Weekdays: []classes.Weekday{classes.Saturday, classes.Sunday}},
+			date:       time.Date(2023, 6, 9, 0, 0, 0, 0, time.UTC),
+			want:       false,
+		},
+		{
+			name: "exception_date",
+			recurrence: &classes.Recurrence{
+				Weekdays:       []classes.Weekday{classes.Monday},
+				ExceptionDates: []time.Time{time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC)},
+			},
+			date: time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := classes.Class{StartDate: startDate, EndDate: endDate, Recurrence: tt.recurrence}
+			assert.Equal(t, tt.want, class.OccursOn(tt.date))
+		})
+	}
+}
+
+func TestClass_Sessions(t *testing.T) {
+	// 2023-07-03 is a Monday.
+	class := classes.Class{
+		ID:        "class",
+		StartDate: time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 7, 20, 0, 0, 0, 0, time.UTC),
+		Recurrence: &classes.Recurrence{
+			Weekdays:        []classes.Weekday{classes.Monday, classes.Wednesday},
+			StartTime:       "06:30",
+			DurationMinutes: 90,
+		},
+	}
+
+	sessions := class.Sessions(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 7, 31, 0, 0, 0, 0, time.UTC))
+
+	dates := make([]time.Time, 0)
+	for _, session := range sessions {
+		assert.Equal(t, "class", session.ClassID)
+		assert.Equal(t, classes.SessionStatusScheduled, session.Status)
+		assert.Equal(t, time.Minute*90, session.EndsAt.Sub(session.StartsAt))
+		dates = append(dates, session.Date)
+	}
+	assert.Equal(t, []time.Time{
+		time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 7, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 7, 17, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 7, 19, 0, 0, 0, 0, time.UTC),
+	}, dates)
+}
+
+func TestClass_LocalDate(t *testing.T) {
+	class := classes.Class{Timezone: "America/Sao_Paulo"}
+
+	// 23:30 in Sao Paulo is 02:30 of the next day in UTC.
+	assert.Equal(t, time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC), class.LocalDate(time.Date(2023, 7, 6, 2, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2023, 7, 6, 0, 0, 0, 0, time.UTC), class.LocalDate(time.Date(2023, 7, 6, 3, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, time.Date(2023, 7, 6, 0, 0, 0, 0, time.UTC), classes.Class{}.LocalDate(time.Date(2023, 7, 6, 2, 30, 0, 0, time.UTC)))
+}
+
+func TestClass_CalendarDate(t *testing.T) {
+	class := classes.Class{Timezone: "America/New_York"}
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	assert.Equal(t, time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC), class.CalendarDate(time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC), class.CalendarDate(time.Date(2023, 3, 12, 0, 0, 0, 0, tokyo)))
+	// 01:00 UTC is still the evening before in New York.
+	assert.Equal(t, time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC), class.CalendarDate(time.Date(2023, 3, 12, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestClass_Location_UnknownTimezone(t *testing.T) {
+	class := classes.Class{ID: "yoga", Timezone: "America/Atlantis"}
+
+	assert.Panics(t, func() {
+		class.Location()
+	})
+}
+
+func TestClass_OccursOn_InClassTimezone(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	// The class starts on 2023-07-05 in Sao Paulo, which is already 2023-07-06 in UTC.
+	class := classes.Class{
+		StartDate: time.Date(2023, 7, 5, 22, 0, 0, 0, saoPaulo).UTC(),
+		EndDate:   time.Date(2023, 7, 7, 23, 0, 0, 0, saoPaulo).UTC(),
+		Timezone:  "America/Sao_Paulo",
+	}
+
+	assert.False(t, class.OccursOn(time.Date(2023, 7, 4, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, class.OccursOn(time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, class.OccursOn(time.Date(2023, 7, 7, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, class.OccursOn(time.Date(2023, 7, 8, 0, 0, 0, 0, time.UTC)))
+
+	session := class.Session(time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC))
+	assert.True(t, session.StartsAt.Equal(time.Date(2023, 7, 6, 1, 0, 0, 0, time.UTC)))
+	assert.True(t, session.EndsAt.Equal(time.Date(2023, 7, 6, 2, 0, 0, 0, time.UTC)))
+}
+
+func TestClass_Sessions_AcrossDSTTransitions(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		recurrence *classes.Recurrence
+	}{
+		{
+			name:       "recurrence start time",
+			recurrence: &classes.Recurrence{Frequency: classes.FrequencyDaily, StartTime: "06:30", DurationMinutes: 60},
+		},
+		{
+			name: "time of day of the class dates",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// New York springs forward on 2023-03-12 and falls back on 2023-11-05.
+			class := classes.Class{
+				StartDate:  time.Date(2023, 1, 2, 6, 30, 0, 0, newYork).UTC(),
+				EndDate:    time.Date(2023, 12, 29, 7, 30, 0, 0, newYork).UTC(),
+				Timezone:   "America/New_York",
+				Recurrence: tt.recurrence,
+			}
+
+			for _, test := range []struct {
+				date     time.Time
+				startsAt time.Time
+			}{
+				{date: time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC), startsAt: time.Date(2023, 3, 11, 11, 30, 0, 0, time.UTC)},
+				{date: time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC), startsAt: time.Date(2023, 3, 12, 10, 30, 0, 0, time.UTC)},
+				{date: time.Date(2023, 11, 4, 0, 0, 0, 0, time.UTC), startsAt: time.Date(2023, 11, 4, 10, 30, 0, 0, time.UTC)},
+				{date: time.Date(2023, 11, 5, 0, 0, 0, 0, time.UTC), startsAt: time.Date(2023, 11, 5, 11, 30, 0, 0, time.UTC)},
+			} {
+				sessions := class.Sessions(test.date, test.date)
+				require.Len(t, sessions, 1)
+
+				session := sessions[0]
+				assert.Equal(t, test.date, session.Date)
+				assert.True(t, session.StartsAt.Equal(test.startsAt), "session on %s starts at %s", test.date.Format("2006-01-02"), session.StartsAt.UTC())
+				assert.Equal(t, time.Hour, session.EndsAt.Sub(session.StartsAt))
+				assert.Equal(t, "06:30", session.StartsAt.In(newYork).Format("15:04"))
+			}
+		})
+	}
+}
+
+func TestUpdateClass_MatchesVersion(t *testing.T) {
+	assert.True(t, classes.UpdateClass{}.MatchesVersion(3))
+	assert.True(t, classes.UpdateClass{IfVersions: []int{2, 3}}.MatchesVersion(3))
+	assert.False(t, classes.UpdateClass{IfVersions: []int{2}}.MatchesVersion(3))
+}
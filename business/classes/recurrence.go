@@ -0,0 +1,146 @@
+package classes
+
+import (
+	"time"
+)
+
+// recurrenceTimeLayout is the layout of Recurrence.StartTime.
+const recurrenceTimeLayout = "15:04"
+
+type Frequency string
+
+const (
+	FrequencyDaily  Frequency = "DAILY"
+	FrequencyWeekly Frequency = "WEEKLY"
+)
+
+func (f Frequency) Valid() bool {
+	switch f {
+	case FrequencyDaily, FrequencyWeekly:
+		return true
+	}
+	return false
+}
+
+// Weekday is a day of the week as written in iCalendar BYDAY rules.
+type Weekday string
+
+const (
+	Monday    Weekday = "MO"
+	Tuesday   Weekday = "TU"
+	Wednesday Weekday = "WE"
+	Thursday  Weekday = "TH"
+	Friday    Weekday = "FR"
+	Saturday  Weekday = "SA"
+	Sunday    Weekday = "SU"
+)
+
+var weekdays = map[time.Weekday]Weekday{
+	time.Monday:    Monday,
+	time.Tuesday:   Tuesday,
+	time.Wednesday: Wednesday,
+	time.Thursday:  Thursday,
+	time.Friday:    Friday,
+	time.Saturday:  Saturday,
+	time.Sunday:    Sunday,
+}
+
+func (w Weekday) Valid() bool {
+	for _, weekday := range weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Recurrence defines on which dates of its range a class runs, following the iCalendar RRULE
+// FREQ, INTERVAL and BYDAY parts plus EXDATE exceptions. Weeks start on Monday.
+type Recurrence struct {
+	// Frequency is either DAILY or WEEKLY, defaulting to WEEKLY.
+	Frequency Frequency `json:"frequency,omitempty"`
+	// Interval is how many days or weeks there are between occurrences, defaulting to 1.
+	Interval int `json:"interval,omitempty"`
+	// Weekdays restricts occurrences to the given days of the week. Weekly recurrences without
+	// weekdays run on the weekday the class starts.
+	Weekdays []Weekday `json:"weekdays,omitempty"`
+	// StartTime is the time of day occurrences start at, formatted as HH:MM.
+	StartTime       string `json:"startTime"`
+	DurationMinutes int    `json:"durationMinutes"`
+	// ExceptionDates are dates the class doesn't run on even though the rule matches them.
+	ExceptionDates []time.Time `json:"exceptionDates,omitempty"`
+}
+
+// occursOn tells whether the recurrence of a series starting at seriesStart matches the given date.
+func (r Recurrence) occursOn(seriesStart time.Time, date time.Time) bool {
+	first := dateOf(seriesStart)
+	day := dateOf(date)
+	if day.Before(first) {
+		return false
+	}
+
+	for _, exceptionDate := range r.ExceptionDates {
+		if dateOf(exceptionDate).Equal(day) {
+			return false
+		}
+	}
+
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	if r.Frequency == FrequencyDaily {
+		if daysBetween(first, day)%interval != 0 {
+			return false
+		}
+		return len(r.Weekdays) == 0 || r.hasWeekday(day.Weekday())
+	}
+
+	weeks := daysBetween(startOfWeek(first), startOfWeek(day)) / 7
+	if weeks%interval != 0 {
+		return false
+	}
+
+	if len(r.Weekdays) == 0 {
+		return day.Weekday() == first.Weekday()
+	}
+	return r.hasWeekday(day.Weekday())
+}
+
+func (r Recurrence) hasWeekday(weekday time.Weekday) bool {
+	for _, w := range r.Weekdays {
+		if w == weekdays[weekday] {
+			return true
+		}
+	}
+	return false
+}
+
+// start returns when the occurrence on the given date starts, in the location of loc.
+func (r Recurrence) start(date time.Time, loc *time.Location) time.Time {
+	clock, err := time.Parse(recurrenceTimeLayout, r.StartTime)
+	if err != nil {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+}
+
+func (r Recurrence) duration() time.Duration {
+	return time.Duration(r.DurationMinutes) * time.Minute
+}
+
+// dateOf returns the calendar date of t at midnight UTC, so dates can be compared and subtracted.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfWeek(date time.Time) time.Time {
+	daysSinceMonday := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -daysSinceMonday)
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,60 @@
+package classes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SearchResult is a class matching a search, along with how well it matches and a snippet of its
+// name and description. The snippet is HTML escaped, with the matching words wrapped in <mark> tags.
+type SearchResult struct {
+	Class   Class   `json:"class"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// Facet counts the classes matching a search that share a value. Location facets are labeled
+// with the location name.
+type Facet struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets counts the classes matching a search by category, level and location. Classes
+// without a category, level or room aren't counted in the corresponding facets.
+type SearchFacets struct {
+	Categories []Facet `json:"categories"`
+	Levels     []Facet `json:"levels"`
+	Locations  []Facet `json:"locations"`
+}
+
+// SearchResults holds a page of the classes matching a search, best matches first. Total and
+// Facets count every matching class, not only the ones on the page.
+type SearchResults struct {
+	Results []SearchResult `json:"results"`
+	Total   int            `json:"total"`
+	Facets  SearchFacets   `json:"facets"`
+}
+
+// SearchClasses searches the active classes by name, description, tags and instructor name. The
+// query is written as in web search engines: quoted phrases, OR and -excluded words are supported.
+func (u *Usecase) SearchClasses(ctx context.Context, query string, pageInfo PageInfo) (SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return SearchResults{}, fmt.Errorf("missing search query 'q': %w", ErrInvalidData)
+	}
+
+	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
+		pageInfo.Limit = 100
+	}
+
+	offset := pageInfo.Limit * pageInfo.Page
+	results, err := u.repository.Search(ctx, query, pageInfo.Limit, offset)
+	if err != nil {
+		return SearchResults{}, fmt.Errorf("failed to search classes in repository: %w", err)
+	}
+
+	return results, nil
+}
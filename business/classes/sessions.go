@@ -0,0 +1,139 @@
+package classes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ListSessions lists the sessions of a class from one date to another, both inclusive. Sessions
+// that weren't materialized yet are listed as the schedule generates them, without an ID.
+func (u *Usecase) ListSessions(ctx context.Context, classID string, from time.Time, to time.Time) ([]Session, error) {
+	class, err := u.GetByID(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := u.repository.ListSessions(ctx, classID, dateOf(from), dateOf(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions from repository: %w", err)
+	}
+
+	return mergeSessions(class, stored, from, to), nil
+}
+
+// ListClassesSessions lists the sessions of the classes from one date to another by class ID, as
+// ListSessions does, reading the stored sessions of every class at once.
+func (u *Usecase) ListClassesSessions(ctx context.Context, allClasses []Class, from time.Time, to time.Time) (map[string][]Session, error) {
+	classIDs := make([]string, 0, len(allClasses))
+	for _, class := range allClasses {
+		classIDs = append(classIDs, class.ID)
+	}
+
+	stored, err := u.repository.ListClassesSessions(ctx, classIDs, dateOf(from), dateOf(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions from repository: %w", err)
+	}
+
+	storedByClass := make(map[string][]Session)
+	for _, session := range stored {
+		storedByClass[session.ClassID] = append(storedByClass[session.ClassID], session)
+	}
+
+	sessionsByClass := make(map[string][]Session, len(allClasses))
+	for _, class := range allClasses {
+		sessionsByClass[class.ID] = mergeSessions(class, storedByClass[class.ID], from, to)
+	}
+
+	return sessionsByClass, nil
+}
+
+// mergeSessions returns the sessions the class schedule generates from one date to another, replaced
+// by the stored ones, along with the stored sessions that dropped out of the schedule, by date.
+func mergeSessions(class Class, stored []Session, from time.Time, to time.Time) []Session {
+	storedByDate := make(map[time.Time]Session)
+	for _, session := range stored {
+		storedByDate[dateOf(session.Date)] = session
+	}
+
+	sessions := make([]Session, 0)
+	for _, session := range class.Sessions(from, to) {
+		if storedSession, ok := storedByDate[session.Date]; ok {
+			session = storedSession
+			delete(storedByDate, session.Date)
+		}
+		sessions = append(sessions, session)
+	}
+
+	for _, session := range storedByDate {
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Date.Before(sessions[j].Date)
+	})
+
+	return sessions
+}
+
+// GetStoredSession returns the stored session of the class on the date, including the ones that
+// dropped out of the class schedule, or ErrSessionNotFound when there is none.
+func (u *Usecase) GetStoredSession(ctx context.Context, classID string, date time.Time) (Session, error) {
+	stored, err := u.repository.ListSessions(ctx, classID, dateOf(date), dateOf(date))
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to list sessions from repository: %w", err)
+	}
+
+	if len(stored) == 0 {
+		return Session{}, ErrSessionNotFound
+	}
+
+	return stored[0], nil
+}
+
+// EnsureSession returns the session of the class on the given date, materializing it when it
+// wasn't generated yet.
+func (u *Usecase) EnsureSession(ctx context.Context, class Class, date time.Time) (Session, error) {
+	session, err := u.repository.EnsureSession(ctx, class.Session(date))
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to ensure session in repository: %w", err)
+	}
+
+	return session, nil
+}
+
+// UpdateSession overrides the class capacity, instructor, room or notes for a single session, or
+// resets the overrides the update sets empty.
+func (u *Usecase) UpdateSession(ctx context.Context, classID string, date time.Time, updateSession UpdateSession) (Session, error) {
+	if updateSession.Capacity != nil && *updateSession.Capacity < 0 {
+		return Session{}, fmt.Errorf("session 'capacity' cannot be negative: %w", ErrInvalidData)
+	}
+
+	class, err := u.GetByID(ctx, classID)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if !class.OccursOn(date) {
+		return Session{}, ErrSessionNotFound
+	}
+
+	if _, err := u.EnsureSession(ctx, class, date); err != nil {
+		return Session{}, err
+	}
+
+	session, err := u.repository.UpdateSession(ctx, classID, dateOf(date), updateSession)
+	if err != nil {
+		if invalidAssignment(err) {
+			return Session{}, fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+		if errors.Is(err, ErrCapacityBelowBookings) || errors.Is(err, ErrInstructorConflict) || errors.Is(err, ErrRoomConflict) {
+			return Session{}, err
+		}
+		return Session{}, fmt.Errorf("failed to update session in repository: %w", err)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,101 @@
+package classes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_ListSessions(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	// 2023-07-03 is a Monday.
+	from := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 13)
+	class := NewClass()
+	class.StartDate = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	class.EndDate = time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)
+	class.Recurrence = &classes.Recurrence{Weekdays: []classes.Weekday{classes.Monday}, StartTime: "18:00", DurationMinutes: 60}
+
+	capacity := 5
+	stored := class.Session(from)
+	stored.ID = uuid.NewString()
+	stored.Capacity = &capacity
+
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+	classesRepo.On("ListSessions", mock.Anything, class.ID, from, to).Return([]classes.Session{stored}, nil).Once()
+
+	sessions, err := usecase.ListSessions(ctx, class.ID, from, to)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, stored, sessions[0])
+	assert.Empty(t, sessions[1].ID)
+	assert.Equal(t, time.Date(2023, 7, 10, 18, 0, 0, 0, time.UTC), sessions[1].StartsAt)
+}
+
+func TestUsecase_UpdateSession_NotAnOccurrence(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	class := NewClass()
+	class.StartDate = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	class.EndDate = time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)
+	class.Recurrence = &classes.Recurrence{Weekdays: []classes.Weekday{classes.Monday}, StartTime: "18:00", DurationMinutes: 60}
+
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+
+	notes := uuid.NewString()
+	_, err := usecase.UpdateSession(ctx, class.ID, time.Date(2023, 7, 4, 0, 0, 0, 0, time.UTC), classes.UpdateSession{Notes: &notes})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, classes.ErrSessionNotFound))
+}
+
+func TestUsecase_UpdateSession_InvalidCapacity(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	capacity := -1
+	_, err := usecase.UpdateSession(ctx, uuid.NewString(), time.Now(), classes.UpdateSession{Capacity: &capacity})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, classes.ErrInvalidData))
+}
+
+func TestUsecase_UpdateSession_ResetOverrides(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	date := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	class := NewClass()
+	class.StartDate = date
+	class.EndDate = date.AddDate(0, 0, 7)
+
+	capacity := 0
+	empty := ""
+	updateSession := classes.UpdateSession{Capacity: &capacity, InstructorID: &empty, RoomID: &empty, Notes: &empty}
+	session := class.Session(date)
+	session.ID = uuid.NewString()
+
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+	classesRepo.On("EnsureSession", mock.Anything, class.Session(date)).Return(session, nil).Once()
+	classesRepo.On("UpdateSession", mock.Anything, class.ID, date, updateSession).Return(session, nil).Once()
+
+	sessionUpdated, err := usecase.UpdateSession(ctx, class.ID, date, updateSession)
+	require.NoError(t, err)
+	assert.Nil(t, sessionUpdated.Capacity)
+	assert.Nil(t, sessionUpdated.InstructorID)
+	assert.Nil(t, sessionUpdated.RoomID)
+	assert.Nil(t, sessionUpdated.Notes)
+}
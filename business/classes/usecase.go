@@ -4,13 +4,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidData = errors.New("invalid data")
-	ErrNotFound    = errors.New("not found")
+	ErrInvalidData           = errors.New("invalid data")
+	ErrNotFound              = errors.New("not found")
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrCapacityBelowBookings = errors.New("capacity is below the number of bookings")
+	// ErrBookingsOutsideSchedule is returned by updates that drop dates with upcoming bookings from
+	// the class schedule without saying what to do with them.
+	ErrBookingsOutsideSchedule = errors.New("class has bookings outside the schedule")
+	// ErrInstructorNotFound is returned when assigning an instructor that doesn't exist.
+	ErrInstructorNotFound = errors.New("instructor not found")
+	// ErrInstructorConflict is returned when assigning an instructor to sessions overlapping other
+	// sessions they teach.
+	ErrInstructorConflict = errors.New("instructor teaches an overlapping session")
+	// ErrRoomNotFound is returned when assigning a room that doesn't exist.
+	ErrRoomNotFound = errors.New("room not found")
+	// ErrRoomConflict is returned when assigning a room to sessions overlapping other sessions in
+	// the room.
+	ErrRoomConflict = errors.New("room is taken by an overlapping session")
+	// ErrCapacityAboveRoom is returned when a class or session would take more people than its room
+	// holds.
+	ErrCapacityAboveRoom = errors.New("capacity exceeds room capacity")
+	// ErrVersionMismatch is returned by conditional updates of classes that changed since the caller read them.
+	ErrVersionMismatch = errors.New("class version doesn't match")
+	// ErrClassDeleted is returned when archiving or deleting a class that was already deleted.
+	ErrClassDeleted = errors.New("class was deleted")
+	// ErrDeleteConflict is returned when deleting a class collides with bookings changing at the
+	// same time. Retrying the deletion is safe.
+	ErrDeleteConflict = errors.New("class bookings changed while deleting the class")
 )
 
 type Usecase struct {
@@ -27,10 +55,19 @@ func NewUsecase(repository Repository) *Usecase {
 type Repository interface {
 	Add(ctx context.Context, class Class) (Class, error)
 	GetByID(ctx context.Context, classID string) (Class, error)
+	GetByIDIncludingDeleted(ctx context.Context, classID string) (Class, error)
+	GetRoomTimezone(ctx context.Context, roomID string) (string, error)
 	IsNotFoundErr(err error) bool
 	Update(ctx context.Context, classID string, updateClass UpdateClass) (Class, error)
+	Archive(ctx context.Context, classID string) (Class, error)
 	Delete(ctx context.Context, classID string) error
-	List(ctx context.Context, limit int, offset int) ([]Class, error)
+	List(ctx context.Context, filter Filter, limit int, offset int) ([]Class, error)
+	Search(ctx context.Context, query string, limit int, offset int) (SearchResults, error)
+	EnsureSession(ctx context.Context, session Session) (Session, error)
+	ListSessions(ctx context.Context, classID string, from time.Time, to time.Time) ([]Session, error)
+	ListClassesSessions(ctx context.Context, classIDs []string, from time.Time, to time.Time) ([]Session, error)
+	ListRoomIDs(ctx context.Context, locationID string) ([]string, error)
+	UpdateSession(ctx context.Context, classID string, date time.Time, updateSession UpdateSession) (Session, error)
 }
 
 func (u *Usecase) AddClass(ctx context.Context, newClass NewClass) (Class, error) {
@@ -40,14 +77,46 @@ func (u *Usecase) AddClass(ctx context.Context, newClass NewClass) (Class, error
 		StartDate: newClass.StartDate,
 		EndDate:   newClass.EndDate,
 		Capacity:  newClass.Capacity,
+
+		Description:     strings.TrimSpace(newClass.Description),
+		Category:        normalizeLabel(newClass.Category),
+		Tags:            normalizeTags(newClass.Tags),
+		Level:           newClass.Level,
+		DurationMinutes: newClass.DurationMinutes,
+		ImageURL:        strings.TrimSpace(newClass.ImageURL),
+
+		CancellationPolicy: newClass.CancellationPolicy,
+		Recurrence:         newClass.Recurrence,
+		InstructorID:       newClass.InstructorID,
+		RoomID:             newClass.RoomID,
+		Timezone:           newClass.Timezone,
+	}
+
+	// Classes without a timezone of their own run in the timezone of the location of their room,
+	// so their sessions are validated in it.
+	if class.Timezone == "" && class.RoomID != nil {
+		timezone, err := u.repository.GetRoomTimezone(ctx, *class.RoomID)
+		if err != nil {
+			if errors.Is(err, ErrRoomNotFound) {
+				return Class{}, fmt.Errorf("%w: %w", ErrInvalidData, err)
+			}
+			return Class{}, fmt.Errorf("failed to get room timezone from repository: %w", err)
+		}
+		class.Timezone = timezone
 	}
 
-	if err := u.validClass(class); err != nil {
+	if err := ValidateClass(class); err != nil {
 		return Class{}, err
 	}
 
 	classAdded, err := u.repository.Add(ctx, class)
 	if err != nil {
+		if invalidAssignment(err) {
+			return Class{}, fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+		if errors.Is(err, ErrInstructorConflict) || errors.Is(err, ErrRoomConflict) {
+			return Class{}, err
+		}
 		return Class{}, fmt.Errorf("failed to add class to repository: %w", err)
 	}
 
@@ -67,32 +136,133 @@ func (u *Usecase) GetByID(ctx context.Context, classID string) (Class, error) {
 	return class, nil
 }
 
+// GetByIDIncludingDeleted gets the class even when it was deleted, for the bookings kept as its
+// history.
+func (u *Usecase) GetByIDIncludingDeleted(ctx context.Context, classID string) (Class, error) {
+	class, err := u.repository.GetByIDIncludingDeleted(ctx, classID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Class{}, ErrNotFound
+		}
+
+		return Class{}, err
+	}
+
+	return class, nil
+}
+
+// UpdateClass updates the class, which has to keep holding the same invariants it was created
+// with. The repository also rejects capacities below the bookings of an upcoming date and, unless
+// the update is forced, schedule changes that leave upcoming bookings outside the schedule. Updates
+// forced to cancel those bookings cancel their sessions in the same transaction.
 func (u *Usecase) UpdateClass(ctx context.Context, classID string, updateClass UpdateClass) (Class, error) {
+	if updateClass.Force != "" && !updateClass.Force.Valid() {
+		return Class{}, fmt.Errorf("invalid 'force' option %q: %w", updateClass.Force, ErrInvalidData)
+	}
+
+	if updateClass.Timezone != nil && *updateClass.Timezone == "" {
+		return Class{}, fmt.Errorf("class 'timezone' can't be empty: %w", ErrInvalidData)
+	}
+
+	normalizeUpdate(&updateClass)
+
+	class, err := u.GetByID(ctx, classID)
+	if err != nil {
+		return Class{}, err
+	}
+
+	if err := ValidateClass(updateClass.apply(class)); err != nil {
+		return Class{}, err
+	}
+
 	classUpdated, err := u.repository.Update(ctx, classID, updateClass)
 	if err != nil {
 		if u.repository.IsNotFoundErr(err) {
 			return Class{}, ErrNotFound
 		}
+		if invalidAssignment(err) {
+			return Class{}, fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+		if errors.Is(err, ErrInvalidData) || errors.Is(err, ErrVersionMismatch) || errors.Is(err, ErrCapacityBelowBookings) ||
+			errors.Is(err, ErrBookingsOutsideSchedule) || errors.Is(err, ErrInstructorConflict) || errors.Is(err, ErrRoomConflict) {
+			return Class{}, err
+		}
 		return Class{}, fmt.Errorf("failed to update class in repository: %w", err)
 	}
 
 	return classUpdated, nil
 }
 
+// ArchiveClass archives the class, which keeps its sessions and bookings but hides it from
+// listings and stops new bookings. Archiving an archived class changes nothing.
+func (u *Usecase) ArchiveClass(ctx context.Context, classID string) (Class, error) {
+	class, err := u.repository.Archive(ctx, classID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Class{}, ErrNotFound
+		}
+		if errors.Is(err, ErrClassDeleted) {
+			return Class{}, err
+		}
+		return Class{}, fmt.Errorf("failed to archive class in repository: %w", err)
+	}
+
+	return class, nil
+}
+
+// DeleteClass deletes the class, cancelling the sessions with upcoming bookings, holds or waitlist
+// entries in the same transaction. The class row isn't removed but marked as deleted, so the
+// bookings, holds and waitlist entries of the class are kept as history.
 func (u *Usecase) DeleteClass(ctx context.Context, classID string) error {
-	return u.repository.Delete(ctx, classID)
+	err := u.repository.Delete(ctx, classID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return ErrNotFound
+		}
+		if errors.Is(err, ErrClassDeleted) || errors.Is(err, ErrDeleteConflict) {
+			return err
+		}
+		return fmt.Errorf("failed to delete class from repository: %w", err)
+	}
+
+	return nil
 }
 
-func (u *Usecase) ListClasses(ctx context.Context, pageInfo PageInfo) ([]Class, error) {
+func (u *Usecase) ListClasses(ctx context.Context, filter Filter, pageInfo PageInfo) ([]Class, error) {
+	if filter.Level != "" && !filter.Level.Valid() {
+		return nil, fmt.Errorf("invalid class 'level' %q: %w", filter.Level, ErrInvalidData)
+	}
+
+	filter.Category = normalizeLabel(filter.Category)
+	filter.Tag = normalizeLabel(filter.Tag)
+
 	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
 		pageInfo.Limit = 100
 	}
 
 	offset := pageInfo.Limit * pageInfo.Page
-	return u.repository.List(ctx, pageInfo.Limit, offset)
+	return u.repository.List(ctx, filter, pageInfo.Limit, offset)
+}
+
+// LocationRoomIDs lists the IDs of the rooms of the location.
+func (u *Usecase) LocationRoomIDs(ctx context.Context, locationID string) ([]string, error) {
+	roomIDs, err := u.repository.ListRoomIDs(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list location rooms from repository: %w", err)
+	}
+
+	return roomIDs, nil
+}
+
+// invalidAssignment tells whether the repository rejected the instructor or room of a class or
+// session for not existing or the room for being too small.
+func invalidAssignment(err error) bool {
+	return errors.Is(err, ErrInstructorNotFound) || errors.Is(err, ErrRoomNotFound) || errors.Is(err, ErrCapacityAboveRoom)
 }
 
-func (u *Usecase) validClass(class Class) error {
+// ValidateClass checks the invariants every stored class holds. Repositories check updated classes
+// again once they're locked, since the class can change between reading and updating it.
+func ValidateClass(class Class) error {
 	if class.Name == "" {
 		return fmt.Errorf("missing class 'name': %w", ErrInvalidData)
 	}
@@ -101,9 +271,172 @@ func (u *Usecase) validClass(class Class) error {
 		return fmt.Errorf("missing class 'capacity': %w", ErrInvalidData)
 	}
 
+	if class.Capacity < 0 {
+		return fmt.Errorf("class 'capacity' cannot be negative: %w", ErrInvalidData)
+	}
+
 	if class.StartDate.After(class.EndDate) {
 		return fmt.Errorf("start date cannot be later than end date: %w", ErrInvalidData)
 	}
 
+	if err := validMetadata(class); err != nil {
+		return err
+	}
+
+	if class.Timezone != "" {
+		if err := validTimezone(class.Timezone); err != nil {
+			return err
+		}
+	}
+
+	if class.CancellationPolicy != nil {
+		if err := validCancellationPolicy(*class.CancellationPolicy); err != nil {
+			return err
+		}
+	}
+
+	if class.Recurrence != nil {
+		if err := validRecurrence(*class.Recurrence); err != nil {
+			return err
+		}
+	}
+
+	return validDuration(class)
+}
+
+// validDuration checks the duration the class advertises, when set, is how long its sessions last.
+// Sessions last the recurrence duration, or otherwise from the time of day of StartDate to the one
+// of EndDate, as on the first date of the class.
+func validDuration(class Class) error {
+	if class.DurationMinutes == 0 {
+		return nil
+	}
+
+	firstDate := class.LocalDate(class.StartDate)
+	sessionDuration := class.SessionEnd(firstDate).Sub(class.SessionStart(firstDate))
+	if time.Duration(class.DurationMinutes)*time.Minute != sessionDuration {
+		return fmt.Errorf("class 'durationMinutes' is %d but its sessions last %s: %w", class.DurationMinutes, sessionDuration, ErrInvalidData)
+	}
+
+	return nil
+}
+
+// validMetadata checks the fields describing the class to members.
+func validMetadata(class Class) error {
+	if class.Level != "" && !class.Level.Valid() {
+		return fmt.Errorf("invalid class 'level' %q: %w", class.Level, ErrInvalidData)
+	}
+
+	if class.DurationMinutes < 0 {
+		return fmt.Errorf("class 'durationMinutes' cannot be negative: %w", ErrInvalidData)
+	}
+
+	if class.ImageURL != "" {
+		imageURL, err := url.Parse(class.ImageURL)
+		if err != nil || (imageURL.Scheme != "http" && imageURL.Scheme != "https") || imageURL.Host == "" {
+			return fmt.Errorf("class 'imageURL' must be an http or https URL: %w", ErrInvalidData)
+		}
+	}
+
+	return nil
+}
+
+// normalizeUpdate normalizes the fields describing the class the update sets the way AddClass does.
+func normalizeUpdate(updateClass *UpdateClass) {
+	if updateClass.Description != nil {
+		description := strings.TrimSpace(*updateClass.Description)
+		updateClass.Description = &description
+	}
+
+	if updateClass.Category != nil {
+		category := normalizeLabel(*updateClass.Category)
+		updateClass.Category = &category
+	}
+
+	if updateClass.Tags != nil {
+		tags := normalizeTags(*updateClass.Tags)
+		updateClass.Tags = &tags
+	}
+
+	if updateClass.ImageURL != nil {
+		imageURL := strings.TrimSpace(*updateClass.ImageURL)
+		updateClass.ImageURL = &imageURL
+	}
+}
+
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// normalizeTags returns the tags normalized, without empty and repeated tags.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = normalizeLabel(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// validTimezone checks the timezone is an IANA time zone name. Local isn't accepted, since it
+// depends on where the service runs.
+func validTimezone(timezone string) error {
+	if timezone == "Local" {
+		return fmt.Errorf("class 'timezone' must be an IANA time zone name: %w", ErrInvalidData)
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("unknown class timezone %q: %w", timezone, ErrInvalidData)
+	}
+
+	return nil
+}
+
+func validCancellationPolicy(policy CancellationPolicy) error {
+	if policy.FreeCancelCutoffMinutes < 0 {
+		return fmt.Errorf("cancellation policy 'freeCancelCutoffMinutes' cannot be negative: %w", ErrInvalidData)
+	}
+
+	if !policy.LateCancelPenalty.Valid() {
+		return fmt.Errorf("invalid cancellation policy 'lateCancelPenalty' %q: %w", policy.LateCancelPenalty, ErrInvalidData)
+	}
+
+	return nil
+}
+
+func validRecurrence(recurrence Recurrence) error {
+	if recurrence.Frequency != "" && !recurrence.Frequency.Valid() {
+		return fmt.Errorf("invalid recurrence 'frequency' %q: %w", recurrence.Frequency, ErrInvalidData)
+	}
+
+	if recurrence.Interval < 0 {
+		return fmt.Errorf("recurrence 'interval' cannot be negative: %w", ErrInvalidData)
+	}
+
+	seen := make(map[Weekday]bool)
+	for _, weekday := range recurrence.Weekdays {
+		if !weekday.Valid() {
+			return fmt.Errorf("invalid recurrence weekday %q: %w", weekday, ErrInvalidData)
+		}
+		if seen[weekday] {
+			return fmt.Errorf("duplicated recurrence weekday %q: %w", weekday, ErrInvalidData)
+		}
+		seen[weekday] = true
+	}
+
+	if _, err := time.Parse(recurrenceTimeLayout, recurrence.StartTime); err != nil {
+		return fmt.Errorf("recurrence 'startTime' must be formatted as HH:MM: %w", ErrInvalidData)
+	}
+
+	if recurrence.DurationMinutes <= 0 || recurrence.DurationMinutes > 24*60 {
+		return fmt.Errorf("recurrence 'durationMinutes' must be between 1 and 1440: %w", ErrInvalidData)
+	}
+
 	return nil
 }
@@ -3,6 +3,7 @@ package classes_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -52,6 +53,65 @@ func TestUsecase_AddClass(t *testing.T) {
 			want:    classes.Class{},
 			wantErr: classes.ErrInvalidData,
 		},
+		{
+			name: "invalid_late_cancel_penalty",
+			newClass: classes.NewClass{
+				Name:      uuid.NewString(),
+				Capacity:  30,
+				StartDate: time.Now(),
+				EndDate:   time.Now().Add(time.Hour * 24 * 10),
+				CancellationPolicy: &classes.CancellationPolicy{
+					FreeCancelCutoffMinutes: 60,
+					LateCancelPenalty:       "refund",
+				},
+			},
+			want:    classes.Class{},
+			wantErr: classes.ErrInvalidData,
+		},
+		{
+			name: "invalid_recurrence_weekday",
+			newClass: classes.NewClass{
+				Name:      uuid.NewString(),
+				Capacity:  30,
+				StartDate: time.Now(),
+				EndDate:   time.Now().Add(time.Hour * 24 * 10),
+				Recurrence: &classes.Recurrence{
+					Weekdays:        []classes.Weekday{"MONDAY"},
+					StartTime:       "18:00",
+					DurationMinutes: 60,
+				},
+			},
+			want:    classes.Class{},
+			wantErr: classes.ErrInvalidData,
+		},
+		{
+			name: "invalid_recurrence_start_time",
+			newClass: classes.NewClass{
+				Name:      uuid.NewString(),
+				Capacity:  30,
+				StartDate: time.Now(),
+				EndDate:   time.Now().Add(time.Hour * 24 * 10),
+				Recurrence: &classes.Recurrence{
+					Weekdays:        []classes.Weekday{classes.Monday},
+					StartTime:       "6pm",
+					DurationMinutes: 60,
+				},
+			},
+			want:    classes.Class{},
+			wantErr: classes.ErrInvalidData,
+		},
+		{
+			name: "unknown_timezone",
+			newClass: classes.NewClass{
+				Name:      uuid.NewString(),
+				Capacity:  30,
+				StartDate: time.Now(),
+				EndDate:   time.Now().Add(time.Hour * 24 * 10),
+				Timezone:  "America/Atlantis",
+			},
+			want:    classes.Class{},
+			wantErr: classes.ErrInvalidData,
+		},
 		{
 			name: "valid_class",
 			newClass: classes.NewClass{
@@ -134,7 +194,7 @@ func TestUsecase_UpdateClass_NotFound(t *testing.T) {
 
 	UpdateClass := classes.UpdateClass{}
 	expectedErr := pgx.ErrNoRows
-	classesRepo.On("Update", mock.Anything, classID, UpdateClass).Return(classes.Class{}, expectedErr).Once()
+	classesRepo.On("GetByID", mock.Anything, classID).Return(classes.Class{}, expectedErr).Once()
 	classesRepo.On("IsNotFoundErr", expectedErr).Return(true).Once()
 
 	classFound, err := usecase.UpdateClass(ctx, classID, UpdateClass)
@@ -156,6 +216,7 @@ func TestUsecase_UpdateClass(t *testing.T) {
 		Name: &newName,
 	}
 
+	classesRepo.On("GetByID", mock.Anything, classID).Return(expectedClass, nil).Once()
 	expectedClass.Name = newName
 	classesRepo.On("Update", mock.Anything, classID, UpdateClass).Return(expectedClass, nil).Once()
 
@@ -164,6 +225,158 @@ func TestUsecase_UpdateClass(t *testing.T) {
 	require.Equal(t, newName, classUpdated.Name)
 }
 
+func TestUsecase_UpdateClass_ClearCancellationPolicy(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	class := NewClass()
+	class.CancellationPolicy = &classes.CancellationPolicy{FreeCancelCutoffMinutes: 720, LateCancelPenalty: classes.PenaltyCredit}
+	updateClass := classes.UpdateClass{CancellationPolicy: &classes.CancellationPolicy{}}
+
+	classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+	expectedClass := class
+	expectedClass.CancellationPolicy = nil
+	classesRepo.On("Update", mock.Anything, class.ID, updateClass).Return(expectedClass, nil).Once()
+
+	classUpdated, err := usecase.UpdateClass(ctx, class.ID, updateClass)
+	require.NoError(t, err)
+	require.Nil(t, classUpdated.CancellationPolicy)
+}
+
+func TestUsecase_UpdateClass_InvalidData(t *testing.T) {
+	ctx := context.Background()
+
+	startDate := time.Date(2030, 3, 1, 9, 0, 0, 0, time.UTC)
+	class := NewClass()
+	class.StartDate = startDate
+	class.EndDate = startDate.AddDate(0, 1, 0)
+
+	emptyName := ""
+	zeroCapacity := 0
+	negativeCapacity := -5
+	startAfterEnd := class.EndDate.AddDate(0, 0, 1)
+	endBeforeStart := startDate.AddDate(0, 0, -1)
+	unknownLevel := classes.Level("expert")
+	emptyTimezone := ""
+	localTimezone := "Local"
+	otherDuration := 45
+
+	testCases := []struct {
+		name        string
+		updateClass classes.UpdateClass
+	}{
+		{name: "empty name", updateClass: classes.UpdateClass{Name: &emptyName}},
+		{name: "zero capacity", updateClass: classes.UpdateClass{Capability: &zeroCapacity}},
+		{name: "negative capacity", updateClass: classes.UpdateClass{Capability: &negativeCapacity}},
+		{name: "start after current end", updateClass: classes.UpdateClass{StartDate: &startAfterEnd}},
+		{name: "end before current start", updateClass: classes.UpdateClass{EndDate: &endBeforeStart}},
+		{name: "invalid recurrence", updateClass: classes.UpdateClass{Recurrence: &classes.Recurrence{StartTime: "25:00", DurationMinutes: 60}}},
+		{name: "invalid force option", updateClass: classes.UpdateClass{Force: "drop"}},
+		{name: "unknown level", updateClass: classes.UpdateClass{Level: &unknownLevel}},
+		{name: "empty timezone", updateClass: classes.UpdateClass{Timezone: &emptyTimezone}},
+		{name: "local timezone", updateClass: classes.UpdateClass{Timezone: &localTimezone}},
+		{name: "duration other than the sessions", updateClass: classes.UpdateClass{DurationMinutes: &otherDuration}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			classesRepo := mocks.NewRepository(t)
+			usecase := classes.NewUsecase(classesRepo)
+			classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Maybe()
+
+			_, err := usecase.UpdateClass(ctx, class.ID, testCase.updateClass)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, classes.ErrInvalidData))
+		})
+	}
+}
+
+func TestUsecase_UpdateClass_BookingConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	for _, expectedErr := range []error{classes.ErrCapacityBelowBookings, classes.ErrBookingsOutsideSchedule, classes.ErrInstructorConflict} {
+		t.Run(expectedErr.Error(), func(t *testing.T) {
+			classesRepo := mocks.NewRepository(t)
+			usecase := classes.NewUsecase(classesRepo)
+
+			class := NewClass()
+			newCapacity := 5
+			updateClass := classes.UpdateClass{Capability: &newCapacity}
+			repoErr := fmt.Errorf("conflicting bookings: %w", expectedErr)
+			classesRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil).Once()
+			classesRepo.On("Update", mock.Anything, class.ID, updateClass).Return(classes.Class{}, repoErr).Once()
+			classesRepo.On("IsNotFoundErr", repoErr).Return(false).Once()
+
+			_, err := usecase.UpdateClass(ctx, class.ID, updateClass)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, expectedErr))
+		})
+	}
+}
+
+func TestUsecase_AddClass_InstructorErrors(t *testing.T) {
+	ctx := context.Background()
+	instructorID := uuid.NewString()
+	newClass := classes.NewClass{
+		Name:         uuid.NewString(),
+		Capacity:     20,
+		StartDate:    time.Now(),
+		EndDate:      time.Now().Add(time.Hour * 24),
+		InstructorID: &instructorID,
+	}
+
+	tests := map[error]error{
+		classes.ErrInstructorNotFound: classes.ErrInvalidData,
+		classes.ErrInstructorConflict: classes.ErrInstructorConflict,
+	}
+	for repoErr, expectedErr := range tests {
+		t.Run(repoErr.Error(), func(t *testing.T) {
+			classesRepo := mocks.NewRepository(t)
+			usecase := classes.NewUsecase(classesRepo)
+			classesRepo.On("Add", mock.Anything, mock.Anything).Return(classes.Class{}, fmt.Errorf("instructor %s: %w", instructorID, repoErr)).Once()
+
+			_, err := usecase.AddClass(ctx, newClass)
+			require.ErrorIs(t, err, expectedErr)
+		})
+	}
+}
+
+func TestUsecase_AddClass_RoomTimezone(t *testing.T) {
+	ctx := context.Background()
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// New York springs forward on 2023-03-12, so the sessions last an hour in New York but none in UTC.
+	roomID := uuid.NewString()
+	newClass := classes.NewClass{
+		Name:            uuid.NewString(),
+		Capacity:        20,
+		StartDate:       time.Date(2023, 3, 11, 6, 30, 0, 0, newYork),
+		EndDate:         time.Date(2023, 3, 12, 7, 30, 0, 0, newYork),
+		DurationMinutes: 60,
+		RoomID:          &roomID,
+	}
+
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+	classesRepo.On("GetRoomTimezone", mock.Anything, roomID).Return("America/New_York", nil).Once()
+	classesRepo.On("Add", mock.Anything, mock.MatchedBy(func(class classes.Class) bool {
+		return class.Timezone == "America/New_York"
+	})).Return(NewClass(), nil).Once()
+
+	_, err = usecase.AddClass(ctx, newClass)
+	require.NoError(t, err)
+
+	classesRepo.On("GetRoomTimezone", mock.Anything, roomID).Return("UTC", nil).Once()
+	_, err = usecase.AddClass(ctx, newClass)
+	require.ErrorIs(t, err, classes.ErrInvalidData)
+
+	classesRepo.On("GetRoomTimezone", mock.Anything, roomID).Return("", fmt.Errorf("room %s: %w", roomID, classes.ErrRoomNotFound)).Once()
+	_, err = usecase.AddClass(ctx, newClass)
+	require.ErrorIs(t, err, classes.ErrInvalidData)
+}
+
 func TestUsecase_DeleteClass(t *testing.T) {
 	ctx := context.Background()
 	classesRepo := mocks.NewRepository(t)
@@ -175,6 +388,67 @@ func TestUsecase_DeleteClass(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUsecase_DeleteClass_Errors(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("connection reset by peer")
+
+	testCases := []struct {
+		name        string
+		repoErr     error
+		notFound    bool
+		expectedErr error
+	}{
+		{name: "not found", repoErr: pgx.ErrNoRows, notFound: true, expectedErr: classes.ErrNotFound},
+		{name: "already deleted", repoErr: fmt.Errorf("class: %w", classes.ErrClassDeleted), expectedErr: classes.ErrClassDeleted},
+		{name: "concurrent bookings", repoErr: fmt.Errorf("deadlock: %w", classes.ErrDeleteConflict), expectedErr: classes.ErrDeleteConflict},
+		{name: "repository failure", repoErr: dbErr, expectedErr: dbErr},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			classesRepo := mocks.NewRepository(t)
+			usecase := classes.NewUsecase(classesRepo)
+
+			classID := uuid.NewString()
+			classesRepo.On("Delete", mock.Anything, classID).Return(testCase.repoErr).Once()
+			classesRepo.On("IsNotFoundErr", testCase.repoErr).Return(testCase.notFound).Once()
+
+			err := usecase.DeleteClass(ctx, classID)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, testCase.expectedErr))
+		})
+	}
+}
+
+func TestUsecase_ArchiveClass(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	archivedAt := time.Now().UTC()
+	expectedClass := NewClass()
+	expectedClass.ArchivedAt = &archivedAt
+	classesRepo.On("Archive", mock.Anything, expectedClass.ID).Return(expectedClass, nil).Once()
+
+	class, err := usecase.ArchiveClass(ctx, expectedClass.ID)
+	require.NoError(t, err)
+	assert.Equal(t, expectedClass, class)
+
+	classID := uuid.NewString()
+	classesRepo.On("Archive", mock.Anything, classID).Return(classes.Class{}, pgx.ErrNoRows).Once()
+	classesRepo.On("IsNotFoundErr", pgx.ErrNoRows).Return(true).Once()
+
+	_, err = usecase.ArchiveClass(ctx, classID)
+	require.True(t, errors.Is(err, classes.ErrNotFound))
+
+	deletedErr := fmt.Errorf("class %s: %w", classID, classes.ErrClassDeleted)
+	classesRepo.On("Archive", mock.Anything, classID).Return(classes.Class{}, deletedErr).Once()
+	classesRepo.On("IsNotFoundErr", deletedErr).Return(false).Once()
+
+	_, err = usecase.ArchiveClass(ctx, classID)
+	require.True(t, errors.Is(err, classes.ErrClassDeleted))
+}
+
 func TestUsecase_ListClasses(t *testing.T) {
 	ctx := context.Background()
 	classesRepo := mocks.NewRepository(t)
@@ -182,13 +456,94 @@ func TestUsecase_ListClasses(t *testing.T) {
 
 	expectedClasses := make([]classes.Class, 0)
 	expectedClasses = append(expectedClasses, NewClass(), NewClass())
-	classesRepo.On("List", mock.Anything, 100, 0).Return(expectedClasses, nil).Once()
+	classesRepo.On("List", mock.Anything, classes.Filter{}, 100, 0).Return(expectedClasses, nil).Once()
 
 	pageInfo := classes.PageInfo{
 		Limit: 200,
 		Page:  0,
 	}
-	all, err := usecase.ListClasses(ctx, pageInfo)
+	all, err := usecase.ListClasses(ctx, classes.Filter{}, pageInfo)
 	require.NoError(t, err)
 	require.NotEmpty(t, all)
 }
+
+func TestUsecase_ListClasses_Filter(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	expectedFilter := classes.Filter{Category: "yoga", Tag: "hot", Level: classes.LevelBeginner}
+	classesRepo.On("List", mock.Anything, expectedFilter, 100, 0).Return([]classes.Class{NewClass()}, nil).Once()
+
+	all, err := usecase.ListClasses(ctx, classes.Filter{Category: " Yoga", Tag: "HOT ", Level: classes.LevelBeginner}, classes.PageInfo{})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+
+	_, err = usecase.ListClasses(ctx, classes.Filter{Level: "expert"}, classes.PageInfo{})
+	require.ErrorIs(t, err, classes.ErrInvalidData)
+}
+
+func TestUsecase_AddClass_Metadata(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	newClass := classes.NewClass{
+		Name:            uuid.NewString(),
+		Capacity:        30,
+		StartDate:       time.Now(),
+		EndDate:         time.Now().Add(time.Hour * 24 * 10),
+		Description:     "  Slow flow to end the day.  ",
+		Category:        " Yoga ",
+		Tags:            []string{"Evening", " relax", "evening", ""},
+		Level:           classes.LevelAll,
+		DurationMinutes: 75,
+		ImageURL:        "https://images.example.com/yoga.png",
+		Recurrence:      &classes.Recurrence{Frequency: classes.FrequencyDaily, Interval: 1, StartTime: "18:00", DurationMinutes: 75},
+	}
+
+	classesRepo.On("Add", mock.Anything, mock.MatchedBy(func(class classes.Class) bool {
+		return class.Description == "Slow flow to end the day." && class.Category == "yoga" &&
+			assert.ObjectsAreEqual([]string{"evening", "relax"}, class.Tags) &&
+			class.Level == classes.LevelAll && class.DurationMinutes == 75 && class.ImageURL == newClass.ImageURL
+	})).Return(NewClass(), nil).Once()
+
+	_, err := usecase.AddClass(ctx, newClass)
+	require.NoError(t, err)
+
+	for name, invalid := range map[string]func(newClass *classes.NewClass){
+		"unknown level":     func(newClass *classes.NewClass) { newClass.Level = "expert" },
+		"negative duration": func(newClass *classes.NewClass) { newClass.DurationMinutes = -10 },
+		"session duration":  func(newClass *classes.NewClass) { newClass.DurationMinutes = 60 },
+		"class dates duration": func(newClass *classes.NewClass) {
+			newClass.Recurrence = nil
+			newClass.EndDate = newClass.StartDate.AddDate(0, 0, 10).Add(time.Hour)
+		},
+		"relative image": func(newClass *classes.NewClass) { newClass.ImageURL = "/images/yoga.png" },
+		"ftp image":      func(newClass *classes.NewClass) { newClass.ImageURL = "ftp://images.example.com/yoga.png" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalidClass := newClass
+			invalid(&invalidClass)
+
+			_, err := usecase.AddClass(ctx, invalidClass)
+			require.ErrorIs(t, err, classes.ErrInvalidData)
+		})
+	}
+}
+
+func TestUsecase_SearchClasses(t *testing.T) {
+	ctx := context.Background()
+	classesRepo := mocks.NewRepository(t)
+	usecase := classes.NewUsecase(classesRepo)
+
+	expected := classes.SearchResults{Results: []classes.SearchResult{{Class: NewClass(), Rank: 0.5, Snippet: "<mark>Yoga</mark>"}}, Total: 21}
+	classesRepo.On("Search", mock.Anything, "hot yoga", 10, 20).Return(expected, nil).Once()
+
+	results, err := usecase.SearchClasses(ctx, "  hot yoga ", classes.PageInfo{Limit: 10, Page: 2})
+	require.NoError(t, err)
+	assert.Equal(t, expected, results)
+
+	_, err = usecase.SearchClasses(ctx, "   ", classes.PageInfo{})
+	require.ErrorIs(t, err, classes.ErrInvalidData)
+}
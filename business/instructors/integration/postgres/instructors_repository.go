@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const instructorColumns = `id, created_at, updated_at, name, email`
+
+type InstructorsRepository struct {
+	logger *zap.SugaredLogger
+	db     *pgxpool.Pool
+}
+
+func NewInstructorsRepository(logger *zap.SugaredLogger, db *pgxpool.Pool) *InstructorsRepository {
+	return &InstructorsRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *InstructorsRepository) AddInstructor(ctx context.Context, instructor instructors.Instructor) (instructors.Instructor, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	insertInstructor := `INSERT INTO instructors (id, name, email)
+				VALUES ($1, $2, $3)
+				RETURNING ` + instructorColumns
+	storedInstructor, err := scanInstructor(txn.QueryRow(ctx, insertInstructor, instructor.ID, instructor.Name, instructor.Email))
+	if err != nil {
+		return instructors.Instructor{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedInstructor, nil
+}
+
+func (r *InstructorsRepository) GetByID(ctx context.Context, instructorID string) (instructors.Instructor, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + instructorColumns + ` FROM instructors WHERE id = $1`
+	instructor, err := scanInstructor(txn.QueryRow(ctx, query, instructorID))
+	if err != nil {
+		return instructors.Instructor{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return instructor, nil
+}
+
+func scanInstructor(row pgx.Row) (instructors.Instructor, error) {
+	var instructor instructors.Instructor
+	err := row.Scan(&instructor.ID, &instructor.CreatedAt, &instructor.UpdatedAt, &instructor.Name, &instructor.Email)
+	if err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to scan instructors row to instructors.Instructor: %w", err)
+	}
+	return instructor, nil
+}
+
+func (r *InstructorsRepository) IsNotFoundErr(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows)
+}
+
+func (r *InstructorsRepository) UpdateInstructor(ctx context.Context, instructorID string, updateInstructor instructors.UpdateInstructor) (instructors.Instructor, error) {
+	values := make([]interface{}, 0)
+	columns := make([]string, 0)
+
+	if updateInstructor.Name != nil {
+		values = append(values, *updateInstructor.Name)
+		columns = append(columns, "name")
+	}
+
+	// An empty email removes the instructor email.
+	if updateInstructor.Email != nil {
+		var email *string
+		if *updateInstructor.Email != "" {
+			email = updateInstructor.Email
+		}
+		values = append(values, email)
+		columns = append(columns, "email")
+	}
+
+	updateStatements := make([]string, 0)
+	for index := range columns {
+		updateStatements = append(updateStatements, fmt.Sprintf("%s = $%d", columns[index], index+1))
+	}
+	updateStatements = append(updateStatements, "updated_at = now()")
+
+	values = append(values, instructorID)
+	statement := "UPDATE instructors SET " + strings.Join(updateStatements, ", ") + fmt.Sprintf(" WHERE id = $%d", len(values)) +
+		" RETURNING " + instructorColumns
+
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	instructor, err := scanInstructor(txn.QueryRow(ctx, statement, values...))
+	if err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to update instructor: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return instructors.Instructor{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return instructor, nil
+}
+
+func (r *InstructorsRepository) DeleteInstructor(ctx context.Context, instructorID string) error {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	statement := `DELETE FROM instructors WHERE id = $1`
+	_, err = txn.Exec(ctx, statement, instructorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete instructor: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return nil
+}
+
+func (r *InstructorsRepository) ListInstructors(ctx context.Context, limit int, offset int) ([]instructors.Instructor, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + instructorColumns + `
+				FROM instructors
+			  ORDER BY name, id
+			  LIMIT $1 OFFSET $2`
+	rows, err := txn.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query instructors: %w", err)
+	}
+
+	allInstructors := make([]instructors.Instructor, 0)
+	for rows.Next() {
+		instructor, err := scanInstructor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		allInstructors = append(allInstructors, instructor)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return allInstructors, nil
+}
+
+// ListSchedule lists the sessions of active classes the instructor teaches from one date to
+// another, both inclusive. Sessions naming an instructor of their own aren't taught by the class
+// instructor.
+func (r *InstructorsRepository) ListSchedule(ctx context.Context, instructorID string, from time.Time, to time.Time) ([]instructors.ScheduledSession, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT s.id, s.class_id, c.name, s.session_date, s.starts_at, s.ends_at, s.status
+				FROM class_sessions s
+				JOIN classes c ON c.id = s.class_id
+			  WHERE COALESCE(s.instructor_id, c.instructor_id) = $1 AND c.archived_at IS NULL
+				AND s.session_date BETWEEN $2 AND $3
+			  ORDER BY s.starts_at, s.id`
+	rows, err := txn.Query(ctx, query, instructorID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query instructor schedule: %w", err)
+	}
+
+	schedule := make([]instructors.ScheduledSession, 0)
+	for rows.Next() {
+		var session instructors.ScheduledSession
+		err := rows.Scan(&session.SessionID, &session.ClassID, &session.ClassName, &session.Date, &session.StartsAt, &session.EndsAt, &session.Status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled session: %w", err)
+		}
+
+		schedule = append(schedule, session)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return schedule, nil
+}
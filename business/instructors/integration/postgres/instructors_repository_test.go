@@ -0,0 +1,204 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgclasses "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/instructors/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/foundation/postgres"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupIntegration(t *testing.T) *pgxpool.Pool {
+	ctx := context.Background()
+	schema := t.Name()
+	pgCfg := postgres.Config{
+		Host:             "localhost",
+		Port:             5432,
+		DatabaseUser:     "class_booking",
+		DatabasePassword: "class_booking",
+		DatabaseName:     "class_booking_qa",
+		SSLMode:          "none",
+		SearchPath:       schema,
+	}
+	db, err := postgres.Open(ctx, pgCfg)
+	require.NoError(t, err)
+
+	err = postgres.DropAndCreateSchema(ctx, db, schema)
+	require.NoError(t, err)
+
+	err = postgres.Migrate("file://../../../../scripts/db/migrations/", pgCfg)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestRepository_AddInstructor(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewInstructorsRepository(logger.Sugar(), db)
+
+	email := "coach@example.com"
+	instructor := instructors.Instructor{
+		ID:    uuid.NewString(),
+		Name:  uuid.NewString(),
+		Email: &email,
+	}
+	instructorAdded, err := repo.AddInstructor(ctx, instructor)
+	require.NoError(t, err)
+
+	assert.Equal(t, instructor.ID, instructorAdded.ID)
+	assert.Equal(t, instructor.Name, instructorAdded.Name)
+	assert.Equal(t, instructor.Email, instructorAdded.Email)
+	assert.NotEmpty(t, instructorAdded.CreatedAt)
+
+	instructorFound, err := repo.GetByID(ctx, instructor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, instructorAdded, instructorFound)
+
+	_, err = repo.GetByID(ctx, uuid.NewString())
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_UpdateInstructor(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewInstructorsRepository(logger.Sugar(), db)
+
+	email := "coach@example.com"
+	instructor, err := repo.AddInstructor(ctx, instructors.Instructor{ID: uuid.NewString(), Name: uuid.NewString(), Email: &email})
+	require.NoError(t, err)
+
+	name := uuid.NewString()
+	noEmail := ""
+	updatedInstructor, err := repo.UpdateInstructor(ctx, instructor.ID, instructors.UpdateInstructor{Name: &name, Email: &noEmail})
+	require.NoError(t, err)
+	assert.Equal(t, name, updatedInstructor.Name)
+	assert.Nil(t, updatedInstructor.Email)
+
+	_, err = repo.UpdateInstructor(ctx, uuid.NewString(), instructors.UpdateInstructor{Name: &name})
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_DeleteInstructor(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewInstructorsRepository(logger.Sugar(), db)
+	classesRepo := pgclasses.NewClassesRepository(logger.Sugar(), db)
+
+	instructor, err := repo.AddInstructor(ctx, instructors.Instructor{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	class, err := classesRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now.AddDate(0, 0, 7),
+		Capacity: 20, InstructorID: &instructor.ID})
+	require.NoError(t, err)
+
+	err = repo.DeleteInstructor(ctx, instructor.ID)
+	require.NoError(t, err)
+
+	_, err = repo.GetByID(ctx, instructor.ID)
+	assert.True(t, repo.IsNotFoundErr(err))
+
+	class, err = classesRepo.GetByID(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Nil(t, class.InstructorID)
+}
+
+func TestRepository_ListInstructors(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewInstructorsRepository(logger.Sugar(), db)
+
+	for i := 0; i < 3; i++ {
+		_, err := repo.AddInstructor(ctx, instructors.Instructor{ID: uuid.NewString(), Name: uuid.NewString()})
+		require.NoError(t, err)
+	}
+
+	allInstructors, err := repo.ListInstructors(ctx, 2, 0)
+	require.NoError(t, err)
+	assert.Len(t, allInstructors, 2)
+
+	allInstructors, err = repo.ListInstructors(ctx, 2, 2)
+	require.NoError(t, err)
+	assert.Len(t, allInstructors, 1)
+}
+
+func TestRepository_ListSchedule(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewInstructorsRepository(logger.Sugar(), db)
+	classesRepo := pgclasses.NewClassesRepository(logger.Sugar(), db)
+
+	instructor, err := repo.AddInstructor(ctx, instructors.Instructor{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	recurrence := func(startTime string) *classes.Recurrence {
+		return &classes.Recurrence{
+			Frequency:       classes.FrequencyDaily,
+			Interval:        1,
+			StartTime:       startTime,
+			DurationMinutes: 60,
+		}
+	}
+
+	taught, err := classesRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: tomorrow, EndDate: tomorrow.AddDate(0, 0, 2),
+		Capacity: 20, Recurrence: recurrence("08:00"), InstructorID: &instructor.ID})
+	require.NoError(t, err)
+
+	substituted, err := classesRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: tomorrow, EndDate: tomorrow.AddDate(0, 0, 2),
+		Capacity: 20, Recurrence: recurrence("18:00")})
+	require.NoError(t, err)
+
+	_, err = classesRepo.UpdateSession(ctx, substituted.ID, tomorrow, classes.UpdateSession{InstructorID: &instructor.ID})
+	require.NoError(t, err)
+
+	schedule, err := repo.ListSchedule(ctx, instructor.ID, tomorrow, tomorrow.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, schedule, 3)
+
+	assert.Equal(t, taught.ID, schedule[0].ClassID)
+	assert.Equal(t, taught.Name, schedule[0].ClassName)
+	assert.Equal(t, substituted.ID, schedule[1].ClassID)
+	assert.True(t, tomorrow.Equal(schedule[1].Date))
+	assert.Equal(t, taught.ID, schedule[2].ClassID)
+	assert.True(t, tomorrow.AddDate(0, 0, 1).Equal(schedule[2].Date))
+}
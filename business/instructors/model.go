@@ -0,0 +1,40 @@
+package instructors
+
+import (
+	"time"
+)
+
+type Instructor struct {
+	ID        string    `json:"id,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Email     *string   `json:"email,omitempty"`
+}
+
+type NewInstructor struct {
+	Name  string  `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+}
+
+type UpdateInstructor struct {
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+}
+
+// ScheduledSession is a class session an instructor teaches, either as the class instructor or
+// as the instructor the session names.
+type ScheduledSession struct {
+	SessionID string    `json:"sessionID,omitempty"`
+	ClassID   string    `json:"classID,omitempty"`
+	ClassName string    `json:"className,omitempty"`
+	Date      time.Time `json:"date"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	Status    string    `json:"status,omitempty"`
+}
+
+type PageInfo struct {
+	Limit int
+	Page  int
+}
@@ -0,0 +1,118 @@
+package instructors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidData = errors.New("invalid data")
+	ErrNotFound    = errors.New("not found")
+)
+
+type Usecase struct {
+	repository Repository
+}
+
+func NewUsecase(repository Repository) *Usecase {
+	return &Usecase{
+		repository: repository,
+	}
+}
+
+//go:generate mockery --name=Repository --filename=instructors_repository.go
+type Repository interface {
+	AddInstructor(ctx context.Context, instructor Instructor) (Instructor, error)
+	GetByID(ctx context.Context, instructorID string) (Instructor, error)
+	IsNotFoundErr(err error) bool
+	UpdateInstructor(ctx context.Context, instructorID string, updateInstructor UpdateInstructor) (Instructor, error)
+	DeleteInstructor(ctx context.Context, instructorID string) error
+	ListInstructors(ctx context.Context, limit int, offset int) ([]Instructor, error)
+	ListSchedule(ctx context.Context, instructorID string, from time.Time, to time.Time) ([]ScheduledSession, error)
+}
+
+func (u *Usecase) AddInstructor(ctx context.Context, newInstructor NewInstructor) (Instructor, error) {
+	instructor := Instructor{
+		ID:    uuid.NewString(),
+		Name:  strings.TrimSpace(newInstructor.Name),
+		Email: newInstructor.Email,
+	}
+
+	if instructor.Name == "" {
+		return Instructor{}, fmt.Errorf("missing instructor name. %w", ErrInvalidData)
+	}
+
+	addedInstructor, err := u.repository.AddInstructor(ctx, instructor)
+	if err != nil {
+		return Instructor{}, fmt.Errorf("failed to add instructor to repository: %w", err)
+	}
+
+	return addedInstructor, nil
+}
+
+func (u *Usecase) GetByID(ctx context.Context, instructorID string) (Instructor, error) {
+	instructor, err := u.repository.GetByID(ctx, instructorID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Instructor{}, ErrNotFound
+		}
+
+		return Instructor{}, err
+	}
+
+	return instructor, nil
+}
+
+func (u *Usecase) UpdateInstructor(ctx context.Context, instructorID string, updateInstructor UpdateInstructor) (Instructor, error) {
+	if updateInstructor.Name != nil && strings.TrimSpace(*updateInstructor.Name) == "" {
+		return Instructor{}, fmt.Errorf("instructor 'name' cannot be empty: %w", ErrInvalidData)
+	}
+
+	updatedInstructor, err := u.repository.UpdateInstructor(ctx, instructorID, updateInstructor)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Instructor{}, ErrNotFound
+		}
+		return Instructor{}, fmt.Errorf("failed to update instructor in repository: %w", err)
+	}
+
+	return updatedInstructor, nil
+}
+
+// DeleteInstructor deletes the instructor, unassigning them from the classes and sessions they teach.
+func (u *Usecase) DeleteInstructor(ctx context.Context, instructorID string) error {
+	return u.repository.DeleteInstructor(ctx, instructorID)
+}
+
+func (u *Usecase) ListInstructors(ctx context.Context, pageInfo PageInfo) ([]Instructor, error) {
+	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
+		pageInfo.Limit = 100
+	}
+
+	offset := pageInfo.Limit * pageInfo.Page
+	return u.repository.ListInstructors(ctx, pageInfo.Limit, offset)
+}
+
+// Schedule lists the sessions the instructor teaches from one date to another, both inclusive,
+// in the order they start.
+func (u *Usecase) Schedule(ctx context.Context, instructorID string, from time.Time, to time.Time) ([]ScheduledSession, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("schedule 'to' date cannot be before 'from' date: %w", ErrInvalidData)
+	}
+
+	if _, err := u.GetByID(ctx, instructorID); err != nil {
+		return nil, err
+	}
+
+	schedule, err := u.repository.ListSchedule(ctx, instructorID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instructor schedule from repository: %w", err)
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,151 @@
+package instructors_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors"
+	"github.com/daniel-oliveiravas/class-booking-service/business/instructors/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_AddInstructor(t *testing.T) {
+	instructorsRepo := mocks.NewRepository(t)
+	usecase := instructors.NewUsecase(instructorsRepo)
+	tests := []struct {
+		name          string
+		newInstructor instructors.NewInstructor
+		want          instructors.Instructor
+		wantErr       error
+	}{
+		{
+			name: "without_name",
+			newInstructor: instructors.NewInstructor{
+				Name: " ",
+			},
+			want:    instructors.Instructor{},
+			wantErr: instructors.ErrInvalidData,
+		},
+		{
+			name: "valid_instructor",
+			newInstructor: instructors.NewInstructor{
+				Name: uuid.NewString(),
+			},
+			want:    NewInstructor(),
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wantErr == nil {
+				instructorsRepo.On("AddInstructor", mock.Anything, mock.Anything).Return(tt.want, nil).Once()
+			}
+
+			instructor, err := usecase.AddInstructor(ctx, tt.newInstructor)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.Equal(t, tt.want, instructor)
+		})
+	}
+}
+
+func NewInstructor() instructors.Instructor {
+	return instructors.Instructor{
+		ID:   uuid.NewString(),
+		Name: uuid.NewString(),
+	}
+}
+
+func TestUsecase_GetByID(t *testing.T) {
+	ctx := context.Background()
+	instructorsRepo := mocks.NewRepository(t)
+	usecase := instructors.NewUsecase(instructorsRepo)
+
+	expectedInstructor := NewInstructor()
+	instructorsRepo.On("GetByID", mock.Anything, expectedInstructor.ID).Return(expectedInstructor, nil).Once()
+
+	instructor, err := usecase.GetByID(ctx, expectedInstructor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, expectedInstructor, instructor)
+}
+
+func TestUsecase_GetByID_NotFound(t *testing.T) {
+	ctx := context.Background()
+	instructorsRepo := mocks.NewRepository(t)
+	usecase := instructors.NewUsecase(instructorsRepo)
+
+	instructorID := uuid.NewString()
+	instructorsRepo.On("GetByID", mock.Anything, instructorID).Return(instructors.Instructor{}, pgx.ErrNoRows).Once()
+	instructorsRepo.On("IsNotFoundErr", pgx.ErrNoRows).Return(true).Once()
+
+	_, err := usecase.GetByID(ctx, instructorID)
+	require.ErrorIs(t, err, instructors.ErrNotFound)
+}
+
+func TestUsecase_UpdateInstructor_EmptyName(t *testing.T) {
+	ctx := context.Background()
+	instructorsRepo := mocks.NewRepository(t)
+	usecase := instructors.NewUsecase(instructorsRepo)
+
+	name := ""
+	_, err := usecase.UpdateInstructor(ctx, uuid.NewString(), instructors.UpdateInstructor{Name: &name})
+	require.ErrorIs(t, err, instructors.ErrInvalidData)
+}
+
+func TestUsecase_Schedule(t *testing.T) {
+	ctx := context.Background()
+	instructorsRepo := mocks.NewRepository(t)
+	usecase := instructors.NewUsecase(instructorsRepo)
+
+	instructor := NewInstructor()
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	expectedSchedule := []instructors.ScheduledSession{
+		{
+			SessionID: uuid.NewString(),
+			ClassID:   uuid.NewString(),
+			Date:      from,
+		},
+	}
+	instructorsRepo.On("GetByID", mock.Anything, instructor.ID).Return(instructor, nil).Once()
+	instructorsRepo.On("ListSchedule", mock.Anything, instructor.ID, from, to).Return(expectedSchedule, nil).Once()
+
+	schedule, err := usecase.Schedule(ctx, instructor.ID, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, expectedSchedule, schedule)
+}
+
+func TestUsecase_Schedule_InvalidRange(t *testing.T) {
+	ctx := context.Background()
+	instructorsRepo := mocks.NewRepository(t)
+	usecase := instructors.NewUsecase(instructorsRepo)
+
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	_, err := usecase.Schedule(ctx, uuid.NewString(), from, from.AddDate(0, 0, -1))
+	require.ErrorIs(t, err, instructors.ErrInvalidData)
+}
+
+func TestUsecase_Schedule_InstructorNotFound(t *testing.T) {
+	ctx := context.Background()
+	instructorsRepo := mocks.NewRepository(t)
+	usecase := instructors.NewUsecase(instructorsRepo)
+
+	instructorID := uuid.NewString()
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	instructorsRepo.On("GetByID", mock.Anything, instructorID).Return(instructors.Instructor{}, pgx.ErrNoRows).Once()
+	instructorsRepo.On("IsNotFoundErr", pgx.ErrNoRows).Return(true).Once()
+
+	_, err := usecase.Schedule(ctx, instructorID, from, from.AddDate(0, 0, 7))
+	require.ErrorIs(t, err, instructors.ErrNotFound)
+}
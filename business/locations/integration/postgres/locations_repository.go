@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const locationColumns = `id, created_at, updated_at, name, address, timezone`
+
+type LocationsRepository struct {
+	logger *zap.SugaredLogger
+	db     *pgxpool.Pool
+}
+
+func NewLocationsRepository(logger *zap.SugaredLogger, db *pgxpool.Pool) *LocationsRepository {
+	return &LocationsRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *LocationsRepository) AddLocation(ctx context.Context, location locations.Location) (locations.Location, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return locations.Location{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	insertLocation := `INSERT INTO locations (id, name, address, timezone)
+				VALUES ($1, $2, $3, $4)
+				RETURNING ` + locationColumns
+	storedLocation, err := scanLocation(txn.QueryRow(ctx, insertLocation, location.ID, location.Name, location.Address, location.Timezone))
+	if err != nil {
+		return locations.Location{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return locations.Location{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedLocation, nil
+}
+
+func (r *LocationsRepository) GetByID(ctx context.Context, locationID string) (locations.Location, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return locations.Location{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + locationColumns + ` FROM locations WHERE id = $1`
+	location, err := scanLocation(txn.QueryRow(ctx, query, locationID))
+	if err != nil {
+		return locations.Location{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return locations.Location{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return location, nil
+}
+
+func scanLocation(row pgx.Row) (locations.Location, error) {
+	var location locations.Location
+	err := row.Scan(&location.ID, &location.CreatedAt, &location.UpdatedAt, &location.Name, &location.Address, &location.Timezone)
+	if err != nil {
+		return locations.Location{}, fmt.Errorf("failed to scan locations row to locations.Location: %w", err)
+	}
+	return location, nil
+}
+
+func (r *LocationsRepository) IsNotFoundErr(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows)
+}
+
+func (r *LocationsRepository) UpdateLocation(ctx context.Context, locationID string, updateLocation locations.UpdateLocation) (locations.Location, error) {
+	values := make([]interface{}, 0)
+	columns := make([]string, 0)
+
+	if updateLocation.Name != nil {
+		values = append(values, *updateLocation.Name)
+		columns = append(columns, "name")
+	}
+
+	if updateLocation.Address != nil {
+		values = append(values, *updateLocation.Address)
+		columns = append(columns, "address")
+	}
+
+	if updateLocation.Timezone != nil {
+		values = append(values, *updateLocation.Timezone)
+		columns = append(columns, "timezone")
+	}
+
+	updateStatements := make([]string, 0)
+	for index := range columns {
+		updateStatements = append(updateStatements, fmt.Sprintf("%s = $%d", columns[index], index+1))
+	}
+	updateStatements = append(updateStatements, "updated_at = now()")
+
+	values = append(values, locationID)
+	statement := "UPDATE locations SET " + strings.Join(updateStatements, ", ") + fmt.Sprintf(" WHERE id = $%d", len(values)) +
+		" RETURNING " + locationColumns
+
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return locations.Location{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	location, err := scanLocation(txn.QueryRow(ctx, statement, values...))
+	if err != nil {
+		return locations.Location{}, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return locations.Location{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return location, nil
+}
+
+func (r *LocationsRepository) ListLocations(ctx context.Context, limit int, offset int) ([]locations.Location, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + locationColumns + `
+				FROM locations
+			  ORDER BY name, id
+			  LIMIT $1 OFFSET $2`
+	rows, err := txn.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locations: %w", err)
+	}
+
+	allLocations := make([]locations.Location, 0)
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		allLocations = append(allLocations, location)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return allLocations, nil
+}
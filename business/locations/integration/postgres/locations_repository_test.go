@@ -0,0 +1,168 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/classes"
+	pgclasses "github.com/daniel-oliveiravas/class-booking-service/business/classes/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/locations/integration/postgres"
+	"github.com/daniel-oliveiravas/class-booking-service/foundation/postgres"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupIntegration(t *testing.T) *pgxpool.Pool {
+	ctx := context.Background()
+	schema := t.Name()
+	pgCfg := postgres.Config{
+		Host:             "localhost",
+		Port:             5432,
+		DatabaseUser:     "class_booking",
+		DatabasePassword: "class_booking",
+		DatabaseName:     "class_booking_qa",
+		SSLMode:          "none",
+		SearchPath:       schema,
+	}
+	db, err := postgres.Open(ctx, pgCfg)
+	require.NoError(t, err)
+
+	err = postgres.DropAndCreateSchema(ctx, db, schema)
+	require.NoError(t, err)
+
+	err = postgres.Migrate("file://../../../../scripts/db/migrations/", pgCfg)
+	require.NoError(t, err)
+
+	return db
+}
+
+func newLocation() locations.Location {
+	return locations.Location{
+		ID:       uuid.NewString(),
+		Name:     uuid.NewString(),
+		Address:  "Av. Paulista, 1000",
+		Timezone: "America/Sao_Paulo",
+	}
+}
+
+func TestRepository_AddLocation(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewLocationsRepository(logger.Sugar(), db)
+
+	location := newLocation()
+	locationAdded, err := repo.AddLocation(ctx, location)
+	require.NoError(t, err)
+
+	assert.Equal(t, location.ID, locationAdded.ID)
+	assert.Equal(t, location.Address, locationAdded.Address)
+	assert.Equal(t, location.Timezone, locationAdded.Timezone)
+	assert.NotEmpty(t, locationAdded.CreatedAt)
+
+	locationFound, err := repo.GetByID(ctx, location.ID)
+	require.NoError(t, err)
+	assert.Equal(t, locationAdded, locationFound)
+
+	_, err = repo.GetByID(ctx, uuid.NewString())
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_UpdateLocation(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewLocationsRepository(logger.Sugar(), db)
+
+	location, err := repo.AddLocation(ctx, newLocation())
+	require.NoError(t, err)
+
+	timezone := "Europe/Lisbon"
+	updatedLocation, err := repo.UpdateLocation(ctx, location.ID, locations.UpdateLocation{Timezone: &timezone})
+	require.NoError(t, err)
+	assert.Equal(t, timezone, updatedLocation.Timezone)
+	assert.Equal(t, location.Name, updatedLocation.Name)
+
+	_, err = repo.UpdateLocation(ctx, uuid.NewString(), locations.UpdateLocation{Timezone: &timezone})
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_Rooms(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewLocationsRepository(logger.Sugar(), db)
+
+	location, err := repo.AddLocation(ctx, newLocation())
+	require.NoError(t, err)
+	otherLocation, err := repo.AddLocation(ctx, newLocation())
+	require.NoError(t, err)
+
+	room, err := repo.AddRoom(ctx, locations.Room{ID: uuid.NewString(), LocationID: location.ID, Name: "Studio A", Capacity: 20})
+	require.NoError(t, err)
+	_, err = repo.AddRoom(ctx, locations.Room{ID: uuid.NewString(), LocationID: otherLocation.ID, Name: "Studio B", Capacity: 10})
+	require.NoError(t, err)
+
+	roomFound, err := repo.GetRoomByID(ctx, room.ID)
+	require.NoError(t, err)
+	assert.Equal(t, room, roomFound)
+
+	rooms, err := repo.ListRooms(ctx, location.ID, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, rooms, 1)
+	assert.Equal(t, room.ID, rooms[0].ID)
+
+	_, err = repo.GetRoomByID(ctx, uuid.NewString())
+	assert.True(t, repo.IsNotFoundErr(err))
+}
+
+func TestRepository_UpdateRoom_CapacityBelowClasses(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewLocationsRepository(logger.Sugar(), db)
+	classesRepo := pgclasses.NewClassesRepository(logger.Sugar(), db)
+
+	location, err := repo.AddLocation(ctx, newLocation())
+	require.NoError(t, err)
+	room, err := repo.AddRoom(ctx, locations.Room{ID: uuid.NewString(), LocationID: location.ID, Name: "Studio A", Capacity: 20})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	_, err = classesRepo.Add(ctx, classes.Class{ID: uuid.NewString(), Name: uuid.NewString(), StartDate: now, EndDate: now.AddDate(0, 0, 7),
+		Capacity: 15, RoomID: &room.ID})
+	require.NoError(t, err)
+
+	capacity := 10
+	_, err = repo.UpdateRoom(ctx, room.ID, locations.UpdateRoom{Capacity: &capacity})
+	require.ErrorIs(t, err, locations.ErrRoomCapacityBelowClasses)
+
+	capacity = 15
+	updatedRoom, err := repo.UpdateRoom(ctx, room.ID, locations.UpdateRoom{Capacity: &capacity})
+	require.NoError(t, err)
+	assert.Equal(t, 15, updatedRoom.Capacity)
+}
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	"github.com/jackc/pgx/v5"
+)
+
+const roomColumns = `id, created_at, updated_at, location_id, name, capacity`
+
+func (r *LocationsRepository) AddRoom(ctx context.Context, room locations.Room) (locations.Room, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return locations.Room{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	insertRoom := `INSERT INTO rooms (id, location_id, name, capacity)
+				VALUES ($1, $2, $3, $4)
+				RETURNING ` + roomColumns
+	storedRoom, err := scanRoom(txn.QueryRow(ctx, insertRoom, room.ID, room.LocationID, room.Name, room.Capacity))
+	if err != nil {
+		return locations.Room{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return locations.Room{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedRoom, nil
+}
+
+func (r *LocationsRepository) GetRoomByID(ctx context.Context, roomID string) (locations.Room, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return locations.Room{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + roomColumns + ` FROM rooms WHERE id = $1`
+	room, err := scanRoom(txn.QueryRow(ctx, query, roomID))
+	if err != nil {
+		return locations.Room{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return locations.Room{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return room, nil
+}
+
+func scanRoom(row pgx.Row) (locations.Room, error) {
+	var room locations.Room
+	err := row.Scan(&room.ID, &room.CreatedAt, &room.UpdatedAt, &room.LocationID, &room.Name, &room.Capacity)
+	if err != nil {
+		return locations.Room{}, fmt.Errorf("failed to scan rooms row to locations.Room: %w", err)
+	}
+	return room, nil
+}
+
+// UpdateRoom updates the room. It fails with locations.ErrRoomCapacityBelowClasses when the new
+// capacity is below the capacity of an active class or upcoming session taught in the room.
+func (r *LocationsRepository) UpdateRoom(ctx context.Context, roomID string, updateRoom locations.UpdateRoom) (locations.Room, error) {
+	values := make([]interface{}, 0)
+	columns := make([]string, 0)
+
+	if updateRoom.Name != nil {
+		values = append(values, *updateRoom.Name)
+		columns = append(columns, "name")
+	}
+
+	if updateRoom.Capacity != nil {
+		values = append(values, *updateRoom.Capacity)
+		columns = append(columns, "capacity")
+	}
+
+	updateStatements := make([]string, 0)
+	for index := range columns {
+		updateStatements = append(updateStatements, fmt.Sprintf("%s = $%d", columns[index], index+1))
+	}
+	updateStatements = append(updateStatements, "updated_at = now()")
+
+	values = append(values, roomID)
+	statement := "UPDATE rooms SET " + strings.Join(updateStatements, ", ") + fmt.Sprintf(" WHERE id = $%d", len(values)) +
+		" RETURNING " + roomColumns
+
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return locations.Room{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	if updateRoom.Capacity != nil {
+		// Locking the room serializes the update with classes being assigned to the room.
+		lockRoom := `SELECT id FROM rooms WHERE id = $1 FOR UPDATE`
+		err = txn.QueryRow(ctx, lockRoom, roomID).Scan(&roomID)
+		if err != nil {
+			return locations.Room{}, fmt.Errorf("failed to lock room: %w", err)
+		}
+
+		var taught int
+		maxCapacity := `SELECT COALESCE(MAX(capacity), 0)
+						  FROM (SELECT c.capacity
+								  FROM classes c
+								WHERE c.room_id = $1 AND c.archived_at IS NULL
+								UNION ALL
+								SELECT COALESCE(s.capacity, c.capacity)
+								  FROM class_sessions s
+								  JOIN classes c ON c.id = s.class_id
+								WHERE COALESCE(s.room_id, c.room_id) = $1 AND c.archived_at IS NULL
+								  AND s.status = 'scheduled' AND s.ends_at > now()) taught`
+		err = txn.QueryRow(ctx, maxCapacity, roomID).Scan(&taught)
+		if err != nil {
+			return locations.Room{}, fmt.Errorf("failed to get capacity of room classes: %w", err)
+		}
+
+		if *updateRoom.Capacity < taught {
+			return locations.Room{}, fmt.Errorf("room holds a class of capacity %d: %w", taught, locations.ErrRoomCapacityBelowClasses)
+		}
+	}
+
+	room, err := scanRoom(txn.QueryRow(ctx, statement, values...))
+	if err != nil {
+		return locations.Room{}, fmt.Errorf("failed to update room: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return locations.Room{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return room, nil
+}
+
+func (r *LocationsRepository) ListRooms(ctx context.Context, locationID string, limit int, offset int) ([]locations.Room, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + roomColumns + `
+				FROM rooms
+			  WHERE location_id = $1
+			  ORDER BY name, id
+			  LIMIT $2 OFFSET $3`
+	rows, err := txn.Query(ctx, query, locationID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rooms: %w", err)
+	}
+
+	allRooms := make([]locations.Room, 0)
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		allRooms = append(allRooms, room)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return allRooms, nil
+}
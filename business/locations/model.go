@@ -0,0 +1,52 @@
+package locations
+
+import (
+	"time"
+)
+
+type Location struct {
+	ID        string    `json:"id,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Address   string    `json:"address,omitempty"`
+	// Timezone is the IANA name of the location time zone, like America/Sao_Paulo.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type NewLocation struct {
+	Name     string `json:"name,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type UpdateLocation struct {
+	Name     *string `json:"name,omitempty"`
+	Address  *string `json:"address,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// Room is a room of a location. Capacity is how many people the room physically holds.
+type Room struct {
+	ID         string    `json:"id,omitempty"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty"`
+	LocationID string    `json:"locationID,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Capacity   int       `json:"capacity,omitempty"`
+}
+
+type NewRoom struct {
+	Name     string `json:"name,omitempty"`
+	Capacity int    `json:"capacity,omitempty"`
+}
+
+type UpdateRoom struct {
+	Name     *string `json:"name,omitempty"`
+	Capacity *int    `json:"capacity,omitempty"`
+}
+
+type PageInfo struct {
+	Limit int
+	Page  int
+}
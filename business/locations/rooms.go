@@ -0,0 +1,90 @@
+package locations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func (u *Usecase) AddRoom(ctx context.Context, locationID string, newRoom NewRoom) (Room, error) {
+	room := Room{
+		ID:         uuid.NewString(),
+		LocationID: locationID,
+		Name:       strings.TrimSpace(newRoom.Name),
+		Capacity:   newRoom.Capacity,
+	}
+
+	if room.Name == "" {
+		return Room{}, fmt.Errorf("missing room name. %w", ErrInvalidData)
+	}
+
+	if room.Capacity <= 0 {
+		return Room{}, fmt.Errorf("room capacity must be greater than 0. %w", ErrInvalidData)
+	}
+
+	if _, err := u.GetByID(ctx, locationID); err != nil {
+		return Room{}, err
+	}
+
+	addedRoom, err := u.repository.AddRoom(ctx, room)
+	if err != nil {
+		return Room{}, fmt.Errorf("failed to add room to repository: %w", err)
+	}
+
+	return addedRoom, nil
+}
+
+func (u *Usecase) GetRoomByID(ctx context.Context, roomID string) (Room, error) {
+	room, err := u.repository.GetRoomByID(ctx, roomID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Room{}, ErrRoomNotFound
+		}
+
+		return Room{}, err
+	}
+
+	return room, nil
+}
+
+// UpdateRoom updates the room. Its capacity can't go below the capacity of the classes and
+// upcoming sessions taught in it.
+func (u *Usecase) UpdateRoom(ctx context.Context, roomID string, updateRoom UpdateRoom) (Room, error) {
+	if updateRoom.Name != nil && strings.TrimSpace(*updateRoom.Name) == "" {
+		return Room{}, fmt.Errorf("room 'name' cannot be empty: %w", ErrInvalidData)
+	}
+
+	if updateRoom.Capacity != nil && *updateRoom.Capacity <= 0 {
+		return Room{}, fmt.Errorf("room capacity must be greater than 0: %w", ErrInvalidData)
+	}
+
+	updatedRoom, err := u.repository.UpdateRoom(ctx, roomID, updateRoom)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Room{}, ErrRoomNotFound
+		}
+		if errors.Is(err, ErrRoomCapacityBelowClasses) {
+			return Room{}, err
+		}
+		return Room{}, fmt.Errorf("failed to update room in repository: %w", err)
+	}
+
+	return updatedRoom, nil
+}
+
+// ListRooms lists the rooms of the location.
+func (u *Usecase) ListRooms(ctx context.Context, locationID string, pageInfo PageInfo) ([]Room, error) {
+	if _, err := u.GetByID(ctx, locationID); err != nil {
+		return nil, err
+	}
+
+	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
+		pageInfo.Limit = 100
+	}
+
+	offset := pageInfo.Limit * pageInfo.Page
+	return u.repository.ListRooms(ctx, locationID, pageInfo.Limit, offset)
+}
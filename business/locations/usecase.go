@@ -0,0 +1,137 @@
+package locations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidData  = errors.New("invalid data")
+	ErrNotFound     = errors.New("not found")
+	ErrRoomNotFound = errors.New("room not found")
+	// ErrRoomCapacityBelowClasses is returned when shrinking a room below the capacity of the
+	// classes or upcoming sessions taught in it.
+	ErrRoomCapacityBelowClasses = errors.New("room capacity is below the capacity of its classes")
+)
+
+type Usecase struct {
+	repository Repository
+}
+
+func NewUsecase(repository Repository) *Usecase {
+	return &Usecase{
+		repository: repository,
+	}
+}
+
+//go:generate mockery --name=Repository --filename=locations_repository.go
+type Repository interface {
+	AddLocation(ctx context.Context, location Location) (Location, error)
+	GetByID(ctx context.Context, locationID string) (Location, error)
+	IsNotFoundErr(err error) bool
+	UpdateLocation(ctx context.Context, locationID string, updateLocation UpdateLocation) (Location, error)
+	ListLocations(ctx context.Context, limit int, offset int) ([]Location, error)
+	AddRoom(ctx context.Context, room Room) (Room, error)
+	GetRoomByID(ctx context.Context, roomID string) (Room, error)
+	UpdateRoom(ctx context.Context, roomID string, updateRoom UpdateRoom) (Room, error)
+	ListRooms(ctx context.Context, locationID string, limit int, offset int) ([]Room, error)
+}
+
+func (u *Usecase) AddLocation(ctx context.Context, newLocation NewLocation) (Location, error) {
+	location := Location{
+		ID:       uuid.NewString(),
+		Name:     strings.TrimSpace(newLocation.Name),
+		Address:  strings.TrimSpace(newLocation.Address),
+		Timezone: newLocation.Timezone,
+	}
+
+	if err := validateLocation(location); err != nil {
+		return Location{}, err
+	}
+
+	addedLocation, err := u.repository.AddLocation(ctx, location)
+	if err != nil {
+		return Location{}, fmt.Errorf("failed to add location to repository: %w", err)
+	}
+
+	return addedLocation, nil
+}
+
+func (u *Usecase) GetByID(ctx context.Context, locationID string) (Location, error) {
+	location, err := u.repository.GetByID(ctx, locationID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Location{}, ErrNotFound
+		}
+
+		return Location{}, err
+	}
+
+	return location, nil
+}
+
+func (u *Usecase) UpdateLocation(ctx context.Context, locationID string, updateLocation UpdateLocation) (Location, error) {
+	if updateLocation.Name != nil && strings.TrimSpace(*updateLocation.Name) == "" {
+		return Location{}, fmt.Errorf("location 'name' cannot be empty: %w", ErrInvalidData)
+	}
+
+	if updateLocation.Address != nil && strings.TrimSpace(*updateLocation.Address) == "" {
+		return Location{}, fmt.Errorf("location 'address' cannot be empty: %w", ErrInvalidData)
+	}
+
+	if updateLocation.Timezone != nil {
+		if err := validateTimezone(*updateLocation.Timezone); err != nil {
+			return Location{}, err
+		}
+	}
+
+	updatedLocation, err := u.repository.UpdateLocation(ctx, locationID, updateLocation)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Location{}, ErrNotFound
+		}
+		return Location{}, fmt.Errorf("failed to update location in repository: %w", err)
+	}
+
+	return updatedLocation, nil
+}
+
+func (u *Usecase) ListLocations(ctx context.Context, pageInfo PageInfo) ([]Location, error) {
+	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
+		pageInfo.Limit = 100
+	}
+
+	offset := pageInfo.Limit * pageInfo.Page
+	return u.repository.ListLocations(ctx, pageInfo.Limit, offset)
+}
+
+func validateLocation(location Location) error {
+	if location.Name == "" {
+		return fmt.Errorf("missing location name. %w", ErrInvalidData)
+	}
+
+	if location.Address == "" {
+		return fmt.Errorf("missing location address. %w", ErrInvalidData)
+	}
+
+	return validateTimezone(location.Timezone)
+}
+
+// validateTimezone checks the timezone is an IANA time zone name. UTC is accepted, but the empty
+// name and Local aren't, since they depend on where the service runs.
+func validateTimezone(timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return fmt.Errorf("location 'timezone' must be an IANA time zone name: %w", ErrInvalidData)
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("unknown location timezone %q: %w", timezone, ErrInvalidData)
+	}
+
+	return nil
+}
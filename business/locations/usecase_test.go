@@ -0,0 +1,141 @@
+package locations_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations"
+	"github.com/daniel-oliveiravas/class-booking-service/business/locations/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_AddLocation(t *testing.T) {
+	locationsRepo := mocks.NewRepository(t)
+	usecase := locations.NewUsecase(locationsRepo)
+	tests := []struct {
+		name        string
+		newLocation locations.NewLocation
+		wantErr     error
+	}{
+		{
+			name:        "without_name",
+			newLocation: locations.NewLocation{Address: "Av. Paulista, 1000", Timezone: "America/Sao_Paulo"},
+			wantErr:     locations.ErrInvalidData,
+		},
+		{
+			name:        "without_address",
+			newLocation: locations.NewLocation{Name: uuid.NewString(), Timezone: "America/Sao_Paulo"},
+			wantErr:     locations.ErrInvalidData,
+		},
+		{
+			name:        "without_timezone",
+			newLocation: locations.NewLocation{Name: uuid.NewString(), Address: "Av. Paulista, 1000"},
+			wantErr:     locations.ErrInvalidData,
+		},
+		{
+			name:        "local_timezone",
+			newLocation: locations.NewLocation{Name: uuid.NewString(), Address: "Av. Paulista, 1000", Timezone: "Local"},
+			wantErr:     locations.ErrInvalidData,
+		},
+		{
+			name:        "unknown_timezone",
+			newLocation: locations.NewLocation{Name: uuid.NewString(), Address: "Av. Paulista, 1000", Timezone: "America/Atlantis"},
+			wantErr:     locations.ErrInvalidData,
+		},
+		{
+			name:        "valid_location",
+			newLocation: locations.NewLocation{Name: uuid.NewString(), Address: "Av. Paulista, 1000", Timezone: "America/Sao_Paulo"},
+			wantErr:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wantErr == nil {
+				locationsRepo.On("AddLocation", mock.Anything, mock.Anything).Return(func(_ context.Context, location locations.Location) locations.Location {
+					return location
+				}, nil).Once()
+			}
+
+			location, err := usecase.AddLocation(ctx, tt.newLocation)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, location.ID)
+			assert.Equal(t, tt.newLocation.Timezone, location.Timezone)
+		})
+	}
+}
+
+func TestUsecase_UpdateLocation_InvalidTimezone(t *testing.T) {
+	ctx := context.Background()
+	locationsRepo := mocks.NewRepository(t)
+	usecase := locations.NewUsecase(locationsRepo)
+
+	timezone := "GMT-3"
+	_, err := usecase.UpdateLocation(ctx, uuid.NewString(), locations.UpdateLocation{Timezone: &timezone})
+	require.ErrorIs(t, err, locations.ErrInvalidData)
+}
+
+func TestUsecase_AddRoom(t *testing.T) {
+	ctx := context.Background()
+	locationsRepo := mocks.NewRepository(t)
+	usecase := locations.NewUsecase(locationsRepo)
+
+	location := locations.Location{ID: uuid.NewString(), Name: uuid.NewString()}
+	_, err := usecase.AddRoom(ctx, location.ID, locations.NewRoom{Name: uuid.NewString()})
+	require.ErrorIs(t, err, locations.ErrInvalidData)
+
+	locationsRepo.On("GetByID", mock.Anything, location.ID).Return(location, nil).Once()
+	locationsRepo.On("AddRoom", mock.Anything, mock.Anything).Return(func(_ context.Context, room locations.Room) locations.Room {
+		return room
+	}, nil).Once()
+
+	room, err := usecase.AddRoom(ctx, location.ID, locations.NewRoom{Name: uuid.NewString(), Capacity: 25})
+	require.NoError(t, err)
+	assert.Equal(t, location.ID, room.LocationID)
+	assert.Equal(t, 25, room.Capacity)
+}
+
+func TestUsecase_AddRoom_LocationNotFound(t *testing.T) {
+	ctx := context.Background()
+	locationsRepo := mocks.NewRepository(t)
+	usecase := locations.NewUsecase(locationsRepo)
+
+	locationID := uuid.NewString()
+	locationsRepo.On("GetByID", mock.Anything, locationID).Return(locations.Location{}, pgx.ErrNoRows).Once()
+	locationsRepo.On("IsNotFoundErr", pgx.ErrNoRows).Return(true).Once()
+
+	_, err := usecase.AddRoom(ctx, locationID, locations.NewRoom{Name: uuid.NewString(), Capacity: 25})
+	require.ErrorIs(t, err, locations.ErrNotFound)
+}
+
+func TestUsecase_UpdateRoom_CapacityBelowClasses(t *testing.T) {
+	ctx := context.Background()
+	locationsRepo := mocks.NewRepository(t)
+	usecase := locations.NewUsecase(locationsRepo)
+
+	roomID := uuid.NewString()
+	capacity := 10
+	updateRoom := locations.UpdateRoom{Capacity: &capacity}
+	repoErr := fmt.Errorf("room holds a class of capacity 20: %w", locations.ErrRoomCapacityBelowClasses)
+	locationsRepo.On("UpdateRoom", mock.Anything, roomID, updateRoom).Return(locations.Room{}, repoErr).Once()
+	locationsRepo.On("IsNotFoundErr", repoErr).Return(false).Once()
+
+	_, err := usecase.UpdateRoom(ctx, roomID, updateRoom)
+	require.ErrorIs(t, err, locations.ErrRoomCapacityBelowClasses)
+
+	capacity = 0
+	_, err = usecase.UpdateRoom(ctx, roomID, updateRoom)
+	require.ErrorIs(t, err, locations.ErrInvalidData)
+}
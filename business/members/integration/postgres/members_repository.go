@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/daniel-oliveiravas/class-booking-service/business/members"
 	"github.com/jackc/pgx/v5"
@@ -11,6 +12,8 @@ import (
 	"go.uber.org/zap"
 )
 
+const memberColumns = `id, created_at, updated_at, name, credits, strikes, plan_id, version`
+
 type MembersRepository struct {
 	logger *zap.SugaredLogger
 	db     *pgxpool.Pool
@@ -33,15 +36,14 @@ func (r *MembersRepository) AddMember(ctx context.Context, member members.Member
 
 	defer tx.Rollback(ctx)
 
-	insertMember := `INSERT INTO members (id, name) 
-				VALUES ($1, $2)
-				RETURNING id, name, created_at, updated_at`
-	row := tx.QueryRow(ctx, insertMember, member.ID, member.Name)
+	insertMember := `INSERT INTO members (id, name, credits, plan_id) 
+				VALUES ($1, $2, $3, $4)
+				RETURNING ` + memberColumns
+	row := tx.QueryRow(ctx, insertMember, member.ID, member.Name, member.Credits, member.PlanID)
 
-	var storedMember members.Member
-	err = row.Scan(&storedMember.ID, &storedMember.Name, &storedMember.CreatedAt, &storedMember.UpdatedAt)
+	storedMember, err := scanMember(row)
 	if err != nil {
-		return members.Member{}, fmt.Errorf("failed to scan members row to members.Member: %w", err)
+		return members.Member{}, err
 	}
 	err = tx.Commit(ctx)
 	if err != nil {
@@ -72,47 +74,111 @@ func (r *MembersRepository) GetByID(ctx context.Context, memberID string) (membe
 }
 
 func (r *MembersRepository) getByIdTxn(ctx context.Context, txn pgx.Tx, memberID string) (members.Member, error) {
-	query := `SELECT id, created_at, updated_at, name FROM members WHERE id = $1;`
+	query := `SELECT ` + memberColumns + ` FROM members WHERE id = $1;`
 
 	row := txn.QueryRow(ctx, query, memberID)
+	return scanMember(row)
+}
+
+func scanMember(row pgx.Row) (members.Member, error) {
 	var member members.Member
-	err := row.Scan(&member.ID, &member.CreatedAt, &member.UpdatedAt, &member.Name)
+	err := row.Scan(memberFields(&member)...)
 	if err != nil {
 		return members.Member{}, fmt.Errorf("failed to scan members row to members.Member: %w", err)
 	}
 	return member, nil
 }
 
+// memberFields returns the fields of the member memberColumns are scanned into.
+func memberFields(member *members.Member) []interface{} {
+	return []interface{}{&member.ID, &member.CreatedAt, &member.UpdatedAt, &member.Name, &member.Credits, &member.Strikes, &member.PlanID, &member.Version}
+}
+
 func (r *MembersRepository) IsNotFoundErr(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows)
 }
 
 func (r *MembersRepository) UpdateMember(ctx context.Context, memberID string, updateMember members.UpdateMember) (members.Member, error) {
-	var member members.Member
+	values := make([]interface{}, 0)
+	columns := make([]string, 0)
+
 	if updateMember.Name != nil {
-		txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
-			AccessMode: pgx.ReadWrite,
-		})
+		values = append(values, *updateMember.Name)
+		columns = append(columns, "name")
+	}
+
+	if updateMember.Credits != nil {
+		values = append(values, *updateMember.Credits)
+		columns = append(columns, "credits")
+	}
+
+	if updateMember.Strikes != nil {
+		values = append(values, *updateMember.Strikes)
+		columns = append(columns, "strikes")
+	}
+
+	if updateMember.PlanID != nil {
+		var planID *string
+		if *updateMember.PlanID != "" {
+			planID = updateMember.PlanID
+		}
+		values = append(values, planID)
+		columns = append(columns, "plan_id")
+	}
+
+	if len(values) == 0 {
+		member, err := r.GetByID(ctx, memberID)
 		if err != nil {
-			return members.Member{}, fmt.Errorf("failed to begin transaction: %w", err)
+			return members.Member{}, err
 		}
 
-		defer txn.Rollback(ctx)
+		if !updateMember.MatchesVersion(member.Version) {
+			return members.Member{}, fmt.Errorf("member is at version %d: %w", member.Version, members.ErrVersionMismatch)
+		}
 
-		statement := `UPDATE members SET name = $1, updated_at = now() WHERE id = $2
-						RETURNING id, name, created_at, updated_at`
-		row := txn.QueryRow(ctx, statement, updateMember.Name, memberID)
+		return member, nil
+	}
+
+	updateStatements := make([]string, 0)
+	for index := range columns {
+		updateStatements = append(updateStatements, fmt.Sprintf("%s = $%d", columns[index], index+1))
+	}
 
-		err = row.Scan(&member.ID, &member.Name, &member.CreatedAt, &member.UpdatedAt)
+	values = append(values, memberID)
+	statement := "UPDATE members SET " + strings.Join(updateStatements, ", ") + fmt.Sprintf(", updated_at = now(), version = version + 1 WHERE id = $%d", len(values)) +
+		" RETURNING " + memberColumns
+
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return members.Member{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	if updateMember.IfVersions != nil {
+		var version int
+		lockMember := `SELECT version FROM members WHERE id = $1 FOR UPDATE`
+		err = txn.QueryRow(ctx, lockMember, memberID).Scan(&version)
 		if err != nil {
-			return members.Member{}, fmt.Errorf("failed to update member: %w", err)
+			return members.Member{}, fmt.Errorf("failed to lock member: %w", err)
 		}
 
-		if err := txn.Commit(ctx); err != nil {
-			return members.Member{}, fmt.Errorf("failed to commit transaction. :%w", err)
+		if !updateMember.MatchesVersion(version) {
+			return members.Member{}, fmt.Errorf("member is at version %d: %w", version, members.ErrVersionMismatch)
 		}
 	}
 
+	member, err := scanMember(txn.QueryRow(ctx, statement, values...))
+	if err != nil {
+		return members.Member{}, fmt.Errorf("failed to update member: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return members.Member{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
 	return member, nil
 }
 
@@ -147,7 +213,7 @@ func (r *MembersRepository) ListMembers(ctx context.Context, limit int, offset i
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	query := `SELECT id, created_at, updated_at, name
+	query := `SELECT ` + memberColumns + `
 				FROM members m
 			  LIMIT $1 OFFSET $2;`
 
@@ -158,10 +224,9 @@ func (r *MembersRepository) ListMembers(ctx context.Context, limit int, offset i
 
 	allMembers := make([]members.Member, 0)
 	for rows.Next() {
-		var member members.Member
-		err := rows.Scan(&member.ID, &member.CreatedAt, &member.UpdatedAt, &member.Name)
+		member, err := scanMember(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan members row to members.Member: %w", err)
+			return nil, err
 		}
 
 		allMembers = append(allMembers, member)
@@ -173,3 +238,62 @@ func (r *MembersRepository) ListMembers(ctx context.Context, limit int, offset i
 
 	return allMembers, nil
 }
+
+// memberSearchThreshold is how similar to the query, from 0 to 1, a member name or one of its words
+// has to be to match. It's the default pg_trgm similarity_threshold, set anyway in case the server
+// changes it, while word_similarity_threshold is lowered to it from its 0.6 default so misspelled
+// words of longer names still match.
+const memberSearchThreshold = "0.3"
+
+// likeEscaper escapes the LIKE wildcards of a query matched as a substring.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchMembers finds the members whose name contains the query or is similar to it or has a word
+// similar to it, ranked by the best of both similarities. The pg_trgm operators are qualified,
+// since the extension lives in the public schema.
+func (r *MembersRepository) SearchMembers(ctx context.Context, query string, limit int, offset int) ([]members.SearchResult, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	setThresholds := `SELECT set_config('pg_trgm.similarity_threshold', $1, true), set_config('pg_trgm.word_similarity_threshold', $1, true)`
+	if _, err := txn.Exec(ctx, setThresholds, memberSearchThreshold); err != nil {
+		return nil, fmt.Errorf("failed to set similarity thresholds: %w", err)
+	}
+
+	search := `SELECT ` + memberColumns + `,
+					GREATEST(public.similarity(name, $1), public.word_similarity($1, name)) AS rank
+				FROM members
+			  WHERE name ILIKE $2 OR name OPERATOR(public.%) $1 OR $1 OPERATOR(public.<%) name
+			  ORDER BY rank DESC, name, id
+			  LIMIT $3 OFFSET $4`
+	rows, err := txn.Query(ctx, search, query, "%"+likeEscaper.Replace(query)+"%", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search members: %w", err)
+	}
+
+	results := make([]members.SearchResult, 0)
+	for rows.Next() {
+		var result members.SearchResult
+		err := rows.Scan(append(memberFields(&result.Member), &result.Rank)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan members row to members.SearchResult: %w", err)
+		}
+
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search members: %w", err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return results, nil
+}
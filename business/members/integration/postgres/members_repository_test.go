@@ -179,3 +179,88 @@ func TestRepository_ListMembers(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, allMembers)
 }
+
+func TestRepository_UpdateMember_IfVersion(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewMembersRepository(logger.Sugar(), db)
+
+	member, err := repo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, member.Version)
+
+	newName := uuid.NewString()
+	memberUpdated, err := repo.UpdateMember(ctx, member.ID, members.UpdateMember{Name: &newName, IfVersions: []int{member.Version}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, memberUpdated.Version)
+
+	staleName := uuid.NewString()
+	_, err = repo.UpdateMember(ctx, member.ID, members.UpdateMember{Name: &staleName, IfVersions: []int{member.Version}})
+	require.ErrorIs(t, err, members.ErrVersionMismatch)
+
+	memberFound, err := repo.GetByID(ctx, member.ID)
+	require.NoError(t, err)
+	assert.Equal(t, newName, memberFound.Name)
+	assert.Equal(t, 2, memberFound.Version)
+}
+
+func TestRepository_SearchMembers(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewMembersRepository(logger.Sugar(), db)
+
+	addMember := func(name string) members.Member {
+		member, err := repo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: name})
+		require.NoError(t, err)
+		return member
+	}
+	marina := addMember("Marina Flores")
+	mariana := addMember("Mariana Costa")
+	addMember("Joao Pereira")
+	percent := addMember("100% Effort")
+
+	results, err := repo.SearchMembers(ctx, "Marina", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, marina.ID, results[0].Member.ID)
+	assert.Equal(t, "Marina Flores", results[0].Member.Name)
+	assert.Equal(t, mariana.ID, results[1].Member.ID)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+
+	// Misspelled names still match.
+	results, err = repo.SearchMembers(ctx, "florez", 10, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, marina.ID, results[0].Member.ID)
+
+	// Partial names match as substrings.
+	results, err = repo.SearchMembers(ctx, "cost", 10, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, mariana.ID, results[0].Member.ID)
+
+	// LIKE wildcards in the query are matched literally.
+	results, err = repo.SearchMembers(ctx, "0%", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, percent.ID, results[0].Member.ID)
+
+	page, err := repo.SearchMembers(ctx, "Marina", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, mariana.ID, page[0].Member.ID)
+
+	results, err = repo.SearchMembers(ctx, "Zebediah", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	"github.com/jackc/pgx/v5"
+)
+
+const planColumns = `id, created_at, updated_at, name, max_active_bookings, max_bookings_per_day, max_bookings_per_class_per_week, max_guests_per_booking`
+
+func scanPlan(row pgx.Row) (members.Plan, error) {
+	var plan members.Plan
+	err := row.Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt, &plan.Name,
+		&plan.Limits.MaxActiveBookings, &plan.Limits.MaxBookingsPerDay, &plan.Limits.MaxBookingsPerClassPerWeek, &plan.Limits.MaxGuestsPerBooking)
+	if err != nil {
+		return members.Plan{}, fmt.Errorf("failed to scan plans row to members.Plan: %w", err)
+	}
+	return plan, nil
+}
+
+func (r *MembersRepository) AddPlan(ctx context.Context, plan members.Plan) (members.Plan, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return members.Plan{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	insertPlan := `INSERT INTO plans (id, name, max_active_bookings, max_bookings_per_day, max_bookings_per_class_per_week, max_guests_per_booking)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				RETURNING ` + planColumns
+	row := txn.QueryRow(ctx, insertPlan, plan.ID, plan.Name,
+		plan.Limits.MaxActiveBookings, plan.Limits.MaxBookingsPerDay, plan.Limits.MaxBookingsPerClassPerWeek, plan.Limits.MaxGuestsPerBooking)
+
+	storedPlan, err := scanPlan(row)
+	if err != nil {
+		return members.Plan{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return members.Plan{}, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return storedPlan, nil
+}
+
+func (r *MembersRepository) GetPlanByID(ctx context.Context, planID string) (members.Plan, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return members.Plan{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + planColumns + ` FROM plans WHERE id = $1;`
+	plan, err := scanPlan(txn.QueryRow(ctx, query, planID))
+	if err != nil {
+		return members.Plan{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return members.Plan{}, fmt.Errorf("failed to get plan by ID: %w", err)
+	}
+
+	return plan, nil
+}
+
+func (r *MembersRepository) ListPlans(ctx context.Context, limit int, offset int) ([]members.Plan, error) {
+	txn, err := r.db.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer txn.Rollback(ctx)
+
+	query := `SELECT ` + planColumns + `
+				FROM plans
+			  ORDER BY name, id
+			  LIMIT $1 OFFSET $2;`
+	rows, err := txn.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plans: %w", err)
+	}
+
+	plans := make([]members.Plan, 0)
+	for rows.Next() {
+		plan, err := scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		plans = append(plans, plan)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction. :%w", err)
+	}
+
+	return plans, nil
+}
@@ -0,0 +1,48 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	pgrepo "github.com/daniel-oliveiravas/class-booking-service/business/members/integration/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRepository_AddPlan(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+	db := setupIntegration(t)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	repo := pgrepo.NewMembersRepository(logger.Sugar(), db)
+
+	maxPerDay := 2
+	plan := members.Plan{
+		ID:     uuid.NewString(),
+		Name:   uuid.NewString(),
+		Limits: members.BookingLimits{MaxBookingsPerDay: &maxPerDay},
+	}
+	_, err := repo.AddPlan(ctx, plan)
+	require.NoError(t, err)
+
+	planFound, err := repo.GetPlanByID(ctx, plan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, plan.Name, planFound.Name)
+	assert.Equal(t, plan.Limits, planFound.Limits)
+
+	memberAdded, err := repo.AddMember(ctx, members.Member{ID: uuid.NewString(), Name: uuid.NewString(), PlanID: &plan.ID})
+	require.NoError(t, err)
+	assert.Equal(t, &plan.ID, memberAdded.PlanID)
+
+	noPlan := ""
+	memberUpdated, err := repo.UpdateMember(ctx, memberAdded.ID, members.UpdateMember{PlanID: &noPlan})
+	require.NoError(t, err)
+	assert.Nil(t, memberUpdated.PlanID)
+}
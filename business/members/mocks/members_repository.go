@@ -38,6 +38,30 @@ func (_m *Repository) AddMember(ctx context.Context, member members.Member) (mem
 	return r0, r1
 }
 
+// AddPlan provides a mock function with given fields: ctx, plan
+func (_m *Repository) AddPlan(ctx context.Context, plan members.Plan) (members.Plan, error) {
+	ret := _m.Called(ctx, plan)
+
+	var r0 members.Plan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, members.Plan) (members.Plan, error)); ok {
+		return rf(ctx, plan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, members.Plan) members.Plan); ok {
+		r0 = rf(ctx, plan)
+	} else {
+		r0 = ret.Get(0).(members.Plan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, members.Plan) error); ok {
+		r1 = rf(ctx, plan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteMember provides a mock function with given fields: ctx, memberID
 func (_m *Repository) DeleteMember(ctx context.Context, memberID string) error {
 	ret := _m.Called(ctx, memberID)
@@ -76,6 +100,30 @@ func (_m *Repository) GetByID(ctx context.Context, memberID string) (members.Mem
 	return r0, r1
 }
 
+// GetPlanByID provides a mock function with given fields: ctx, planID
+func (_m *Repository) GetPlanByID(ctx context.Context, planID string) (members.Plan, error) {
+	ret := _m.Called(ctx, planID)
+
+	var r0 members.Plan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (members.Plan, error)); ok {
+		return rf(ctx, planID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) members.Plan); ok {
+		r0 = rf(ctx, planID)
+	} else {
+		r0 = ret.Get(0).(members.Plan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, planID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsNotFoundErr provides a mock function with given fields: err
 func (_m *Repository) IsNotFoundErr(err error) bool {
 	ret := _m.Called(err)
@@ -116,6 +164,58 @@ func (_m *Repository) ListMembers(ctx context.Context, limit int, offset int) ([
 	return r0, r1
 }
 
+// ListPlans provides a mock function with given fields: ctx, limit, offset
+func (_m *Repository) ListPlans(ctx context.Context, limit int, offset int) ([]members.Plan, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	var r0 []members.Plan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]members.Plan, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []members.Plan); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]members.Plan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchMembers provides a mock function with given fields: ctx, query, limit, offset
+func (_m *Repository) SearchMembers(ctx context.Context, query string, limit int, offset int) ([]members.SearchResult, error) {
+	ret := _m.Called(ctx, query, limit, offset)
+
+	var r0 []members.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]members.SearchResult, error)); ok {
+		return rf(ctx, query, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []members.SearchResult); ok {
+		r0 = rf(ctx, query, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]members.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, query, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateMember provides a mock function with given fields: ctx, memberID, updateMember
 func (_m *Repository) UpdateMember(ctx context.Context, memberID string, updateMember members.UpdateMember) (members.Member, error) {
 	ret := _m.Called(ctx, memberID, updateMember)
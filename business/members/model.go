@@ -11,14 +11,102 @@ type Member struct {
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 	UpdatedAt time.Time `json:"updatedAt,omitempty"`
 	Name      string    `json:"name,omitempty"`
+	// Credits is the member's balance of class credits.
+	Credits int `json:"credits"`
+	// Strikes counts the penalties the member got for late cancellations.
+	Strikes int `json:"strikes"`
+	// PlanID is the plan the member is subscribed to, if any.
+	PlanID *string `json:"planID,omitempty"`
+	// Version counts the updates of the member.
+	Version int `json:"version,omitempty"`
+}
+
+// SearchResult is a member matching a search, along with how similar their name is to the query,
+// from 0 to 1.
+type SearchResult struct {
+	Member Member  `json:"member"`
+	Rank   float64 `json:"rank"`
 }
 
 type NewMember struct {
-	Name string `json:"name,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Credits int     `json:"credits,omitempty"`
+	PlanID  *string `json:"planID,omitempty"`
 }
 
 type UpdateMember struct {
-	Name *string `json:"name,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Credits *int    `json:"credits,omitempty"`
+	Strikes *int    `json:"strikes,omitempty"`
+	// PlanID changes the plan of the member. An empty plan ID removes the member from their plan.
+	PlanID *string `json:"planID,omitempty"`
+	// IfVersions makes the update fail with ErrVersionMismatch unless the member is still at one of
+	// these versions. The update is unconditional without them.
+	IfVersions []int `json:"-"`
+}
+
+// MatchesVersion tells whether the update applies to the member at the version.
+func (u UpdateMember) MatchesVersion(version int) bool {
+	if u.IfVersions == nil {
+		return true
+	}
+
+	for _, ifVersion := range u.IfVersions {
+		if ifVersion == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BookingLimits caps how much a member can book. Limits that aren't set don't apply.
+type BookingLimits struct {
+	// MaxActiveBookings is how many upcoming booked sessions a member can hold at once.
+	MaxActiveBookings *int `json:"maxActiveBookings,omitempty"`
+	MaxBookingsPerDay *int `json:"maxBookingsPerDay,omitempty"`
+	// MaxBookingsPerClassPerWeek is how many sessions of the same class a member can book in a
+	// week, with weeks starting on Monday.
+	MaxBookingsPerClassPerWeek *int `json:"maxBookingsPerClassPerWeek,omitempty"`
+	// MaxGuestsPerBooking is how many guests a member can bring to a session. Zero keeps the
+	// member from bringing guests.
+	MaxGuestsPerBooking *int `json:"maxGuestsPerBooking,omitempty"`
+}
+
+// Or returns the limits, taking the ones that aren't set from fallback.
+func (l BookingLimits) Or(fallback BookingLimits) BookingLimits {
+	if l.MaxActiveBookings == nil {
+		l.MaxActiveBookings = fallback.MaxActiveBookings
+	}
+
+	if l.MaxBookingsPerDay == nil {
+		l.MaxBookingsPerDay = fallback.MaxBookingsPerDay
+	}
+
+	if l.MaxBookingsPerClassPerWeek == nil {
+		l.MaxBookingsPerClassPerWeek = fallback.MaxBookingsPerClassPerWeek
+	}
+
+	if l.MaxGuestsPerBooking == nil {
+		l.MaxGuestsPerBooking = fallback.MaxGuestsPerBooking
+	}
+
+	return l
+}
+
+// Plan is a membership plan. Members on a plan follow its booking limits instead of the
+// studio-wide ones, for the limits the plan sets.
+type Plan struct {
+	ID        string        `json:"id,omitempty"`
+	CreatedAt time.Time     `json:"createdAt,omitempty"`
+	UpdatedAt time.Time     `json:"updatedAt,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	Limits    BookingLimits `json:"limits"`
+}
+
+type NewPlan struct {
+	Name   string        `json:"name,omitempty"`
+	Limits BookingLimits `json:"limits"`
 }
 
 type PageInfo struct {
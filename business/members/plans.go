@@ -0,0 +1,87 @@
+package members
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (u *Usecase) AddPlan(ctx context.Context, newPlan NewPlan) (Plan, error) {
+	plan := Plan{
+		ID:     uuid.NewString(),
+		Name:   newPlan.Name,
+		Limits: newPlan.Limits,
+	}
+
+	if err := validatePlan(plan); err != nil {
+		return Plan{}, err
+	}
+
+	addedPlan, err := u.repository.AddPlan(ctx, plan)
+	if err != nil {
+		return Plan{}, fmt.Errorf("failed to add plan to repository: %w", err)
+	}
+
+	return addedPlan, nil
+}
+
+func (u *Usecase) GetPlanByID(ctx context.Context, planID string) (Plan, error) {
+	plan, err := u.repository.GetPlanByID(ctx, planID)
+	if err != nil {
+		if u.repository.IsNotFoundErr(err) {
+			return Plan{}, ErrPlanNotFound
+		}
+
+		return Plan{}, err
+	}
+
+	return plan, nil
+}
+
+func (u *Usecase) ListPlans(ctx context.Context, pageInfo PageInfo) ([]Plan, error) {
+	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
+		pageInfo.Limit = 100
+	}
+
+	offset := pageInfo.Limit * pageInfo.Page
+	return u.repository.ListPlans(ctx, pageInfo.Limit, offset)
+}
+
+// BookingLimits returns the booking limits of the member, taking the ones their plan doesn't set
+// from fallback.
+func (u *Usecase) BookingLimits(ctx context.Context, member Member, fallback BookingLimits) (BookingLimits, error) {
+	if member.PlanID == nil {
+		return fallback, nil
+	}
+
+	plan, err := u.GetPlanByID(ctx, *member.PlanID)
+	if err != nil {
+		return BookingLimits{}, fmt.Errorf("failed to get member plan: %w", err)
+	}
+
+	return plan.Limits.Or(fallback), nil
+}
+
+func validatePlan(plan Plan) error {
+	if plan.Name == "" {
+		return fmt.Errorf("missing plan name. %w", ErrInvalidData)
+	}
+
+	limits := map[string]*int{
+		"maxActiveBookings":          plan.Limits.MaxActiveBookings,
+		"maxBookingsPerDay":          plan.Limits.MaxBookingsPerDay,
+		"maxBookingsPerClassPerWeek": plan.Limits.MaxBookingsPerClassPerWeek,
+	}
+	for name, limit := range limits {
+		if limit != nil && *limit <= 0 {
+			return fmt.Errorf("plan limit '%s' must be positive: %w", name, ErrInvalidData)
+		}
+	}
+
+	if plan.Limits.MaxGuestsPerBooking != nil && *plan.Limits.MaxGuestsPerBooking < 0 {
+		return fmt.Errorf("plan limit 'maxGuestsPerBooking' can't be negative: %w", ErrInvalidData)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package members_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daniel-oliveiravas/class-booking-service/business/members"
+	"github.com/daniel-oliveiravas/class-booking-service/business/members/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_AddPlan(t *testing.T) {
+	membersRepo := mocks.NewRepository(t)
+	usecase := members.NewUsecase(membersRepo)
+
+	minusOne, zero, five := -1, 0, 5
+	tests := []struct {
+		name    string
+		newPlan members.NewPlan
+		wantErr error
+	}{
+		{
+			name:    "without_name",
+			newPlan: members.NewPlan{},
+			wantErr: members.ErrInvalidData,
+		},
+		{
+			name:    "zero_limit",
+			newPlan: members.NewPlan{Name: uuid.NewString(), Limits: members.BookingLimits{MaxBookingsPerDay: &zero}},
+			wantErr: members.ErrInvalidData,
+		},
+		{
+			name:    "negative_guest_limit",
+			newPlan: members.NewPlan{Name: uuid.NewString(), Limits: members.BookingLimits{MaxGuestsPerBooking: &minusOne}},
+			wantErr: members.ErrInvalidData,
+		},
+		{
+			name:    "no_guests_plan",
+			newPlan: members.NewPlan{Name: uuid.NewString(), Limits: members.BookingLimits{MaxGuestsPerBooking: &zero}},
+			wantErr: nil,
+		},
+		{
+			name:    "valid_plan",
+			newPlan: members.NewPlan{Name: uuid.NewString(), Limits: members.BookingLimits{MaxActiveBookings: &five}},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wantErr == nil {
+				membersRepo.On("AddPlan", mock.Anything, mock.Anything).Return(members.Plan{Name: tt.newPlan.Name}, nil).Once()
+			}
+
+			plan, err := usecase.AddPlan(ctx, tt.newPlan)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.Equal(t, tt.newPlan.Name, plan.Name)
+		})
+	}
+}
+
+func TestUsecase_AddMember_UnknownPlan(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := mocks.NewRepository(t)
+	usecase := members.NewUsecase(membersRepo)
+
+	planID := uuid.NewString()
+	expectedErr := pgx.ErrNoRows
+	membersRepo.On("GetPlanByID", mock.Anything, planID).Return(members.Plan{}, expectedErr).Once()
+	membersRepo.On("IsNotFoundErr", expectedErr).Return(true).Once()
+
+	_, err := usecase.AddMember(ctx, members.NewMember{Name: uuid.NewString(), PlanID: &planID})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, members.ErrInvalidData))
+}
+
+func TestUsecase_BookingLimits(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := mocks.NewRepository(t)
+	usecase := members.NewUsecase(membersRepo)
+
+	one, two, ten := 1, 2, 10
+	studioLimits := members.BookingLimits{MaxActiveBookings: &ten, MaxBookingsPerDay: &two}
+
+	member := NewMember()
+	limits, err := usecase.BookingLimits(ctx, member, studioLimits)
+	require.NoError(t, err)
+	assert.Equal(t, studioLimits, limits)
+
+	plan := members.Plan{ID: uuid.NewString(), Limits: members.BookingLimits{MaxBookingsPerDay: &one}}
+	member.PlanID = &plan.ID
+	membersRepo.On("GetPlanByID", mock.Anything, plan.ID).Return(plan, nil).Once()
+
+	limits, err = usecase.BookingLimits(ctx, member, studioLimits)
+	require.NoError(t, err)
+	assert.Equal(t, members.BookingLimits{MaxActiveBookings: &ten, MaxBookingsPerDay: &one}, limits)
+}
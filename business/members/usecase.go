@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidData = errors.New("invalid data")
-	ErrNotFound    = errors.New("not found")
+	ErrInvalidData  = errors.New("invalid data")
+	ErrNotFound     = errors.New("not found")
+	ErrPlanNotFound = errors.New("plan not found")
+	// ErrVersionMismatch is returned by conditional updates of members that changed since the caller read them.
+	ErrVersionMismatch = errors.New("member version doesn't match")
 )
 
 type Usecase struct {
@@ -31,18 +35,30 @@ type Repository interface {
 	UpdateMember(ctx context.Context, memberID string, updateMember UpdateMember) (Member, error)
 	DeleteMember(ctx context.Context, memberID string) error
 	ListMembers(ctx context.Context, limit int, offset int) ([]Member, error)
+	SearchMembers(ctx context.Context, query string, limit int, offset int) ([]SearchResult, error)
+	AddPlan(ctx context.Context, plan Plan) (Plan, error)
+	GetPlanByID(ctx context.Context, planID string) (Plan, error)
+	ListPlans(ctx context.Context, limit int, offset int) ([]Plan, error)
 }
 
 func (u *Usecase) AddMember(ctx context.Context, newMember NewMember) (Member, error) {
 	member := Member{
-		ID:   uuid.NewString(),
-		Name: newMember.Name,
+		ID:      uuid.NewString(),
+		Name:    newMember.Name,
+		Credits: newMember.Credits,
+		PlanID:  newMember.PlanID,
 	}
 
 	if err := u.validateMember(member); err != nil {
 		return Member{}, err
 	}
 
+	if member.PlanID != nil {
+		if err := u.validatePlanID(ctx, *member.PlanID); err != nil {
+			return Member{}, err
+		}
+	}
+
 	addedMember, err := u.repository.AddMember(ctx, member)
 	if err != nil {
 		return Member{}, fmt.Errorf("failed to add member to repository: %w", err)
@@ -65,11 +81,28 @@ func (u *Usecase) GetByID(ctx context.Context, memberID string) (Member, error)
 }
 
 func (u *Usecase) UpdateMember(ctx context.Context, memberID string, updateMember UpdateMember) (Member, error) {
+	if updateMember.Credits != nil && *updateMember.Credits < 0 {
+		return Member{}, fmt.Errorf("member 'credits' cannot be negative: %w", ErrInvalidData)
+	}
+
+	if updateMember.Strikes != nil && *updateMember.Strikes < 0 {
+		return Member{}, fmt.Errorf("member 'strikes' cannot be negative: %w", ErrInvalidData)
+	}
+
+	if updateMember.PlanID != nil && *updateMember.PlanID != "" {
+		if err := u.validatePlanID(ctx, *updateMember.PlanID); err != nil {
+			return Member{}, err
+		}
+	}
+
 	updatedMember, err := u.repository.UpdateMember(ctx, memberID, updateMember)
 	if err != nil {
 		if u.repository.IsNotFoundErr(err) {
 			return Member{}, ErrNotFound
 		}
+		if errors.Is(err, ErrVersionMismatch) {
+			return Member{}, err
+		}
 		return Member{}, fmt.Errorf("failed to update member in repository: %w", err)
 	}
 
@@ -89,10 +122,49 @@ func (u *Usecase) ListMembers(ctx context.Context, pageInfo PageInfo) ([]Member,
 	return u.repository.ListMembers(ctx, pageInfo.Limit, offset)
 }
 
+// SearchMembers finds the members whose name contains the query or is similar to it, so partial
+// and misspelled names still find them. Results are ranked by similarity, best matches first.
+// Members don't have contact details yet, so names are all there is to search.
+func (u *Usecase) SearchMembers(ctx context.Context, query string, pageInfo PageInfo) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("missing search query 'q': %w", ErrInvalidData)
+	}
+
+	if pageInfo.Limit > 100 || pageInfo.Limit == 0 {
+		pageInfo.Limit = 100
+	}
+
+	offset := pageInfo.Limit * pageInfo.Page
+	results, err := u.repository.SearchMembers(ctx, query, pageInfo.Limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search members in repository: %w", err)
+	}
+
+	return results, nil
+}
+
 func (u *Usecase) validateMember(member Member) error {
 	if member.Name == "" {
 		return fmt.Errorf("missing member name. %w", ErrInvalidData)
 	}
 
+	if member.Credits < 0 {
+		return fmt.Errorf("member credits cannot be negative. %w", ErrInvalidData)
+	}
+
+	return nil
+}
+
+// validatePlanID checks members are only put on plans that exist.
+func (u *Usecase) validatePlanID(ctx context.Context, planID string) error {
+	_, err := u.GetPlanByID(ctx, planID)
+	if err != nil {
+		if errors.Is(err, ErrPlanNotFound) {
+			return fmt.Errorf("plan %s doesn't exist: %w", planID, ErrInvalidData)
+		}
+		return err
+	}
+
 	return nil
 }
@@ -3,6 +3,7 @@ package members_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -166,3 +167,40 @@ func TestUsecase_ListMembers(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, all)
 }
+
+func TestUsecase_SearchMembers(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := mocks.NewRepository(t)
+	usecase := members.NewUsecase(membersRepo)
+
+	expected := []members.SearchResult{{Member: NewMember(), Rank: 0.8}, {Member: NewMember(), Rank: 0.4}}
+	membersRepo.On("SearchMembers", mock.Anything, "marina", 20, 20).Return(expected, nil).Once()
+
+	results, err := usecase.SearchMembers(ctx, " marina ", members.PageInfo{Limit: 20, Page: 1})
+	require.NoError(t, err)
+	assert.Equal(t, expected, results)
+
+	_, err = usecase.SearchMembers(ctx, "", members.PageInfo{})
+	require.ErrorIs(t, err, members.ErrInvalidData)
+}
+
+func TestUsecase_UpdateMember_VersionMismatch(t *testing.T) {
+	ctx := context.Background()
+	membersRepo := mocks.NewRepository(t)
+	usecase := members.NewUsecase(membersRepo)
+
+	memberID := uuid.NewString()
+
+	newName := uuid.NewString()
+	updateMember := members.UpdateMember{
+		Name:       &newName,
+		IfVersions: []int{1},
+	}
+	expectedErr := fmt.Errorf("member is at version 2: %w", members.ErrVersionMismatch)
+	membersRepo.On("UpdateMember", mock.Anything, memberID, updateMember).Return(members.Member{}, expectedErr).Once()
+	membersRepo.On("IsNotFoundErr", expectedErr).Return(false).Once()
+
+	_, err := usecase.UpdateMember(ctx, memberID, updateMember)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, members.ErrVersionMismatch))
+}
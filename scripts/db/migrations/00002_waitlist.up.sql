@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS waitlist_entries
+(
+    id         TEXT      NOT NULL PRIMARY KEY,
+    member_id  TEXT      NOT NULL,
+    class_id   TEXT      NOT NULL,
+    class_date DATE      NOT NULL,
+    joined_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (member_id) REFERENCES members (id),
+    FOREIGN KEY (class_id) REFERENCES classes (id),
+    UNIQUE (member_id, class_id, class_date)
+);
+
+CREATE INDEX IF NOT EXISTS waitlist_entries_class_date_idx ON waitlist_entries (class_id, class_date, joined_at);
@@ -0,0 +1,30 @@
+-- Members could book the same class date several times. The oldest booking of each member, class
+-- and date is kept and the others are moved to bookings_duplicates, so they can still be reviewed.
+CREATE TABLE IF NOT EXISTS bookings_duplicates
+(
+    id              TEXT      NOT NULL PRIMARY KEY,
+    member_id       TEXT      NOT NULL,
+    class_id        TEXT      NOT NULL,
+    class_date      DATE      NOT NULL,
+    booked_at       TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL,
+    kept_booking_id TEXT      NOT NULL,
+    archived_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+WITH ranked AS (
+    SELECT id,
+           FIRST_VALUE(id) OVER same_booking AS kept_booking_id,
+           ROW_NUMBER() OVER same_booking    AS rank
+    FROM bookings
+    WINDOW same_booking AS (PARTITION BY member_id, class_id, class_date ORDER BY booked_at, id)
+)
+INSERT INTO bookings_duplicates (id, member_id, class_id, class_date, booked_at, updated_at, kept_booking_id)
+SELECT b.id, b.member_id, b.class_id, b.class_date, b.booked_at, b.updated_at, r.kept_booking_id
+FROM bookings b
+         JOIN ranked r ON r.id = b.id
+WHERE r.rank > 1;
+
+DELETE FROM bookings b USING bookings_duplicates d WHERE b.id = d.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookings_member_class_date_key ON bookings (member_id, class_id, class_date);
@@ -0,0 +1,15 @@
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS status       TEXT      NOT NULL DEFAULT 'booked',
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS attended_at  TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS no_show_at   TIMESTAMP NULL;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_status_check CHECK (status IN ('booked', 'cancelled', 'attended', 'no_show'));
+
+-- Cancelled bookings are kept, so a member must be able to book a class date again after cancelling it.
+DROP INDEX IF EXISTS bookings_member_class_date_key;
+CREATE UNIQUE INDEX IF NOT EXISTS bookings_member_class_date_active_key ON bookings (member_id, class_id, class_date)
+    WHERE status IN ('booked', 'attended', 'no_show');
+
+CREATE INDEX IF NOT EXISTS bookings_status_idx ON bookings (status);
@@ -0,0 +1,15 @@
+ALTER TABLE members
+    ADD COLUMN IF NOT EXISTS credits INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS strikes INT NOT NULL DEFAULT 0;
+
+-- Classes without a cancellation policy of their own follow the studio-wide policy.
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS cancellation_cutoff_minutes INT  NULL,
+    ADD COLUMN IF NOT EXISTS late_cancel_penalty         TEXT NULL;
+
+ALTER TABLE classes
+    ADD CONSTRAINT classes_late_cancel_penalty_check CHECK (late_cancel_penalty IN ('none', 'strike', 'credit'));
+
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS late_cancel    BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS cancel_penalty TEXT    NULL;
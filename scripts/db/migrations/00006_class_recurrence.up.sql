@@ -0,0 +1,3 @@
+-- Classes without a recurrence keep running every day of their date range.
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS recurrence JSONB NULL;
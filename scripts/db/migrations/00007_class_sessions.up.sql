@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS class_sessions
+(
+    id            TEXT      NOT NULL PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    class_id      TEXT      NOT NULL,
+    session_date  DATE      NOT NULL,
+    starts_at     TIMESTAMP NOT NULL,
+    ends_at       TIMESTAMP NOT NULL,
+    status        TEXT      NOT NULL DEFAULT 'scheduled',
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    capacity      INT       NULL,
+    instructor_id TEXT      NULL,
+    room_id       TEXT      NULL,
+    notes         TEXT      NULL,
+    FOREIGN KEY (class_id) REFERENCES classes (id) ON DELETE CASCADE,
+    CONSTRAINT class_sessions_class_date_key UNIQUE (class_id, session_date),
+    CONSTRAINT class_sessions_status_check CHECK (status IN ('scheduled', 'unscheduled')),
+    CONSTRAINT class_sessions_capacity_check CHECK (capacity IS NULL OR capacity > 0)
+);
+
+-- Existing bookings get the session of their class date. Sessions of classes without bookings
+-- are generated the next time the class is saved or booked.
+INSERT INTO class_sessions (class_id, session_date, starts_at, ends_at)
+SELECT booked.class_id,
+       booked.class_date,
+       booked.starts_at,
+       CASE
+           WHEN c.recurrence IS NOT NULL
+               THEN booked.starts_at + make_interval(mins => (c.recurrence ->> 'durationMinutes')::int)
+           WHEN c.end_date::time > booked.starts_at::time
+               THEN booked.class_date + c.end_date::time
+           ELSE (booked.class_date + 1)::timestamp
+           END
+FROM (SELECT DISTINCT b.class_id,
+                      b.class_date,
+                      b.class_date + COALESCE((c.recurrence ->> 'startTime')::time, c.start_date::time) AS starts_at
+      FROM bookings b
+               JOIN classes c ON c.id = b.class_id) booked
+         JOIN classes c ON c.id = booked.class_id
+ON CONFLICT (class_id, session_date) DO NOTHING;
+
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS session_id TEXT NULL REFERENCES class_sessions (id);
+
+UPDATE bookings b
+SET session_id = s.id
+FROM class_sessions s
+WHERE s.class_id = b.class_id
+  AND s.session_date = b.class_date;
+
+CREATE INDEX IF NOT EXISTS bookings_session_id_idx ON bookings (session_id);
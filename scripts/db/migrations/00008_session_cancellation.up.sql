@@ -0,0 +1,39 @@
+ALTER TABLE class_sessions
+    ADD COLUMN IF NOT EXISTS cancelled_at  TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS cancel_reason TEXT      NULL;
+
+ALTER TABLE class_sessions
+    DROP CONSTRAINT IF EXISTS class_sessions_status_check,
+    ADD CONSTRAINT class_sessions_status_check CHECK (status IN ('scheduled', 'unscheduled', 'cancelled'));
+
+-- Bookings of sessions cancelled by the studio. Credits taken by late cancels of those sessions
+-- are given back, and refunded_at keeps them from being refunded twice.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMP NULL;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_status_check,
+    ADD CONSTRAINT bookings_status_check CHECK (status IN ('booked', 'cancelled', 'attended', 'no_show', 'studio_cancelled'));
+
+ALTER TABLE waitlist_entries
+    ADD COLUMN IF NOT EXISTS status       TEXT      NOT NULL DEFAULT 'waiting',
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP NULL;
+
+ALTER TABLE waitlist_entries
+    ADD CONSTRAINT waitlist_entries_status_check CHECK (status IN ('waiting', 'studio_cancelled'));
+
+-- Outbox of events for members. Events are stored in the same transaction as the change that
+-- emits them and published_at is set once they are delivered.
+CREATE TABLE IF NOT EXISTS events
+(
+    id           TEXT      NOT NULL PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    type         TEXT      NOT NULL,
+    member_id    TEXT      NOT NULL,
+    payload      JSONB     NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP NULL,
+    FOREIGN KEY (member_id) REFERENCES members (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS events_member_id_idx ON events (member_id, created_at);
+CREATE INDEX IF NOT EXISTS events_unpublished_idx ON events (created_at) WHERE published_at IS NULL;
@@ -0,0 +1,3 @@
+-- Booking listings filter by member or class, usually together with a range of class dates.
+CREATE INDEX IF NOT EXISTS bookings_member_id_class_date_idx ON bookings (member_id, class_date);
+CREATE INDEX IF NOT EXISTS bookings_class_id_class_date_idx ON bookings (class_id, class_date);
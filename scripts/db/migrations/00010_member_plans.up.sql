@@ -0,0 +1,16 @@
+-- Plans set booking limits for their members. Limits left NULL fall back to the studio-wide ones.
+CREATE TABLE IF NOT EXISTS plans
+(
+    id                              TEXT      NOT NULL PRIMARY KEY,
+    created_at                      TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at                      TIMESTAMP NOT NULL DEFAULT NOW(),
+    name                            TEXT      NOT NULL,
+    max_active_bookings             INT       NULL,
+    max_bookings_per_day            INT       NULL,
+    max_bookings_per_class_per_week INT       NULL,
+    CONSTRAINT plans_limits_check CHECK (max_active_bookings > 0 AND max_bookings_per_day > 0 AND
+                                         max_bookings_per_class_per_week > 0)
+);
+
+ALTER TABLE members
+    ADD COLUMN IF NOT EXISTS plan_id TEXT NULL REFERENCES plans (id);
@@ -0,0 +1,16 @@
+-- Guests members bring along. Every guest takes a spot of the class date, on top of the one of
+-- the member, and the spots are released with the booking.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS guests      INT    NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS guest_names TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_guests_check CHECK (guests >= 0 AND cardinality(guest_names) <= guests);
+
+-- Unlike the other limits, a plan can set the guest limit to zero to keep its members from
+-- bringing guests.
+ALTER TABLE plans
+    ADD COLUMN IF NOT EXISTS max_guests_per_booking INT NULL;
+
+ALTER TABLE plans
+    ADD CONSTRAINT plans_max_guests_per_booking_check CHECK (max_guests_per_booking >= 0);
@@ -0,0 +1,24 @@
+-- Holds reserve spots of a class date for a few minutes, while the member checks out. Held holds
+-- take spots until they expire, and confirming a hold turns it into a booking with the same ID.
+CREATE TABLE IF NOT EXISTS booking_holds
+(
+    id           TEXT      NOT NULL PRIMARY KEY,
+    member_id    TEXT      NOT NULL,
+    class_id     TEXT      NOT NULL,
+    class_date   DATE      NOT NULL,
+    guests       INT       NOT NULL DEFAULT 0,
+    guest_names  TEXT[]    NOT NULL DEFAULT '{}',
+    status       TEXT      NOT NULL DEFAULT 'held',
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP NULL,
+    released_at  TIMESTAMP NULL,
+    booking_id   TEXT      NULL,
+    FOREIGN KEY (member_id) REFERENCES members (id),
+    FOREIGN KEY (class_id) REFERENCES classes (id),
+    CONSTRAINT booking_holds_status_check CHECK (status IN ('held', 'confirmed', 'released')),
+    CONSTRAINT booking_holds_guests_check CHECK (guests >= 0 AND cardinality(guest_names) <= guests)
+);
+
+CREATE INDEX IF NOT EXISTS booking_holds_class_date_idx ON booking_holds (class_id, class_date) WHERE status = 'held';
+CREATE INDEX IF NOT EXISTS booking_holds_expires_at_idx ON booking_holds (expires_at) WHERE status = 'held';
@@ -0,0 +1,10 @@
+-- Versions count the updates of a row. They are returned as ETags, so clients can make an update
+-- conditional on the row not having changed since they read it.
+ALTER TABLE members
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- Archived classes are hidden from listings and can't be booked anymore, but keep their sessions
+-- and bookings.
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP NULL;
@@ -0,0 +1,21 @@
+-- Instructors teach classes. A class instructor teaches every session of the class, unless the
+-- session names an instructor of its own. Deleting an instructor unassigns them.
+CREATE TABLE IF NOT EXISTS instructors
+(
+    id         TEXT      NOT NULL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    name       TEXT      NOT NULL,
+    email      TEXT      NULL
+);
+
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS instructor_id TEXT NULL REFERENCES instructors (id) ON DELETE SET NULL;
+
+-- Sessions took free-form instructor IDs before, so only new ones have to reference instructors.
+ALTER TABLE class_sessions
+    ADD CONSTRAINT class_sessions_instructor_fk FOREIGN KEY (instructor_id) REFERENCES instructors (id)
+        ON DELETE SET NULL NOT VALID;
+
+CREATE INDEX IF NOT EXISTS classes_instructor_idx ON classes (instructor_id) WHERE instructor_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS class_sessions_instructor_idx ON class_sessions (instructor_id) WHERE instructor_id IS NOT NULL;
@@ -0,0 +1,33 @@
+-- Locations are the studios, each with its own rooms. Timezones are IANA names.
+CREATE TABLE IF NOT EXISTS locations
+(
+    id         TEXT      NOT NULL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    name       TEXT      NOT NULL,
+    address    TEXT      NOT NULL,
+    timezone   TEXT      NOT NULL
+);
+
+-- Rooms hold at most capacity people, so classes taught in them can't take more bookings.
+CREATE TABLE IF NOT EXISTS rooms
+(
+    id          TEXT      NOT NULL PRIMARY KEY,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    location_id TEXT      NOT NULL REFERENCES locations (id),
+    name        TEXT      NOT NULL,
+    capacity    INTEGER   NOT NULL CHECK (capacity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS rooms_location_idx ON rooms (location_id);
+
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS room_id TEXT NULL REFERENCES rooms (id);
+
+-- Sessions took free-form room IDs before, so only new ones have to reference rooms.
+ALTER TABLE class_sessions
+    ADD CONSTRAINT class_sessions_room_fk FOREIGN KEY (room_id) REFERENCES rooms (id) NOT VALID;
+
+CREATE INDEX IF NOT EXISTS classes_room_idx ON classes (room_id) WHERE room_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS class_sessions_room_idx ON class_sessions (room_id) WHERE room_id IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Classes are described to the members browsing them. Categories and tags are stored lowercase.
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS description      TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS category         TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags             TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS level            TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS duration_minutes INT    NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS image_url        TEXT   NOT NULL DEFAULT '';
+
+ALTER TABLE classes
+    ADD CONSTRAINT classes_level_check CHECK (level IN ('', 'beginner', 'intermediate', 'advanced', 'all')),
+    ADD CONSTRAINT classes_duration_minutes_check CHECK (duration_minutes >= 0);
+
+CREATE INDEX IF NOT EXISTS classes_category_idx ON classes (category) WHERE archived_at IS NULL;
+CREATE INDEX IF NOT EXISTS classes_level_idx ON classes (level) WHERE archived_at IS NULL;
+CREATE INDEX IF NOT EXISTS classes_tags_idx ON classes USING GIN (tags);
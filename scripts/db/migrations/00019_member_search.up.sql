@@ -0,0 +1,5 @@
+-- Members are searched by trigram similarity on their name. The extension lives in public, so
+-- schemas that don't have public in their search path qualify its operators and functions.
+CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+
+CREATE INDEX IF NOT EXISTS members_name_trgm_idx ON members USING GIN (name public.gin_trgm_ops);
@@ -0,0 +1,11 @@
+-- Credits each booking took from its member, so cancelling its session gives back exactly what it
+-- consumed. Credits taken by late cancels before this column existed were always one.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS credits_used INT NOT NULL DEFAULT 0;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_credits_used_check CHECK (credits_used >= 0);
+
+UPDATE bookings
+SET credits_used = 1
+WHERE cancel_penalty = 'credit';
@@ -0,0 +1,4 @@
+-- Deleted classes are archived and hidden everywhere, but their sessions, bookings, holds and
+-- waitlist entries are kept as the members' history.
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL;
@@ -0,0 +1,18 @@
+-- Timezones are IANA time zone names the services load, so names Postgres doesn't know can't be
+-- stored either.
+CREATE OR REPLACE FUNCTION is_timezone(name TEXT) RETURNS BOOLEAN AS
+$$
+BEGIN
+    PERFORM now() AT TIME ZONE name;
+    RETURN TRUE;
+EXCEPTION
+    WHEN invalid_parameter_value THEN
+        RETURN FALSE;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+ALTER TABLE classes
+    ADD CONSTRAINT classes_timezone_check CHECK (timezone <> '' AND is_timezone(timezone));
+
+ALTER TABLE locations
+    ADD CONSTRAINT locations_timezone_check CHECK (timezone <> '' AND is_timezone(timezone));
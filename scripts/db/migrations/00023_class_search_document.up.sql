@@ -0,0 +1,49 @@
+-- Classes are searched by a document built from their name, tags and description and the name of
+-- their instructor. It's stored on the classes, kept up to date by triggers, so that searches can
+-- use an index.
+CREATE OR REPLACE FUNCTION class_search_document(name TEXT, tags TEXT[], description TEXT, instructor_id TEXT) RETURNS TSVECTOR AS
+$$
+SELECT setweight(to_tsvector('english', name), 'A') ||
+       setweight(to_tsvector('english', array_to_string(tags, ' ')), 'B') ||
+       setweight(to_tsvector('english', COALESCE((SELECT i.name FROM instructors i WHERE i.id = instructor_id), '')), 'B') ||
+       setweight(to_tsvector('english', description), 'C')
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS search_document TSVECTOR NOT NULL DEFAULT ''::TSVECTOR;
+
+UPDATE classes
+SET search_document = class_search_document(name, tags, description, instructor_id);
+
+CREATE OR REPLACE FUNCTION classes_search_document_trigger() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.search_document := class_search_document(NEW.name, NEW.tags, NEW.description, NEW.instructor_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER classes_search_document
+    BEFORE INSERT OR UPDATE OF name, tags, description, instructor_id
+    ON classes
+    FOR EACH ROW
+EXECUTE FUNCTION classes_search_document_trigger();
+
+-- Renaming an instructor changes the documents of the classes they teach.
+CREATE OR REPLACE FUNCTION instructors_search_document_trigger() RETURNS TRIGGER AS
+$$
+BEGIN
+    UPDATE classes
+    SET search_document = class_search_document(name, tags, description, instructor_id)
+    WHERE instructor_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER instructors_search_document
+    AFTER UPDATE OF name
+    ON instructors
+    FOR EACH ROW
+EXECUTE FUNCTION instructors_search_document_trigger();
+
+CREATE INDEX IF NOT EXISTS classes_search_document_idx ON classes USING GIN (search_document) WHERE archived_at IS NULL;